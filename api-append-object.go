@@ -63,7 +63,7 @@ func (opts *AppendObjectOptions) setWriteOffset(offset int64) {
 	if len(opts.customHeaders) == 0 {
 		opts.customHeaders = make(http.Header)
 	}
-	opts.customHeaders["x-amz-write-offset-bytes"] = []string{strconv.FormatInt(offset, 10)}
+	opts.customHeaders.Set(amzWriteOffsetBytes, strconv.FormatInt(offset, 10))
 }
 
 func (opts *AppendObjectOptions) setChecksumParams(info ObjectInfo) {
@@ -108,7 +108,7 @@ func (opts AppendObjectOptions) validate(c *Client) (err error) {
 // NOTE: You must have WRITE permissions on a bucket to add an object to it.
 func (c *Client) appendObjectDo(ctx context.Context, bucketName, objectName string, reader io.Reader, size int64, opts AppendObjectOptions) (UploadInfo, error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return UploadInfo{}, err
 	}
 	if err := s3utils.CheckValidObjectName(objectName); err != nil {
@@ -156,6 +156,9 @@ func (c *Client) appendObjectDo(ctx context.Context, bucketName, objectName stri
 		if err != nil {
 			return UploadInfo{}, err
 		}
+	} else if offset, err := strconv.ParseInt(customHeader.Get(amzWriteOffsetBytes), 10, 64); err == nil {
+		// Otherwise the object size is the offset of the appended data.
+		size += offset
 	}
 
 	return UploadInfo{
@@ -193,41 +196,92 @@ func (c *Client) AppendObject(ctx context.Context, bucketName, objectName string
 	if oinfo.ChecksumMode != "" && oinfo.ChecksumMode != ChecksumFullObjectMode.String() {
 		return UploadInfo{}, fmt.Errorf("Append() is not allowed on objects that are not of FULL_OBJECT checksum type: %s", oinfo.ChecksumMode)
 	}
-	opts.setChecksumParams(oinfo)   // set the appropriate checksum params based on the existing object checksum metadata.
-	opts.setWriteOffset(oinfo.Size) // First append must set the current object size as the offset.
+	opts.setChecksumParams(oinfo) // set the appropriate checksum params based on the existing object checksum metadata.
 
-	if opts.ChunkSize > 0 {
-		finalObjSize := int64(-1)
-		if objectSize > 0 {
-			finalObjSize = info.Size + objectSize
+	// First append must set the current object size as the offset.
+	return c.appendObjectAt(ctx, bucketName, objectName, reader, objectSize, oinfo.Size, opts)
+}
+
+// AppendObjectAt appends the content of reader to an object whose size
+// is expected to be offset, sent as x-amz-write-offset-bytes, without
+// fetching the object size first. The returned UploadInfo.Size is the
+// new size of the object, the offset of the next append.
+//
+// The server rejects the append if the object size is not offset, for
+// example when another client appended to it concurrently, with an
+// error matching ErrWriteOffsetMismatch through errors.Is: the append
+// can then be retried at the current object size. With opts.ChunkSize
+// set the content is appended in several requests, of which only the
+// failed one and the following ones must be retried.
+//
+// Unlike AppendObject, the appended data is not added to the full
+// object checksum of the object.
+func (c *Client) AppendObjectAt(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize, offset int64,
+	opts AppendObjectOptions,
+) (UploadInfo, error) {
+	if objectSize < 0 && opts.ChunkSize == 0 {
+		return UploadInfo{}, errors.New("object size must be provided when no chunk size is provided")
+	}
+	if offset < 0 {
+		return UploadInfo{}, errInvalidArgument("Write offset cannot be negative.")
+	}
+	if err := opts.validate(c); err != nil {
+		return UploadInfo{}, err
+	}
+	return c.appendObjectAt(ctx, bucketName, objectName, reader, objectSize, offset, opts)
+}
+
+// appendObjectAt appends the content of reader at offset, in chunks of
+// opts.ChunkSize if set.
+func (c *Client) appendObjectAt(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize, offset int64,
+	opts AppendObjectOptions,
+) (info UploadInfo, err error) {
+	opts.setWriteOffset(offset)
+	if opts.ChunkSize == 0 {
+		return c.appendObjectDo(ctx, bucketName, objectName, newHook(reader, opts.Progress), objectSize, opts)
+	}
+
+	chunkSize := int64(opts.ChunkSize)
+	if objectSize >= 0 {
+		chunkSize = min(chunkSize, objectSize)
+	}
+	buf := make([]byte, chunkSize)
+	remaining := objectSize
+	for {
+		size := chunkSize
+		if remaining >= 0 {
+			size = min(size, remaining)
+		}
+		n, err := readFull(reader, buf[:size])
+		last := remaining >= 0 && int64(n) == remaining
+		if objectSize < 0 {
+			// The content of unknown size ends with the first short read.
+			switch err {
+			case io.EOF:
+				if info.Key != "" {
+					return info, nil
+				}
+				err = nil
+				last = true
+			case io.ErrUnexpectedEOF:
+				err = nil
+				last = true
+			}
 		}
-		totalPartsCount, partSize, lastPartSize, err := OptimalPartInfo(finalObjSize, opts.ChunkSize)
 		if err != nil {
-			return UploadInfo{}, err
+			return info, err
 		}
-		buf := make([]byte, partSize)
-		var partNumber int
-		for partNumber = 1; partNumber <= totalPartsCount; partNumber++ {
-			// Proceed to upload the part.
-			if partNumber == totalPartsCount {
-				partSize = lastPartSize
-			}
-			n, err := readFull(reader, buf)
-			if err != nil {
-				return info, err
-			}
-			if n != int(partSize) {
-				return info, io.ErrUnexpectedEOF
-			}
-			rd := newHook(bytes.NewReader(buf[:n]), opts.Progress)
-			uinfo, err := c.appendObjectDo(ctx, bucketName, objectName, rd, partSize, opts)
-			if err != nil {
-				return info, err
-			}
-			opts.setWriteOffset(uinfo.Size)
+		rd := newHook(bytes.NewReader(buf[:n]), opts.Progress)
+		info, err = c.appendObjectDo(ctx, bucketName, objectName, rd, int64(n), opts)
+		if err != nil {
+			return info, err
+		}
+		if last {
+			return info, nil
+		}
+		opts.setWriteOffset(info.Size)
+		if remaining >= 0 {
+			remaining -= int64(n)
 		}
 	}
-
-	rd := newHook(reader, opts.Progress)
-	return c.appendObjectDo(ctx, bucketName, objectName, rd, objectSize, opts)
 }
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openstor/openstor-go/v7/pkg/credentials"
+)
+
+func TestAppendObjectAt(t *testing.T) {
+	var stored []byte
+	var appends int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodHead:
+			w.Header().Set("Content-Length", strconv.Itoa(len(stored)))
+			w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+			return
+		case http.MethodPut:
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			return
+		}
+		offset, err := strconv.Atoi(r.Header.Get("x-amz-write-offset-bytes"))
+		if err != nil || offset != len(stored) {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `<Error><Code>InvalidWriteOffset</Code><Message>The write offset value that you specified does not match the current object size.</Message></Error>`)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		stored = append(stored, body...)
+		appends++
+		// Only report the object size every other append.
+		if appends%2 == 0 {
+			w.Header().Set("x-amz-object-size", strconv.Itoa(len(stored)))
+		}
+	}))
+	defer srv.Close()
+
+	// Use anonymous credentials to receive the raw data.
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:           credentials.NewStaticV4("", "", ""),
+		Region:          "us-east-1",
+		TrailingHeaders: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	info, err := clnt.AppendObjectAt(ctx, "bucket", "object", strings.NewReader("hello"), 5, 0, AppendObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size != 5 {
+		t.Fatalf("expected object size 5, got %d", info.Size)
+	}
+
+	// Content of unknown size is appended in chunks.
+	info, err = clnt.AppendObjectAt(ctx, "bucket", "object", strings.NewReader(" world"), -1, info.Size, AppendObjectOptions{ChunkSize: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size != 11 || string(stored) != "hello world" || appends != 3 {
+		t.Fatalf("unexpected object %q of size %d after %d appends", stored, info.Size, appends)
+	}
+
+	// A stale offset is reported distinctly.
+	_, err = clnt.AppendObjectAt(ctx, "bucket", "object", strings.NewReader("!"), 1, 5, AppendObjectOptions{})
+	if !errors.Is(err, ErrWriteOffsetMismatch) {
+		t.Fatalf("expected ErrWriteOffsetMismatch, got %v", err)
+	}
+	if string(stored) != "hello world" {
+		t.Fatalf("unexpected object %q", stored)
+	}
+
+	// AppendObject appends at the current object size.
+	info, err = clnt.AppendObject(ctx, "bucket", "object", strings.NewReader("!!"), 2, AppendObjectOptions{ChunkSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size != 13 || string(stored) != "hello world!!" {
+		t.Fatalf("unexpected object %q of size %d", stored, info.Size)
+	}
+
+	if _, err = clnt.AppendObjectAt(ctx, "bucket", "object", strings.NewReader("!"), 1, -1, AppendObjectOptions{}); ToErrorResponse(err).Code != InvalidArgument {
+		t.Fatalf("expected negative offset to be rejected, got %v", err)
+	}
+}
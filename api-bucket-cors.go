@@ -26,7 +26,6 @@ import (
 	"net/url"
 
 	"github.com/openstor/openstor-go/v7/pkg/cors"
-	"github.com/openstor/openstor-go/v7/pkg/s3utils"
 )
 
 // SetBucketCors sets the Cross-Origin Resource Sharing (CORS) configuration for the bucket.
@@ -39,7 +38,7 @@ import (
 //
 // Returns an error if the operation fails.
 func (c *Client) SetBucketCors(ctx context.Context, bucketName string, corsConfig *cors.Config) error {
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 
@@ -80,6 +79,21 @@ func (c *Client) putBucketCors(ctx context.Context, bucketName string, corsConfi
 	return nil
 }
 
+// RemoveBucketCors removes the Cross-Origin Resource Sharing (CORS) configuration from the bucket.
+// This is equivalent to calling SetBucketCors with a nil configuration.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//   - bucketName: Name of the bucket
+//
+// Returns an error if the operation fails.
+func (c *Client) RemoveBucketCors(ctx context.Context, bucketName string) error {
+	if err := c.checkBucketName(bucketName); err != nil {
+		return err
+	}
+	return c.removeBucketCors(ctx, bucketName)
+}
+
 func (c *Client) removeBucketCors(ctx context.Context, bucketName string) error {
 	urlValues := make(url.Values)
 	urlValues.Set("cors", "")
@@ -110,7 +124,7 @@ func (c *Client) removeBucketCors(ctx context.Context, bucketName string) error
 //
 // Returns the CORS configuration or an error if the operation fails.
 func (c *Client) GetBucketCors(ctx context.Context, bucketName string) (*cors.Config, error) {
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return nil, err
 	}
 	bucketCors, err := c.getBucketCors(ctx, bucketName)
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestRemoveBucketCors(t *testing.T) {
+	removed := false
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["cors"]; !ok || r.Method != http.MethodDelete {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		removed = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	if err := clnt.RemoveBucketCors(context.Background(), "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if !removed {
+		t.Fatal("expected CORS configuration to be removed")
+	}
+}
@@ -26,7 +26,6 @@ import (
 	"net/http"
 	"net/url"
 
-	"github.com/openstor/openstor-go/v7/pkg/s3utils"
 	"github.com/openstor/openstor-go/v7/pkg/sse"
 )
 
@@ -41,7 +40,7 @@ import (
 // Returns an error if the operation fails or if config is nil.
 func (c *Client) SetBucketEncryption(ctx context.Context, bucketName string, config *sse.Configuration) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 
@@ -91,7 +90,7 @@ func (c *Client) SetBucketEncryption(ctx context.Context, bucketName string, con
 // Returns an error if the operation fails.
 func (c *Client) RemoveBucketEncryption(ctx context.Context, bucketName string) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 
@@ -126,7 +125,7 @@ func (c *Client) RemoveBucketEncryption(ctx context.Context, bucketName string)
 // Returns the bucket's encryption configuration or an error if the operation fails.
 func (c *Client) GetBucketEncryption(ctx context.Context, bucketName string) (*sse.Configuration, error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return nil, err
 	}
 
@@ -30,13 +30,12 @@ import (
 	"time"
 
 	"github.com/openstor/openstor-go/v7/pkg/lifecycle"
-	"github.com/openstor/openstor-go/v7/pkg/s3utils"
 )
 
 // SetBucketLifecycle set the lifecycle on an existing bucket.
 func (c *Client) SetBucketLifecycle(ctx context.Context, bucketName string, config *lifecycle.Configuration) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 
@@ -113,7 +112,7 @@ func (c *Client) GetBucketLifecycle(ctx context.Context, bucketName string) (*li
 // GetBucketLifecycleWithInfo fetch bucket lifecycle configuration along with when it was last updated
 func (c *Client) GetBucketLifecycleWithInfo(ctx context.Context, bucketName string) (*lifecycle.Configuration, time.Time, error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return nil, time.Time{}, err
 	}
 
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/openstor/openstor-go/v7/pkg/lifecycle"
+)
+
+func TestBucketLifecycleTransitions(t *testing.T) {
+	var stored []byte
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !r.URL.Query().Has("lifecycle") {
+			t.Errorf("expected lifecycle query, got %s", r.URL.RawQuery)
+		}
+		switch r.Method {
+		case http.MethodPut:
+			stored, _ = io.ReadAll(r.Body)
+		case http.MethodGet:
+			w.Write(stored)
+		}
+	}))
+
+	config := lifecycle.NewConfiguration()
+	config.Rules = []lifecycle.Rule{
+		{
+			ID:     "tier",
+			Status: "Enabled",
+			RuleFilter: lifecycle.Filter{
+				Prefix: "logs/",
+			},
+			Transition: lifecycle.Transition{
+				Days:         30,
+				StorageClass: "WARM-TIER",
+			},
+			NoncurrentVersionTransition: lifecycle.NoncurrentVersionTransition{
+				NoncurrentDays:          7,
+				NewerNoncurrentVersions: 3,
+				StorageClass:            "COLD-TIER",
+			},
+		},
+	}
+
+	ctx := context.Background()
+	if err := clnt.SetBucketLifecycle(ctx, "bucket", config); err != nil {
+		t.Fatal(err)
+	}
+	got, err := clnt.GetBucketLifecycle(ctx, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d", len(got.Rules))
+	}
+	rule := got.Rules[0]
+	if tr := rule.Transition; tr.Days != 30 || tr.StorageClass != "WARM-TIER" {
+		t.Fatalf("unexpected transition %+v", tr)
+	}
+	if nt := rule.NoncurrentVersionTransition; nt.NoncurrentDays != 7 || nt.NewerNoncurrentVersions != 3 || nt.StorageClass != "COLD-TIER" {
+		t.Fatalf("unexpected noncurrent version transition %+v", nt)
+	}
+	if rule.RuleFilter.Prefix != "logs/" {
+		t.Fatalf("unexpected filter %+v", rule.RuleFilter)
+	}
+}
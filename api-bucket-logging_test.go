@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestBucketLogging(t *testing.T) {
+	stored := `<BucketLoggingStatus xmlns="http://doc.s3.amazonaws.com/2006-03-01"></BucketLoggingStatus>`
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["logging"]; !ok {
+			t.Errorf("expected logging query, got %s", r.URL.RawQuery)
+		}
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			stored = string(body)
+		case http.MethodGet:
+			io.WriteString(w, stored)
+		}
+	}))
+
+	ctx := context.Background()
+	config, err := clnt.GetBucketLogging(ctx, "bucket")
+	if err != nil || config != nil {
+		t.Fatalf("expected logging to be disabled, got %+v, %v", config, err)
+	}
+
+	testCases := []struct {
+		config   *BucketLoggingConfig
+		expected string
+	}{
+		{
+			&BucketLoggingConfig{TargetBucket: "logs", TargetPrefix: "bucket/"},
+			`<BucketLoggingStatus xmlns="http://doc.s3.amazonaws.com/2006-03-01"><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>bucket/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+		},
+		{
+			&BucketLoggingConfig{
+				TargetBucket: "logs",
+				TargetGrants: []LoggingGrant{
+					{Grantee: LoggingGrantee{Type: GranteeCanonicalUser, ID: "owner-id"}, Permission: "FULL_CONTROL"},
+					{Grantee: LoggingGrantee{Type: GranteeGroup, URI: "http://acs.amazonaws.com/groups/global/AllUsers"}, Permission: "READ"},
+				},
+			},
+			`<BucketLoggingStatus xmlns="http://doc.s3.amazonaws.com/2006-03-01"><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetGrants>` +
+				`<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser"><ID>owner-id</ID></Grantee><Permission>FULL_CONTROL</Permission></Grant>` +
+				`<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="Group"><URI>http://acs.amazonaws.com/groups/global/AllUsers</URI></Grantee><Permission>READ</Permission></Grant>` +
+				`</TargetGrants><TargetPrefix></TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+		},
+		{
+			nil,
+			`<BucketLoggingStatus xmlns="http://doc.s3.amazonaws.com/2006-03-01"></BucketLoggingStatus>`,
+		},
+		{
+			&BucketLoggingConfig{},
+			`<BucketLoggingStatus xmlns="http://doc.s3.amazonaws.com/2006-03-01"></BucketLoggingStatus>`,
+		},
+	}
+
+	for i, testCase := range testCases {
+		if err = clnt.SetBucketLogging(ctx, "bucket", testCase.config); err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if stored != testCase.expected {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.expected, stored)
+		}
+		config, err = clnt.GetBucketLogging(ctx, "bucket")
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		expected := testCase.config
+		if expected != nil && expected.TargetBucket == "" {
+			expected = nil
+		}
+		if !reflect.DeepEqual(config, expected) {
+			t.Fatalf("Test %d: expected %+v, got %+v", i+1, expected, config)
+		}
+	}
+
+	if err = clnt.SetBucketLogging(ctx, "bucket", &BucketLoggingConfig{TargetPrefix: "logs/"}); err == nil {
+		t.Fatal("expected a missing target bucket to fail")
+	}
+}
@@ -26,10 +26,13 @@ import (
 	"context"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"time"
 
+	"github.com/openstor/openstor-go/v7/pkg/arn"
 	"github.com/openstor/openstor-go/v7/pkg/notification"
 	"github.com/openstor/openstor-go/v7/pkg/s3utils"
 )
@@ -37,7 +40,11 @@ import (
 // SetBucketNotification saves a new bucket notification with a context to control cancellations and timeouts.
 func (c *Client) SetBucketNotification(ctx context.Context, bucketName string, config notification.Configuration) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
+		return err
+	}
+
+	if err := validateNotificationARNs(config); err != nil {
 		return err
 	}
 
@@ -75,6 +82,27 @@ func (c *Client) SetBucketNotification(ctx context.Context, bucketName string, c
 	return nil
 }
 
+// validateNotificationARNs checks the ARNs referenced by config, naming
+// the malformed field in the returned error.
+func validateNotificationARNs(config notification.Configuration) error {
+	for i, topic := range config.TopicConfigs {
+		if _, err := arn.Parse(topic.Topic); err != nil {
+			return errInvalidArgument(fmt.Sprintf("TopicConfigs[%d].Topic: %v", i, err))
+		}
+	}
+	for i, queue := range config.QueueConfigs {
+		if _, err := arn.Parse(queue.Queue); err != nil {
+			return errInvalidArgument(fmt.Sprintf("QueueConfigs[%d].Queue: %v", i, err))
+		}
+	}
+	for i, lambda := range config.LambdaConfigs {
+		if _, err := arn.Parse(lambda.Lambda); err != nil {
+			return errInvalidArgument(fmt.Sprintf("LambdaConfigs[%d].Lambda: %v", i, err))
+		}
+	}
+	return nil
+}
+
 // RemoveAllBucketNotification - Remove bucket notification clears all previously specified config
 func (c *Client) RemoveAllBucketNotification(ctx context.Context, bucketName string) error {
 	return c.SetBucketNotification(ctx, bucketName, notification.Configuration{})
@@ -83,7 +111,7 @@ func (c *Client) RemoveAllBucketNotification(ctx context.Context, bucketName str
 // GetBucketNotification returns current bucket notification configuration
 func (c *Client) GetBucketNotification(ctx context.Context, bucketName string) (bucketNotification notification.Configuration, err error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return notification.Configuration{}, err
 	}
 	return c.getBucketNotification(ctx, bucketName)
@@ -127,7 +155,12 @@ func (c *Client) ListenNotification(ctx context.Context, prefix, suffix string,
 	return c.ListenBucketNotification(ctx, "", prefix, suffix, events)
 }
 
-// ListenBucketNotification listen for bucket events, this is a MinIO specific API
+// ListenBucketNotification listen for bucket events, this is a MinIO specific API.
+// The returned channel yields the decoded event records of the bucket
+// matching the prefix, suffix and events. Transient errors, such as network
+// failures or a server being unavailable, are sent on the channel before
+// reconnecting; the channel is closed after any other error or once ctx
+// is cancelled.
 func (c *Client) ListenBucketNotification(ctx context.Context, bucketName, prefix, suffix string, events []string) <-chan notification.Info {
 	notificationInfoCh := make(chan notification.Info, 1)
 	const notificationCapacity = 4 * 1024 * 1024
@@ -138,7 +171,7 @@ func (c *Client) ListenBucketNotification(ctx context.Context, bucketName, prefi
 
 		// Validate the bucket name.
 		if bucketName != "" {
-			if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+			if err := c.checkBucketName(bucketName); err != nil {
 				select {
 				case notificationInfoCh <- notification.Info{
 					Err: err,
@@ -174,13 +207,22 @@ func (c *Client) ListenBucketNotification(ctx context.Context, bucketName, prefi
 				bucketName:       bucketName,
 				queryValues:      urlValues,
 				contentSHA256Hex: emptySHA256Hex,
+				// The listen request lasts until ctx is cancelled.
+				streamingResponse: true,
 			})
 			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
 				select {
 				case notificationInfoCh <- notification.Info{
 					Err: err,
 				}:
 				case <-ctx.Done():
+					return
+				}
+				if isListenErrorTransient(ctx, err) {
+					continue
 				}
 				return
 			}
@@ -236,6 +278,10 @@ func (c *Client) ListenBucketNotification(ctx context.Context, bucketName, prefi
 			}
 
 			if err = bio.Err(); err != nil {
+				if ctx.Err() != nil {
+					closeResponse(resp)
+					return
+				}
 				select {
 				case notificationInfoCh <- notification.Info{
 					Err: err,
@@ -253,3 +299,13 @@ func (c *Client) ListenBucketNotification(ctx context.Context, bucketName, prefi
 	// Returns the notification info channel, for caller to start reading from.
 	return notificationInfoCh
 }
+
+// isListenErrorTransient tells whether listening for notifications
+// should be resumed after the given error.
+func isListenErrorTransient(ctx context.Context, err error) bool {
+	var errResp ErrorResponse
+	if errors.As(err, &errResp) {
+		return isHTTPStatusRetryable(errResp.StatusCode) || isS3CodeRetryable(errResp.Code)
+	}
+	return isRequestErrorRetryable(ctx, err)
+}
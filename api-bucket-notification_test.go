@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openstor/openstor-go/v7/pkg/credentials"
+	"github.com/openstor/openstor-go/v7/pkg/notification"
+)
+
+func TestBucketNotification(t *testing.T) {
+	var stored []byte
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !r.URL.Query().Has("notification") {
+			t.Errorf("expected notification query, got %s", r.URL.RawQuery)
+		}
+		switch r.Method {
+		case http.MethodPut:
+			stored, _ = io.ReadAll(r.Body)
+		case http.MethodGet:
+			w.Write(stored)
+		}
+	}))
+
+	queueArn := notification.NewArn("minio", "sqs", "us-east-1", "1", "webhook")
+	queueConfig := notification.NewConfig(queueArn)
+	queueConfig.ID = "images"
+	queueConfig.AddEvents(notification.ObjectCreatedAll, notification.ObjectRemovedAll)
+	queueConfig.AddFilterPrefix("images/")
+	queueConfig.AddFilterSuffix(".jpg")
+
+	topicConfig := notification.NewConfig(notification.NewArn("aws", "sns", "us-east-1", "1", "topic"))
+	topicConfig.ID = "uploads"
+	topicConfig.AddEvents(notification.ObjectCreatedPut)
+
+	lambdaConfig := notification.NewConfig(notification.NewArn("aws", "lambda", "us-east-1", "1", "function"))
+	lambdaConfig.AddEvents(notification.ObjectRemovedDelete)
+	lambdaConfig.AddFilterSuffix(".tmp")
+
+	var config notification.Configuration
+	config.AddQueue(queueConfig)
+	config.AddTopic(topicConfig)
+	config.AddLambda(lambdaConfig)
+
+	ctx := context.Background()
+	if err := clnt.SetBucketNotification(ctx, "bucket", config); err != nil {
+		t.Fatal(err)
+	}
+	got, err := clnt.GetBucketNotification(ctx, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.QueueConfigs) != 1 || len(got.TopicConfigs) != 1 || len(got.LambdaConfigs) != 1 {
+		t.Fatalf("unexpected configuration %+v", got)
+	}
+	queue := got.QueueConfigs[0]
+	if queue.ID != "images" || queue.Queue != queueArn.String() || !queue.Equal(queueConfig.Events, "images/", ".jpg") {
+		t.Fatalf("unexpected queue config %+v", queue)
+	}
+	if topic := got.TopicConfigs[0]; topic.ID != "uploads" || !topic.Equal(topicConfig.Events, "", "") {
+		t.Fatalf("unexpected topic config %+v", topic)
+	}
+	if lambda := got.LambdaConfigs[0]; !lambda.Equal(lambdaConfig.Events, "", ".tmp") {
+		t.Fatalf("unexpected lambda config %+v", lambda)
+	}
+
+	// Configurations read back are recognized as already present, such
+	// that they can be reconciled without duplicating them.
+	if got.AddQueue(queueConfig) || got.AddTopic(topicConfig) || got.AddLambda(lambdaConfig) {
+		t.Fatalf("expected existing configs to be recognized, got %+v", got)
+	}
+
+	if err = clnt.RemoveAllBucketNotification(ctx, "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = clnt.GetBucketNotification(ctx, "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.QueueConfigs)+len(got.TopicConfigs)+len(got.LambdaConfigs) != 0 {
+		t.Fatalf("expected an empty configuration, got %+v", got)
+	}
+
+	// Malformed ARNs are rejected before being sent.
+	config.AddTopic(notification.NewConfig(notification.NewArn("aws", "sns", "us east 1", "1", "alerts")))
+	err = clnt.SetBucketNotification(ctx, "bucket", config)
+	if ToErrorResponse(err).Code != InvalidArgument || !strings.Contains(err.Error(), "TopicConfigs[1].Topic") {
+		t.Fatalf("expected the malformed topic to be rejected, got %v", err)
+	}
+	if got, err = clnt.GetBucketNotification(ctx, "bucket"); err != nil || len(got.TopicConfigs) != 0 {
+		t.Fatalf("expected the configuration not to be sent, got %+v, %v", got, err)
+	}
+}
+
+func TestListenBucketNotification(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("prefix") != "images/" || query.Get("suffix") != ".jpg" || query.Get("events") != string(notification.ObjectCreatedAll) {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		switch atomic.AddInt32(&requests, 1) {
+		case 1:
+			// A transient error is reported and listening resumed.
+			w.WriteHeader(http.StatusServiceUnavailable)
+			io.WriteString(w, `<Error><Code>ServiceUnavailable</Code><Message>Service unavailable</Message></Error>`)
+		case 2:
+			io.WriteString(w, "{}\n")
+			io.WriteString(w, `{"Records":[{"eventName":"s3:ObjectCreated:Put","s3":{"object":{"key":"images/a.jpg"}}}]}`+"\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, `<Error><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>`)
+		}
+	}))
+	defer srv.Close()
+
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:                   credentials.NewStaticV4("accessKey", "secretKey", ""),
+		Region:                  "us-east-1",
+		MaxRetries:              1,
+		DefaultOperationTimeout: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := clnt.ListenBucketNotification(ctx, "bucket", "images/", ".jpg", []string{string(notification.ObjectCreatedAll)})
+
+	info := <-events
+	if ToErrorResponse(info.Err).Code != "ServiceUnavailable" {
+		t.Fatalf("expected a transient error, got %v", info.Err)
+	}
+	info = <-events
+	if info.Err != nil || len(info.Records) != 1 || info.Records[0].S3.Object.Key != "images/a.jpg" {
+		t.Fatalf("unexpected notification %+v", info)
+	}
+
+	// The stream outlives the default operation timeout.
+	select {
+	case info, ok := <-events:
+		t.Fatalf("unexpected notification %+v, %t", info, ok)
+	case <-time.After(200 * time.Millisecond):
+	}
+	cancel()
+	for info := range events {
+		t.Fatalf("unexpected notification %+v after cancellation", info)
+	}
+
+	// Other errors stop listening.
+	events = clnt.ListenBucketNotification(context.Background(), "bucket", "images/", ".jpg", []string{string(notification.ObjectCreatedAll)})
+	if info = <-events; ToErrorResponse(info.Err).Code != AccessDenied {
+		t.Fatalf("expected access to be denied, got %v", info.Err)
+	}
+	if _, ok := <-events; ok {
+		t.Fatal("expected the channel to be closed")
+	}
+}
@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Ownership - bucket object ownership setting.
+type Ownership string
+
+const (
+	// BucketOwnerPreferred - objects uploaded with the bucket-owner-full-control
+	// canned ACL are owned by the bucket owner.
+	BucketOwnerPreferred Ownership = "BucketOwnerPreferred"
+
+	// ObjectWriter - objects are owned by the account that uploaded them.
+	ObjectWriter Ownership = "ObjectWriter"
+
+	// BucketOwnerEnforced - ACLs are disabled and the bucket owner owns
+	// every object in the bucket.
+	BucketOwnerEnforced Ownership = "BucketOwnerEnforced"
+)
+
+func (o Ownership) String() string {
+	return string(o)
+}
+
+// IsValid - check whether this ownership setting is valid or not.
+func (o Ownership) IsValid() bool {
+	switch o {
+	case BucketOwnerPreferred, ObjectWriter, BucketOwnerEnforced:
+		return true
+	}
+	return false
+}
+
+// ownershipControls - bucket ownership controls specified in
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_OwnershipControls.html
+type ownershipControls struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"OwnershipControls"`
+	Rules   []struct {
+		ObjectOwnership Ownership `xml:"ObjectOwnership"`
+	} `xml:"Rule"`
+}
+
+// SetBucketOwnershipControls sets the object ownership setting of a bucket,
+// use BucketOwnerEnforced to disable ACLs on the bucket.
+func (c *Client) SetBucketOwnershipControls(ctx context.Context, bucketName string, ownership Ownership) error {
+	// Input validation.
+	if err := c.checkBucketName(bucketName); err != nil {
+		return err
+	}
+	if !ownership.IsValid() {
+		return errInvalidArgument(fmt.Sprintf("invalid object ownership `%v`", ownership))
+	}
+
+	config := ownershipControls{XMLNS: "http://s3.amazonaws.com/doc/2006-03-01/"}
+	config.Rules = append(config.Rules, struct {
+		ObjectOwnership Ownership `xml:"ObjectOwnership"`
+	}{ObjectOwnership: ownership})
+
+	buf, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Get resources properly escaped and lined up before
+	// using them in http request.
+	urlValues := make(url.Values)
+	urlValues.Set("ownershipControls", "")
+
+	reqMetadata := requestMetadata{
+		bucketName:       bucketName,
+		queryValues:      urlValues,
+		contentBody:      bytes.NewReader(buf),
+		contentLength:    int64(len(buf)),
+		contentMD5Base64: sumMD5Base64(buf),
+		contentSHA256Hex: sum256Hex(buf),
+	}
+
+	// Execute PUT to set the bucket ownership controls.
+	resp, err := c.executeMethod(ctx, http.MethodPut, reqMetadata)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp, bucketName, "")
+	}
+	return nil
+}
+
+// GetBucketOwnershipControls returns the object ownership setting of a bucket.
+// An OwnershipControlsNotFoundError is returned if none is set.
+func (c *Client) GetBucketOwnershipControls(ctx context.Context, bucketName string) (Ownership, error) {
+	// Input validation.
+	if err := c.checkBucketName(bucketName); err != nil {
+		return "", err
+	}
+
+	urlValues := make(url.Values)
+	urlValues.Set("ownershipControls", "")
+
+	// Execute GET on bucket to get the ownership controls.
+	resp, err := c.executeMethod(ctx, http.MethodGet, requestMetadata{
+		bucketName:       bucketName,
+		queryValues:      urlValues,
+		contentSHA256Hex: emptySHA256Hex,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", httpRespToErrorResponse(resp, bucketName, "")
+	}
+
+	config := ownershipControls{}
+	if err = xmlDecoder(resp.Body, &config); err != nil {
+		return "", err
+	}
+	if len(config.Rules) == 0 {
+		return "", ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Code:       OwnershipControlsNotFoundError,
+			Message:    s3ErrorResponseMap[OwnershipControlsNotFoundError],
+			BucketName: bucketName,
+		}
+	}
+	return config.Rules[0].ObjectOwnership, nil
+}
+
+// RemoveBucketOwnershipControls removes the ownership controls of a bucket.
+func (c *Client) RemoveBucketOwnershipControls(ctx context.Context, bucketName string) error {
+	// Input validation.
+	if err := c.checkBucketName(bucketName); err != nil {
+		return err
+	}
+
+	urlValues := make(url.Values)
+	urlValues.Set("ownershipControls", "")
+
+	// DELETE the ownership controls of a bucket.
+	resp, err := c.executeMethod(ctx, http.MethodDelete, requestMetadata{
+		bucketName:       bucketName,
+		queryValues:      urlValues,
+		contentSHA256Hex: emptySHA256Hex,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return httpRespToErrorResponse(resp, bucketName, "")
+	}
+	return nil
+}
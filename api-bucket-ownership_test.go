@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBucketOwnershipControls(t *testing.T) {
+	var stored string
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["ownershipControls"]; !ok {
+			t.Errorf("expected ownershipControls query, got %s", r.URL.RawQuery)
+		}
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			stored = string(body)
+			w.WriteHeader(http.StatusOK)
+		case http.MethodGet:
+			if stored == "" {
+				w.WriteHeader(http.StatusNotFound)
+				io.WriteString(w, `<Error><Code>OwnershipControlsNotFoundError</Code></Error>`)
+				return
+			}
+			io.WriteString(w, stored)
+		case http.MethodDelete:
+			stored = ""
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+
+	ctx := context.Background()
+	if err := clnt.SetBucketOwnershipControls(ctx, "bucket", Ownership("Invalid")); err == nil {
+		t.Fatal("expected invalid ownership to fail")
+	}
+	if err := clnt.SetBucketOwnershipControls(ctx, "bucket", BucketOwnerEnforced); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stored, "<Rule><ObjectOwnership>BucketOwnerEnforced</ObjectOwnership></Rule>") {
+		t.Fatalf("unexpected request body %s", stored)
+	}
+	ownership, err := clnt.GetBucketOwnershipControls(ctx, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ownership != BucketOwnerEnforced {
+		t.Fatalf("expected %s, got %s", BucketOwnerEnforced, ownership)
+	}
+	if err = clnt.RemoveBucketOwnershipControls(ctx, "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = clnt.GetBucketOwnershipControls(ctx, "bucket"); ToErrorResponse(err).Code != OwnershipControlsNotFoundError {
+		t.Fatalf("expected %s, got %v", OwnershipControlsNotFoundError, err)
+	}
+}
@@ -21,17 +21,17 @@ package openstor
 
 import (
 	"context"
+	"encoding/xml"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
-
-	"github.com/openstor/openstor-go/v7/pkg/s3utils"
 )
 
 // SetBucketPolicy sets the access permissions policy on an existing bucket.
 // The policy should be a valid JSON string that conforms to the IAM policy format.
 // If policy is an empty string, the existing bucket policy will be removed.
+// The policy is sent as is, use policy.Parse to validate it beforehand.
 //
 // Parameters:
 //   - ctx: Context for request cancellation and timeout
@@ -41,7 +41,7 @@ import (
 // Returns an error if the operation fails.
 func (c *Client) SetBucketPolicy(ctx context.Context, bucketName, policy string) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 
@@ -117,7 +117,7 @@ func (c *Client) removeBucketPolicy(ctx context.Context, bucketName string) erro
 // Returns the policy as a JSON string or an error if the operation fails.
 func (c *Client) GetBucketPolicy(ctx context.Context, bucketName string) (string, error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return "", err
 	}
 	bucketPolicy, err := c.getBucketPolicy(ctx, bucketName)
@@ -164,3 +164,53 @@ func (c *Client) getBucketPolicy(ctx context.Context, bucketName string) (string
 	policy := string(bucketPolicyBuf)
 	return policy, err
 }
+
+// policyStatus - bucket policy status returned by the policyStatus API.
+type policyStatus struct {
+	XMLName  xml.Name `xml:"PolicyStatus"`
+	IsPublic bool     `xml:"IsPublic"`
+}
+
+// GetBucketPolicyStatus reports whether the bucket policy makes the bucket
+// public. If no bucket policy exists, the bucket is reported as not public
+// with no error.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//   - bucketName: Name of the bucket
+//
+// Returns true if the bucket is public or an error if the operation fails.
+func (c *Client) GetBucketPolicyStatus(ctx context.Context, bucketName string) (bool, error) {
+	// Input validation.
+	if err := c.checkBucketName(bucketName); err != nil {
+		return false, err
+	}
+
+	// Get resources properly escaped and lined up before
+	// using them in http request.
+	urlValues := make(url.Values)
+	urlValues.Set("policyStatus", "")
+
+	// Execute GET on bucket to get the policy status.
+	resp, err := c.executeMethod(ctx, http.MethodGet, requestMetadata{
+		bucketName:       bucketName,
+		queryValues:      urlValues,
+		contentSHA256Hex: emptySHA256Hex,
+	})
+	defer closeResponse(resp)
+	if err == nil && resp.StatusCode != http.StatusOK {
+		err = httpRespToErrorResponse(resp, bucketName, "")
+	}
+	if err != nil {
+		if ToErrorResponse(err).Code == NoSuchBucketPolicy {
+			return false, nil
+		}
+		return false, err
+	}
+
+	var status policyStatus
+	if err = xmlDecoder(resp.Body, &status); err != nil {
+		return false, err
+	}
+	return status.IsPublic, nil
+}
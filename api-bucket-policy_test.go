@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestGetBucketPolicyStatus(t *testing.T) {
+	testCases := []struct {
+		status   int
+		body     string
+		isPublic bool
+		code     string
+	}{
+		{http.StatusOK, `<PolicyStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><IsPublic>true</IsPublic></PolicyStatus>`, true, ""},
+		{http.StatusOK, `<PolicyStatus><IsPublic>false</IsPublic></PolicyStatus>`, false, ""},
+		{http.StatusNotFound, `<Error><Code>NoSuchBucketPolicy</Code><Message>The bucket policy does not exist</Message></Error>`, false, ""},
+		{http.StatusNotFound, `<Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist</Message></Error>`, false, NoSuchBucket},
+	}
+
+	for i, testCase := range testCases {
+		clnt, srv := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := r.URL.Query()["policyStatus"]; !ok || r.Method != http.MethodGet {
+				t.Errorf("Test %d: expected GET with policyStatus query, got %s %s", i+1, r.Method, r.URL.RawQuery)
+			}
+			w.WriteHeader(testCase.status)
+			io.WriteString(w, testCase.body)
+		}))
+
+		isPublic, err := clnt.GetBucketPolicyStatus(context.Background(), "bucket")
+		srv.Close()
+		if testCase.code != "" {
+			if ToErrorResponse(err).Code != testCase.code {
+				t.Fatalf("Test %d: expected %s, got %v", i+1, testCase.code, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if isPublic != testCase.isPublic {
+			t.Fatalf("Test %d: expected IsPublic %v, got %v", i+1, testCase.isPublic, isPublic)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+)
+
+// PublicAccessBlockConfiguration - public access block configuration of a
+// bucket specified in
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PublicAccessBlockConfiguration.html
+type PublicAccessBlockConfiguration struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"PublicAccessBlockConfiguration"`
+
+	// BlockPublicAcls - reject requests setting public ACLs on the
+	// bucket or its objects.
+	BlockPublicAcls bool `xml:"BlockPublicAcls"`
+	// IgnorePublicAcls - ignore public ACLs on the bucket and its objects.
+	IgnorePublicAcls bool `xml:"IgnorePublicAcls"`
+	// BlockPublicPolicy - reject bucket policies granting public access.
+	BlockPublicPolicy bool `xml:"BlockPublicPolicy"`
+	// RestrictPublicBuckets - restrict access to buckets with a public
+	// policy to the bucket owner and AWS services.
+	RestrictPublicBuckets bool `xml:"RestrictPublicBuckets"`
+}
+
+// SetBucketPublicAccessBlock sets the public access block configuration
+// of a bucket.
+func (c *Client) SetBucketPublicAccessBlock(ctx context.Context, bucketName string, config PublicAccessBlockConfiguration) error {
+	// Input validation.
+	if err := c.checkBucketName(bucketName); err != nil {
+		return err
+	}
+
+	config.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+	buf, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Get resources properly escaped and lined up before
+	// using them in http request.
+	urlValues := make(url.Values)
+	urlValues.Set("publicAccessBlock", "")
+
+	reqMetadata := requestMetadata{
+		bucketName:       bucketName,
+		queryValues:      urlValues,
+		contentBody:      bytes.NewReader(buf),
+		contentLength:    int64(len(buf)),
+		contentMD5Base64: sumMD5Base64(buf),
+		contentSHA256Hex: sum256Hex(buf),
+	}
+
+	// Execute PUT to set the public access block configuration.
+	resp, err := c.executeMethod(ctx, http.MethodPut, reqMetadata)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp, bucketName, "")
+	}
+	return nil
+}
+
+// GetBucketPublicAccessBlock returns the public access block configuration
+// of a bucket. A NoSuchPublicAccessBlockConfiguration error is returned if
+// none is set.
+func (c *Client) GetBucketPublicAccessBlock(ctx context.Context, bucketName string) (PublicAccessBlockConfiguration, error) {
+	// Input validation.
+	if err := c.checkBucketName(bucketName); err != nil {
+		return PublicAccessBlockConfiguration{}, err
+	}
+
+	urlValues := make(url.Values)
+	urlValues.Set("publicAccessBlock", "")
+
+	// Execute GET on bucket to get the public access block configuration.
+	resp, err := c.executeMethod(ctx, http.MethodGet, requestMetadata{
+		bucketName:       bucketName,
+		queryValues:      urlValues,
+		contentSHA256Hex: emptySHA256Hex,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return PublicAccessBlockConfiguration{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return PublicAccessBlockConfiguration{}, httpRespToErrorResponse(resp, bucketName, "")
+	}
+
+	config := PublicAccessBlockConfiguration{}
+	if err = xmlDecoder(resp.Body, &config); err != nil {
+		return PublicAccessBlockConfiguration{}, err
+	}
+	return config, nil
+}
+
+// RemoveBucketPublicAccessBlock removes the public access block
+// configuration of a bucket.
+func (c *Client) RemoveBucketPublicAccessBlock(ctx context.Context, bucketName string) error {
+	// Input validation.
+	if err := c.checkBucketName(bucketName); err != nil {
+		return err
+	}
+
+	urlValues := make(url.Values)
+	urlValues.Set("publicAccessBlock", "")
+
+	// DELETE the public access block configuration of a bucket.
+	resp, err := c.executeMethod(ctx, http.MethodDelete, requestMetadata{
+		bucketName:       bucketName,
+		queryValues:      urlValues,
+		contentSHA256Hex: emptySHA256Hex,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return httpRespToErrorResponse(resp, bucketName, "")
+	}
+	return nil
+}
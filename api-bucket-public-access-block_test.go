@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestBucketPublicAccessBlock(t *testing.T) {
+	var stored string
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["publicAccessBlock"]; !ok {
+			t.Errorf("expected publicAccessBlock query, got %s", r.URL.RawQuery)
+		}
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			stored = string(body)
+			w.WriteHeader(http.StatusOK)
+		case http.MethodGet:
+			if stored == "" {
+				w.WriteHeader(http.StatusNotFound)
+				io.WriteString(w, `<Error><Code>NoSuchPublicAccessBlockConfiguration</Code></Error>`)
+				return
+			}
+			io.WriteString(w, stored)
+		case http.MethodDelete:
+			stored = ""
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+
+	ctx := context.Background()
+	testCases := []struct {
+		config   PublicAccessBlockConfiguration
+		expected string
+	}{
+		{
+			PublicAccessBlockConfiguration{BlockPublicAcls: true, IgnorePublicAcls: true, BlockPublicPolicy: true, RestrictPublicBuckets: true},
+			"<BlockPublicAcls>true</BlockPublicAcls><IgnorePublicAcls>true</IgnorePublicAcls><BlockPublicPolicy>true</BlockPublicPolicy><RestrictPublicBuckets>true</RestrictPublicBuckets>",
+		},
+		{
+			PublicAccessBlockConfiguration{},
+			"<BlockPublicAcls>false</BlockPublicAcls><IgnorePublicAcls>false</IgnorePublicAcls><BlockPublicPolicy>false</BlockPublicPolicy><RestrictPublicBuckets>false</RestrictPublicBuckets>",
+		},
+		{
+			PublicAccessBlockConfiguration{BlockPublicAcls: true, BlockPublicPolicy: true},
+			"<BlockPublicAcls>true</BlockPublicAcls><IgnorePublicAcls>false</IgnorePublicAcls><BlockPublicPolicy>true</BlockPublicPolicy><RestrictPublicBuckets>false</RestrictPublicBuckets>",
+		},
+		{
+			PublicAccessBlockConfiguration{IgnorePublicAcls: true, RestrictPublicBuckets: true},
+			"<BlockPublicAcls>false</BlockPublicAcls><IgnorePublicAcls>true</IgnorePublicAcls><BlockPublicPolicy>false</BlockPublicPolicy><RestrictPublicBuckets>true</RestrictPublicBuckets>",
+		},
+	}
+	for i, testCase := range testCases {
+		if err := clnt.SetBucketPublicAccessBlock(ctx, "bucket", testCase.config); err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		expected := `<PublicAccessBlockConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">` + testCase.expected + `</PublicAccessBlockConfiguration>`
+		if stored != expected {
+			t.Fatalf("Test %d: expected request body %s, got %s", i+1, expected, stored)
+		}
+		config, err := clnt.GetBucketPublicAccessBlock(ctx, "bucket")
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		config.XMLNS, config.XMLName = "", xml.Name{}
+		if config != testCase.config {
+			t.Fatalf("Test %d: expected %+v, got %+v", i+1, testCase.config, config)
+		}
+	}
+
+	if err := clnt.RemoveBucketPublicAccessBlock(ctx, "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := clnt.GetBucketPublicAccessBlock(ctx, "bucket"); ToErrorResponse(err).Code != NoSuchPublicAccessBlockConfiguration {
+		t.Fatalf("expected %s, got %v", NoSuchPublicAccessBlockConfiguration, err)
+	}
+}
@@ -27,7 +27,6 @@ import (
 	"net/url"
 	"strings"
 
-	"github.com/openstor/openstor-go/v7/pkg/s3utils"
 	"gopkg.in/yaml.v3"
 )
 
@@ -70,7 +69,7 @@ func NewQOSConfig() *QOSConfig {
 func (c *Client) GetBucketQOS(ctx context.Context, bucket string) (*QOSConfig, error) {
 	var qosCfg QOSConfig
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucket); err != nil {
+	if err := c.checkBucketName(bucket); err != nil {
 		return nil, err
 	}
 	urlValues := make(url.Values)
@@ -111,7 +110,7 @@ func (c *Client) GetBucketQOS(ctx context.Context, bucket string) (*QOSConfig, e
 // Returns an error if the operation fails.
 func (c *Client) SetBucketQOS(ctx context.Context, bucket string, qosCfg *QOSConfig) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucket); err != nil {
+	if err := c.checkBucketName(bucket); err != nil {
 		return err
 	}
 
@@ -179,7 +178,7 @@ type QOSNodeStats struct {
 // Returns QoS metrics per node or an error if the operation fails.
 func (c *Client) GetBucketQOSMetrics(ctx context.Context, bucketName, nodeName string) (qs []QOSNodeStats, err error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return qs, err
 	}
 	// Get resources properly escaped and lined up before
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/openstor/openstor-go/v7/pkg/s3utils"
+)
+
+// noSuchQuotaConfiguration is the MinIO admin API error code of buckets
+// without a quota.
+const noSuchQuotaConfiguration = "XMinioAdminNoSuchQuotaConfiguration"
+
+// bucketQuota is the JSON quota configuration of a bucket, as managed
+// by the MinIO admin API. Size replaces the deprecated Quota field.
+type bucketQuota struct {
+	Quota uint64 `json:"quota"`
+	Size  uint64 `json:"size"`
+	Type  string `json:"quotatype,omitempty"`
+}
+
+// SetBucketQuota sets the hard quota of the bucket to quotaBytes, writes
+// growing the bucket beyond it being rejected. A zero quota removes it.
+// Bucket quotas are managed through the MinIO admin API, which requires
+// admin permissions, servers without it such as AWS S3 are reported with
+// an APINotSupported error.
+func (c *Client) SetBucketQuota(ctx context.Context, bucketName string, quotaBytes uint64) error {
+	// Input validation.
+	if err := c.checkBucketName(bucketName); err != nil {
+		return err
+	}
+	if s3utils.IsAmazonEndpoint(*c.endpointURL) {
+		return errAPINotSupported("Bucket quotas are not supported by AWS S3.")
+	}
+
+	var quota bucketQuota
+	if quotaBytes > 0 {
+		quota = bucketQuota{Quota: quotaBytes, Size: quotaBytes, Type: "hard"}
+	}
+	data, err := json.Marshal(quota)
+	if err != nil {
+		return err
+	}
+
+	urlValues := make(url.Values)
+	urlValues.Set("bucket", bucketName)
+
+	// Execute PUT on the admin API to set the bucket quota.
+	resp, err := c.executeMethod(ctx, http.MethodPut, requestMetadata{
+		adminPath:        "/set-bucket-quota",
+		queryValues:      urlValues,
+		contentBody:      bytes.NewReader(data),
+		contentLength:    int64(len(data)),
+		contentSHA256Hex: sum256Hex(data),
+	})
+	defer closeResponse(resp)
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		err = adminRespToErrorResponse(resp, bucketName)
+	}
+	return err
+}
+
+// GetBucketQuota returns the hard quota of the bucket in bytes, zero if
+// the bucket has no quota. Bucket quotas are managed through the MinIO
+// admin API, which requires admin permissions, servers without it such
+// as AWS S3 are reported with an APINotSupported error.
+func (c *Client) GetBucketQuota(ctx context.Context, bucketName string) (uint64, error) {
+	// Input validation.
+	if err := c.checkBucketName(bucketName); err != nil {
+		return 0, err
+	}
+	if s3utils.IsAmazonEndpoint(*c.endpointURL) {
+		return 0, errAPINotSupported("Bucket quotas are not supported by AWS S3.")
+	}
+
+	urlValues := make(url.Values)
+	urlValues.Set("bucket", bucketName)
+
+	// Execute GET on the admin API to get the bucket quota.
+	resp, err := c.executeMethod(ctx, http.MethodGet, requestMetadata{
+		adminPath:        "/get-bucket-quota",
+		queryValues:      urlValues,
+		contentSHA256Hex: emptySHA256Hex,
+	})
+	defer closeResponse(resp)
+	if resp != nil && resp.StatusCode != http.StatusOK {
+		err = adminRespToErrorResponse(resp, bucketName)
+		if ToErrorResponse(err).Code == noSuchQuotaConfiguration {
+			return 0, nil
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	var quota bucketQuota
+	if err = json.Unmarshal(data, &quota); err != nil {
+		return 0, errAPINotSupported("Bucket quotas are not supported by the server, unexpected response to the quota request.")
+	}
+	if quota.Size > 0 {
+		return quota.Size, nil
+	}
+	return quota.Quota, nil
+}
+
+// adminRespToErrorResponse returns the JSON error of a failed MinIO
+// admin API response, which executeMethod read as an S3 error. Servers without the admin API answer with S3
+// errors instead, which are reported as an APINotSupported error.
+func adminRespToErrorResponse(resp *http.Response, bucketName string) error {
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	var errResp ErrorResponse
+	if err = json.Unmarshal(data, &errResp); err != nil || errResp.Code == "" {
+		return errAPINotSupported("The MinIO admin API is not supported by the server, " + resp.Status)
+	}
+	errResp.StatusCode = resp.StatusCode
+	errResp.Server = resp.Header.Get("Server")
+	if errResp.BucketName == "" {
+		errResp.BucketName = bucketName
+	}
+	return errResp
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestBucketQuota(t *testing.T) {
+	var stored string
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("bucket") != "bucket" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/minio/admin/v3/set-bucket-quota":
+			body, _ := io.ReadAll(r.Body)
+			stored = string(body)
+		case r.Method == http.MethodGet && r.URL.Path == "/minio/admin/v3/get-bucket-quota":
+			if stored == "" || stored == `{"quota":0,"size":0}` {
+				w.WriteHeader(http.StatusNotFound)
+				io.WriteString(w, `{"Code":"XMinioAdminNoSuchQuotaConfiguration","Message":"The quota configuration does not exist","BucketName":"bucket"}`)
+				return
+			}
+			io.WriteString(w, stored)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	ctx := context.Background()
+
+	if quota, err := clnt.GetBucketQuota(ctx, "bucket"); err != nil || quota != 0 {
+		t.Fatalf("expected no quota, got %d, %v", quota, err)
+	}
+	if err := clnt.SetBucketQuota(ctx, "bucket", 1<<30); err != nil {
+		t.Fatal(err)
+	}
+	if stored != `{"quota":1073741824,"size":1073741824,"quotatype":"hard"}` {
+		t.Fatalf("unexpected quota configuration %s", stored)
+	}
+	if quota, err := clnt.GetBucketQuota(ctx, "bucket"); err != nil || quota != 1<<30 {
+		t.Fatalf("expected a quota of 1GiB, got %d, %v", quota, err)
+	}
+	if err := clnt.SetBucketQuota(ctx, "bucket", 0); err != nil {
+		t.Fatal(err)
+	}
+	if quota, err := clnt.GetBucketQuota(ctx, "bucket"); err != nil || quota != 0 {
+		t.Fatalf("expected the quota to be removed, got %d, %v", quota, err)
+	}
+}
+
+func TestBucketQuotaErrors(t *testing.T) {
+	testCases := []struct {
+		method string
+		status int
+		body   string
+		code   string
+	}{
+		// Servers without the admin API answer with S3 errors.
+		{http.MethodGet, http.StatusNotFound, `<Error><Code>NoSuchBucket</Code><BucketName>minio</BucketName></Error>`, APINotSupported},
+		{http.MethodGet, http.StatusNotImplemented, `<Error><Code>NotImplemented</Code></Error>`, APINotSupported},
+		{http.MethodPut, http.StatusMethodNotAllowed, "", APINotSupported},
+		// Errors of the admin API are returned unchanged.
+		{http.MethodPut, http.StatusNotFound, `{"Code":"NoSuchBucket","Message":"The specified bucket does not exist"}`, NoSuchBucket},
+		{http.MethodGet, http.StatusForbidden, `{"Code":"AccessDenied","Message":"Access Denied."}`, AccessDenied},
+	}
+
+	for i, testCase := range testCases {
+		clnt, srv := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(testCase.status)
+			io.WriteString(w, testCase.body)
+		}))
+
+		var err error
+		if testCase.method == http.MethodGet {
+			_, err = clnt.GetBucketQuota(context.Background(), "bucket")
+		} else {
+			err = clnt.SetBucketQuota(context.Background(), "bucket", 1)
+		}
+		srv.Close()
+		if code := ToErrorResponse(err).Code; code != testCase.code {
+			t.Fatalf("Test %d: expected %s, got %v", i+1, testCase.code, err)
+		}
+	}
+
+	clnt, err := New("s3.amazonaws.com", &Options{Region: "us-east-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = clnt.SetBucketQuota(context.Background(), "bucket", 1); ToErrorResponse(err).Code != APINotSupported {
+		t.Fatalf("expected AWS S3 to be rejected, got %v", err)
+	}
+}
@@ -25,14 +25,15 @@ import (
 	"context"
 	"encoding/json"
 	"encoding/xml"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/openstor/openstor-go/v7/pkg/arn"
 	"github.com/openstor/openstor-go/v7/pkg/replication"
-	"github.com/openstor/openstor-go/v7/pkg/s3utils"
 )
 
 // RemoveBucketReplication removes the replication configuration from an existing bucket.
@@ -57,7 +58,7 @@ func (c *Client) RemoveBucketReplication(ctx context.Context, bucketName string)
 // Returns an error if the operation fails.
 func (c *Client) SetBucketReplication(ctx context.Context, bucketName string, cfg replication.Config) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 
@@ -65,10 +66,29 @@ func (c *Client) SetBucketReplication(ctx context.Context, bucketName string, cf
 	if cfg.Empty() {
 		return c.removeBucketReplication(ctx, bucketName)
 	}
+	if err := validateReplicationARNs(cfg); err != nil {
+		return err
+	}
 	// Save the updated replication.
 	return c.putBucketReplication(ctx, bucketName, cfg)
 }
 
+// validateReplicationARNs checks the ARNs referenced by cfg, naming the
+// malformed field in the returned error.
+func validateReplicationARNs(cfg replication.Config) error {
+	if cfg.Role != "" {
+		if _, err := arn.Parse(cfg.Role); err != nil {
+			return errInvalidArgument("Role: " + err.Error())
+		}
+	}
+	for i, rule := range cfg.Rules {
+		if _, err := arn.Parse(rule.Destination.Bucket); err != nil {
+			return errInvalidArgument(fmt.Sprintf("Rules[%d].Destination.Bucket: %v", i, err))
+		}
+	}
+	return nil
+}
+
 // Saves a new bucket replication.
 func (c *Client) putBucketReplication(ctx context.Context, bucketName string, cfg replication.Config) error {
 	// Get resources properly escaped and lined up before
@@ -135,7 +155,7 @@ func (c *Client) removeBucketReplication(ctx context.Context, bucketName string)
 // Returns the replication configuration or an error if the operation fails.
 func (c *Client) GetBucketReplication(ctx context.Context, bucketName string) (cfg replication.Config, err error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return cfg, err
 	}
 	bucketReplicationCfg, err := c.getBucketReplication(ctx, bucketName)
@@ -187,7 +207,7 @@ func (c *Client) getBucketReplication(ctx context.Context, bucketName string) (c
 // Returns the replication metrics or an error if the operation fails.
 func (c *Client) GetBucketReplicationMetrics(ctx context.Context, bucketName string) (s replication.Metrics, err error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return s, err
 	}
 	// Get resources properly escaped and lined up before
@@ -265,7 +285,7 @@ func (c *Client) ResetBucketReplicationOnTarget(ctx context.Context, bucketName
 // is enabled in the replication config
 func (c *Client) resetBucketReplicationOnTarget(ctx context.Context, bucketName string, olderThan time.Duration, tgtArn, resetID string) (rinfo replication.ResyncTargetsInfo, err error) {
 	// Input validation.
-	if err = s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err = c.checkBucketName(bucketName); err != nil {
 		return rinfo, err
 	}
 	// Get resources properly escaped and lined up before
@@ -310,7 +330,7 @@ func (c *Client) resetBucketReplicationOnTarget(ctx context.Context, bucketName
 // Returns resync status information or an error if the operation fails.
 func (c *Client) GetBucketReplicationResyncStatus(ctx context.Context, bucketName, arn string) (rinfo replication.ResyncTargetsInfo, err error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return rinfo, err
 	}
 	// Get resources properly escaped and lined up before
@@ -351,7 +371,7 @@ func (c *Client) GetBucketReplicationResyncStatus(ctx context.Context, bucketNam
 // Returns the ID of the canceled resync operation or an error if the operation fails.
 func (c *Client) CancelBucketReplicationResync(ctx context.Context, bucketName string, tgtArn string) (id string, err error) {
 	// Input validation.
-	if err = s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err = c.checkBucketName(bucketName); err != nil {
 		return id, err
 	}
 	// Get resources properly escaped and lined up before
@@ -393,7 +413,7 @@ func (c *Client) CancelBucketReplicationResync(ctx context.Context, bucketName s
 // Returns the V2 replication metrics or an error if the operation fails.
 func (c *Client) GetBucketReplicationMetricsV2(ctx context.Context, bucketName string) (s replication.MetricsV2, err error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return s, err
 	}
 	// Get resources properly escaped and lined up before
@@ -435,7 +455,7 @@ func (c *Client) GetBucketReplicationMetricsV2(ctx context.Context, bucketName s
 // Returns nil if replication is valid, or an error describing the validation failure.
 func (c *Client) CheckBucketReplication(ctx context.Context, bucketName string) (err error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 	// Get resources properly escaped and lined up before
@@ -459,3 +479,22 @@ func (c *Client) CheckBucketReplication(ctx context.Context, bucketName string)
 	}
 	return nil
 }
+
+// GetObjectReplicationStatus returns the replication status of an object
+// version as reported by the x-amz-replication-status header.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//   - bucketName: Name of the bucket
+//   - objectName: Name of the object
+//   - versionID: Version of the object, empty for the latest version
+//
+// Returns the replication status, empty if the object is not subject to
+// replication, or an error if the operation fails.
+func (c *Client) GetObjectReplicationStatus(ctx context.Context, bucketName, objectName, versionID string) (ReplicationStatus, error) {
+	objInfo, err := c.StatObject(ctx, bucketName, objectName, StatObjectOptions{VersionID: versionID})
+	if err != nil {
+		return "", err
+	}
+	return ReplicationStatus(objInfo.ReplicationStatus), nil
+}
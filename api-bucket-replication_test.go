@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openstor/openstor-go/v7/pkg/replication"
+)
+
+func TestBucketReplication(t *testing.T) {
+	var stored []byte
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !r.URL.Query().Has("replication") {
+			t.Errorf("expected replication query, got %s", r.URL.RawQuery)
+		}
+		switch r.Method {
+		case http.MethodPut:
+			stored, _ = io.ReadAll(r.Body)
+		case http.MethodGet:
+			w.Write(stored)
+		}
+	}))
+
+	config := replication.Config{
+		Role: "arn:minio:replication::role:bucket",
+		Rules: []replication.Rule{
+			{
+				ID:       "dr",
+				Status:   replication.Enabled,
+				Priority: 1,
+				Filter: replication.Filter{
+					And: replication.And{
+						Prefix: "logs/",
+						Tags:   []replication.Tag{{Key: "tier", Value: "gold"}},
+					},
+				},
+				Destination: replication.Destination{
+					Bucket:       "arn:aws:s3:::dest",
+					StorageClass: "STANDARD_IA",
+				},
+				DeleteMarkerReplication:   replication.DeleteMarkerReplication{Status: replication.Enabled},
+				DeleteReplication:         replication.DeleteReplication{Status: replication.Disabled},
+				SourceSelectionCriteria:   replication.SourceSelectionCriteria{ReplicaModifications: replication.ReplicaModifications{Status: replication.Enabled}},
+				ExistingObjectReplication: replication.ExistingObjectReplication{Status: replication.Enabled},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	if err := clnt.SetBucketReplication(ctx, "bucket", config); err != nil {
+		t.Fatal(err)
+	}
+	got, err := clnt.GetBucketReplication(ctx, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Role != config.Role || len(got.Rules) != 1 {
+		t.Fatalf("unexpected config %+v", got)
+	}
+	rule, want := got.Rules[0], config.Rules[0]
+	if rule.ID != want.ID || rule.Status != want.Status || rule.Priority != want.Priority {
+		t.Fatalf("unexpected rule %+v", rule)
+	}
+	if rule.ExistingObjectReplication != want.ExistingObjectReplication {
+		t.Fatalf("unexpected existing object replication %+v", rule.ExistingObjectReplication)
+	}
+	if rule.DeleteMarkerReplication != want.DeleteMarkerReplication || rule.DeleteReplication != want.DeleteReplication {
+		t.Fatalf("unexpected delete replication %+v, %+v", rule.DeleteMarkerReplication, rule.DeleteReplication)
+	}
+	if rule.SourceSelectionCriteria != want.SourceSelectionCriteria {
+		t.Fatalf("unexpected source selection criteria %+v", rule.SourceSelectionCriteria)
+	}
+	if rule.Destination.Bucket != want.Destination.Bucket || rule.Destination.StorageClass != want.Destination.StorageClass {
+		t.Fatalf("unexpected destination %+v", rule.Destination)
+	}
+	if and := rule.Filter.And; and.Prefix != "logs/" || len(and.Tags) != 1 || and.Tags[0].Key != "tier" || and.Tags[0].Value != "gold" {
+		t.Fatalf("unexpected filter %+v", rule.Filter)
+	}
+
+	// Malformed ARNs are rejected before being sent.
+	stored = nil
+	testCases := []struct {
+		role, bucket string
+		field        string
+	}{
+		{"arn:minio:replication::role:bucket", "dest", "Rules[0].Destination.Bucket"},
+		{"arn:minio:replication::role:bucket", "arn:aws:s3::dest", "Rules[0].Destination.Bucket"},
+		{"arn:minio", "arn:aws:s3:::dest", "Role"},
+	}
+	for i, testCase := range testCases {
+		config.Role = testCase.role
+		config.Rules[0].Destination.Bucket = testCase.bucket
+		err = clnt.SetBucketReplication(ctx, "bucket", config)
+		if ToErrorResponse(err).Code != InvalidArgument || !strings.Contains(err.Error(), testCase.field) {
+			t.Fatalf("Test %d: expected %s to be rejected, got %v", i+1, testCase.field, err)
+		}
+	}
+	if stored != nil {
+		t.Fatal("expected the configuration not to be sent")
+	}
+}
+
+func TestGetObjectReplicationStatus(t *testing.T) {
+	statuses := map[string]string{"v1": "COMPLETED", "v2": "PENDING", "": "FAILED"}
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			return
+		}
+		status, ok := statuses[r.URL.Query().Get("versionId")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Length", "0")
+		w.Header().Set(amzReplicationStatus, status)
+	}))
+
+	testCases := []struct {
+		versionID string
+		status    ReplicationStatus
+	}{
+		{"v1", ReplicationStatusComplete},
+		{"v2", ReplicationStatusPending},
+		{"", ReplicationStatusFailed},
+	}
+	for i, testCase := range testCases {
+		status, err := clnt.GetObjectReplicationStatus(context.Background(), "bucket", "object", testCase.versionID)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if status != testCase.status {
+			t.Fatalf("Test %d: expected status %q, got %q", i+1, testCase.status, status)
+		}
+	}
+
+	if _, err := clnt.GetObjectReplicationStatus(context.Background(), "bucket", "object", "v3"); ToErrorResponse(err).StatusCode != http.StatusNotFound {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
@@ -28,7 +28,6 @@ import (
 	"net/http"
 	"net/url"
 
-	"github.com/openstor/openstor-go/v7/pkg/s3utils"
 	"github.com/openstor/openstor-go/v7/pkg/tags"
 )
 
@@ -42,7 +41,7 @@ import (
 // Returns the bucket's tags or an error if the operation fails.
 func (c *Client) GetBucketTagging(ctx context.Context, bucketName string) (*tags.Tags, error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return nil, err
 	}
 
@@ -76,20 +75,28 @@ func (c *Client) GetBucketTagging(ctx context.Context, bucketName string) (*tags
 // Parameters:
 //   - ctx: Context for request cancellation and timeout
 //   - bucketName: Name of the bucket
-//   - tags: Tag set to apply to the bucket
+//   - bucketTags: Tag set to apply to the bucket
 //
-// Returns an error if the operation fails or if tags is nil.
-func (c *Client) SetBucketTagging(ctx context.Context, bucketName string, tags *tags.Tags) error {
+// Returns an error if the operation fails, if bucketTags is nil or if
+// the tags exceed the bucket tag limits.
+func (c *Client) SetBucketTagging(ctx context.Context, bucketName string, bucketTags *tags.Tags) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 
-	if tags == nil {
+	if bucketTags == nil || bucketTags.TagSet == nil {
 		return errors.New("nil tags passed")
 	}
 
-	buf, err := xml.Marshal(tags)
+	// Tags may have been created with the object tag limits, validate
+	// them against the bucket tag limits.
+	bucketTags, err := tags.MapToBucketTags(bucketTags.ToMap())
+	if err != nil {
+		return err
+	}
+
+	buf, err := xml.Marshal(bucketTags)
 	if err != nil {
 		return err
 	}
@@ -130,7 +137,7 @@ func (c *Client) SetBucketTagging(ctx context.Context, bucketName string, tags *
 // Returns an error if the operation fails.
 func (c *Client) RemoveBucketTagging(ctx context.Context, bucketName string) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 
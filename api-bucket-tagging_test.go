@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/openstor/openstor-go/v7/pkg/tags"
+)
+
+func TestBucketTagging(t *testing.T) {
+	var stored string
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["tagging"]; !ok {
+			t.Errorf("expected tagging query, got %s", r.URL.RawQuery)
+		}
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			stored = string(body)
+		case http.MethodGet:
+			if stored == "" {
+				w.WriteHeader(http.StatusNotFound)
+				io.WriteString(w, `<Error><Code>NoSuchTagSet</Code><Message>The TagSet does not exist</Message></Error>`)
+				return
+			}
+			io.WriteString(w, stored)
+		case http.MethodDelete:
+			stored = ""
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+
+	ctx := context.Background()
+	if err := clnt.SetBucketTagging(ctx, "bucket", nil); err == nil {
+		t.Fatal("expected nil tags to fail")
+	}
+	if err := clnt.SetBucketTagging(ctx, "bucket", &tags.Tags{}); err == nil {
+		t.Fatal("expected empty tags to fail")
+	}
+
+	want := map[string]string{"cost-center": "1234", "team": "storage"}
+	bucketTags, err := tags.MapToBucketTags(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = clnt.SetBucketTagging(ctx, "bucket", bucketTags); err != nil {
+		t.Fatal(err)
+	}
+	got, err := clnt.GetBucketTagging(ctx, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.ToMap(), want) {
+		t.Fatalf("expected tags %v, got %v", want, got.ToMap())
+	}
+
+	if err = clnt.RemoveBucketTagging(ctx, "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = clnt.GetBucketTagging(ctx, "bucket"); ToErrorResponse(err).Code != "NoSuchTagSet" {
+		t.Fatalf("expected NoSuchTagSet, got %v", err)
+	}
+}
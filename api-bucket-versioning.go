@@ -25,17 +25,19 @@ import (
 	"encoding/xml"
 	"net/http"
 	"net/url"
-
-	"github.com/openstor/openstor-go/v7/pkg/s3utils"
 )
 
-// SetBucketVersioning sets a bucket versioning configuration
+// SetBucketVersioning sets a bucket versioning configuration, the
+// MFADelete state is not sent since changing it requires an MFA token.
 func (c *Client) SetBucketVersioning(ctx context.Context, bucketName string, config BucketVersioningConfiguration) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 
+	// Allow configurations returned by GetBucketVersioning to be set again.
+	config.MFADelete = ""
+
 	buf, err := xml.Marshal(config)
 	if err != nil {
 		return err
@@ -86,20 +88,25 @@ type ExcludedPrefix struct {
 
 // BucketVersioningConfiguration is the versioning configuration structure
 type BucketVersioningConfiguration struct {
-	XMLName   xml.Name `xml:"VersioningConfiguration"`
-	Status    string   `xml:"Status"`
-	MFADelete string   `xml:"MfaDelete,omitempty"`
+	XMLName xml.Name `xml:"VersioningConfiguration"`
+	Status  string   `xml:"Status"`
+	// MFADelete is the MFA delete state, either Enabled or Disabled,
+	// and is empty if it was never configured. It is read-only.
+	MFADelete string `xml:"MfaDelete,omitempty"`
 	// MinIO extension - allows selective, prefix-level versioning exclusion.
 	// Requires versioning to be enabled
 	ExcludedPrefixes []ExcludedPrefix `xml:",omitempty"`
-	ExcludeFolders   bool             `xml:",omitempty"`
-	PurgeOnDelete    string           `xml:",omitempty"`
+	// MinIO extension - excludes folder objects, whose names end with
+	// a slash, from versioning.
+	ExcludeFolders bool   `xml:",omitempty"`
+	PurgeOnDelete  string `xml:",omitempty"`
 }
 
 // Various supported states
 const (
 	Enabled = "Enabled"
-	// Disabled  State = "Disabled" only used by MFA Delete not supported yet.
+	// Disabled is only used by MFA Delete.
+	Disabled  = "Disabled"
 	Suspended = "Suspended"
 )
 
@@ -113,11 +120,16 @@ func (b BucketVersioningConfiguration) Suspended() bool {
 	return b.Status == Suspended
 }
 
+// MFADeleteEnabled returns true if MFA delete is enabled
+func (b BucketVersioningConfiguration) MFADeleteEnabled() bool {
+	return b.MFADelete == Enabled
+}
+
 // GetBucketVersioning gets the versioning configuration on
 // an existing bucket with a context to control cancellations and timeouts.
 func (c *Client) GetBucketVersioning(ctx context.Context, bucketName string) (BucketVersioningConfiguration, error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return BucketVersioningConfiguration{}, err
 	}
 
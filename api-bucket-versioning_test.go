@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openstor/openstor-go/v7/pkg/credentials"
+)
+
+func TestBucketVersioningMFADelete(t *testing.T) {
+	testCases := []struct {
+		body      string
+		mfaDelete string
+		enabled   bool
+	}{
+		{`<VersioningConfiguration><Status>Enabled</Status><MfaDelete>Enabled</MfaDelete></VersioningConfiguration>`, Enabled, true},
+		{`<VersioningConfiguration><Status>Enabled</Status><MfaDelete>Disabled</MfaDelete></VersioningConfiguration>`, Disabled, false},
+		{`<VersioningConfiguration><Status>Suspended</Status></VersioningConfiguration>`, "", false},
+	}
+
+	for i, testCase := range testCases {
+		var setBody string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut {
+				body, _ := io.ReadAll(r.Body)
+				setBody = string(body)
+				return
+			}
+			fmt.Fprint(w, testCase.body)
+		}))
+
+		// Use anonymous credentials to receive the raw request body.
+		clnt, err := New(srv.Listener.Addr().String(), &Options{
+			Creds:  credentials.NewStaticV4("", "", ""),
+			Region: "us-east-1",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		config, err := clnt.GetBucketVersioning(context.Background(), "bucket")
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if config.MFADelete != testCase.mfaDelete || config.MFADeleteEnabled() != testCase.enabled {
+			t.Fatalf("Test %d: expected MFA delete %q, got %q", i+1, testCase.mfaDelete, config.MFADelete)
+		}
+
+		// The MFA delete state is never sent back.
+		if err = clnt.SetBucketVersioning(context.Background(), "bucket", config); err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		srv.Close()
+		if strings.Contains(setBody, "MfaDelete") || !strings.Contains(setBody, "<Status>"+config.Status+"</Status>") {
+			t.Fatalf("Test %d: unexpected versioning configuration %s", i+1, setBody)
+		}
+	}
+}
+
+func TestBucketVersioningExcludedPrefixes(t *testing.T) {
+	var stored []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			stored, _ = io.ReadAll(r.Body)
+			return
+		}
+		w.Write(stored)
+	}))
+	defer srv.Close()
+
+	// Use anonymous credentials to receive the raw request body.
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:  credentials.NewStaticV4("", "", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	config := BucketVersioningConfiguration{
+		Status:           Enabled,
+		ExcludedPrefixes: []ExcludedPrefix{{Prefix: "tmp/"}, {Prefix: "cache/"}},
+		ExcludeFolders:   true,
+	}
+	if err = clnt.SetBucketVersioning(ctx, "bucket", config); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<VersioningConfiguration><Status>Enabled</Status><ExcludedPrefixes><Prefix>tmp/</Prefix></ExcludedPrefixes><ExcludedPrefixes><Prefix>cache/</Prefix></ExcludedPrefixes><ExcludeFolders>true</ExcludeFolders></VersioningConfiguration>`
+	if string(stored) != expected {
+		t.Fatalf("unexpected versioning configuration %s", stored)
+	}
+	got, err := clnt.GetBucketVersioning(ctx, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Enabled() || !got.ExcludeFolders || len(got.ExcludedPrefixes) != 2 || got.ExcludedPrefixes[1].Prefix != "cache/" {
+		t.Fatalf("unexpected versioning configuration %+v", got)
+	}
+
+	// The extensions are omitted unless set.
+	if err = clnt.SuspendVersioning(ctx, "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if string(stored) != `<VersioningConfiguration><Status>Suspended</Status></VersioningConfiguration>` {
+		t.Fatalf("unexpected versioning configuration %s", stored)
+	}
+}
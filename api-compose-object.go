@@ -41,8 +41,9 @@ type CopyDestOptions struct {
 	Bucket string // points to destination bucket
 	Object string // points to destination object
 
-	// `Encryption` is the key info for server-side-encryption with customer
-	// provided key. If it is nil, no encryption is performed.
+	// `Encryption` is the server-side-encryption to be applied to the
+	// destination, independent of the source encryption. If it is nil,
+	// no encryption is requested and the bucket default applies.
 	Encryption encrypt.ServerSide
 
 	ChecksumType ChecksumType
@@ -61,12 +62,17 @@ type CopyDestOptions struct {
 	// NOTE: if you set this value to true and now metadata is present
 	// in UserMetadata your destination object will not have any metadata
 	// set.
+	//
+	// ReplaceMetadata maps to `x-amz-metadata-directive` and does not
+	// affect tags, which are copied unless ReplaceTags is set.
 	ReplaceMetadata bool
 
 	// `userTags` is the user defined object tags to be set on destination.
 	// This will be set only if the `replaceTags` field is set to true.
 	// Otherwise this field is ignored
-	UserTags    map[string]string
+	UserTags map[string]string
+	// ReplaceTags maps to `x-amz-tagging-directive` and does not
+	// affect metadata, which is copied unless ReplaceMetadata is set.
 	ReplaceTags bool
 
 	// Specifies whether you want to apply a Legal Hold to the copied object.
@@ -82,9 +88,83 @@ type CopyDestOptions struct {
 	ContentLanguage    string
 	CacheControl       string
 
+	// StorageClass is the storage class of the destination, the
+	// standard storage class applies if it is not set.
+	StorageClass string
+
 	Size int64 // Needs to be specified if progress bar is specified.
 	// Progress of the entire copy operation will be sent here.
 	Progress io.Reader
+
+	// PreserveLastModified stores the Last-Modified time of the source
+	// in the LastModifiedMetaKey user-metadata of the destination, in
+	// RFC3339 format. S3 does not allow setting Last-Modified, so the
+	// destination Last-Modified is still the time of the copy. Unless
+	// ReplaceMetadata is set, the metadata of the source is copied
+	// along, which requires an additional StatObject call.
+	PreserveLastModified bool
+	// LastModifiedMetaKey is the user-metadata key storing the source
+	// Last-Modified time, defaults to `mc-source-mtime`.
+	LastModifiedMetaKey string
+}
+
+// defaultLastModifiedMetaKey is the user-metadata key storing the
+// source Last-Modified time if CopyDestOptions.LastModifiedMetaKey
+// is not set.
+const defaultLastModifiedMetaKey = "mc-source-mtime"
+
+// preserveLastModified returns the options replacing the destination
+// metadata with the metadata of src, when not replaced already, along
+// with the Last-Modified time of src.
+func (opts CopyDestOptions) preserveLastModified(src ObjectInfo) CopyDestOptions {
+	key := opts.LastModifiedMetaKey
+	if key == "" {
+		key = defaultLastModifiedMetaKey
+	}
+	key = strings.TrimPrefix(strings.ToLower(key), "x-amz-meta-")
+
+	if !opts.ReplaceMetadata {
+		opts = opts.replaceMetadata(src)
+	}
+
+	userMeta := opts.UserMetadata
+	opts.UserMetadata = make(map[string]string, len(userMeta)+1)
+	for k, v := range userMeta {
+		if !strings.EqualFold(strings.TrimPrefix(strings.ToLower(k), "x-amz-meta-"), key) {
+			opts.UserMetadata[k] = v
+		}
+	}
+	opts.UserMetadata[key] = src.LastModified.UTC().Format(time.RFC3339Nano)
+	opts.PreserveLastModified = false
+	return opts
+}
+
+// replaceMetadata returns the options replacing the destination
+// metadata with the metadata of src.
+func (opts CopyDestOptions) replaceMetadata(src ObjectInfo) CopyDestOptions {
+	opts.UserMetadata = src.UserMetadata
+	opts.ReplaceMetadata = true
+
+	// Standard headers are replaced along with the metadata.
+	if opts.ContentType == "" {
+		opts.ContentType = src.ContentType
+	}
+	if opts.ContentEncoding == "" {
+		opts.ContentEncoding = src.Metadata.Get("Content-Encoding")
+	}
+	if opts.ContentDisposition == "" {
+		opts.ContentDisposition = src.Metadata.Get("Content-Disposition")
+	}
+	if opts.ContentLanguage == "" {
+		opts.ContentLanguage = src.Metadata.Get("Content-Language")
+	}
+	if opts.CacheControl == "" {
+		opts.CacheControl = src.Metadata.Get("Cache-Control")
+	}
+	if opts.Expires.IsZero() {
+		opts.Expires = src.Expires
+	}
+	return opts
 }
 
 // Process custom-metadata to remove a `x-amz-meta-` prefix if
@@ -148,6 +228,9 @@ func (opts CopyDestOptions) Marshal(header http.Header) {
 	if opts.ChecksumType.IsSet() {
 		header.Set(amzChecksumAlgo, opts.ChecksumType.String())
 	}
+	if opts.StorageClass != "" {
+		header.Set(amzStorageClass, opts.StorageClass)
+	}
 
 	if opts.ReplaceMetadata {
 		header.Set("x-amz-metadata-directive", replaceDirective)
@@ -163,16 +246,20 @@ func (opts CopyDestOptions) Marshal(header http.Header) {
 
 // toDestinationInfo returns a validated copyOptions object.
 func (opts CopyDestOptions) validate() (err error) {
-	// Input validation.
-	if err = s3utils.CheckValidBucketName(opts.Bucket); err != nil {
-		return err
-	}
+	// Input validation, the bucket name is checked by the client.
 	if err = s3utils.CheckValidObjectName(opts.Object); err != nil {
 		return err
 	}
 	if opts.Progress != nil && opts.Size < 0 {
 		return errInvalidArgument("For progress bar effective size needs to be specified")
 	}
+	if opts.Encryption != nil && opts.Encryption.Type() == encrypt.SSEC {
+		h := make(http.Header)
+		opts.Encryption.Marshal(h)
+		if h.Get(encrypt.SseCopyCustomerKey) != "" {
+			return errInvalidArgument("Destination encryption cannot be a SSE-C copy key, use the key as is.")
+		}
+	}
 	return nil
 }
 
@@ -199,6 +286,19 @@ func (opts CopySrcOptions) Marshal(header http.Header) {
 		header.Set("x-amz-copy-source", s3utils.EncodePath(opts.Bucket+"/"+opts.Object)+"?versionId="+opts.VersionID)
 	}
 
+	opts.marshalConditions(header)
+
+	// Only SSE-C encrypted sources need their key to be sent, SSE-S3
+	// and SSE-KMS sources are decrypted transparently by the server and
+	// their headers would otherwise apply to the destination object.
+	if opts.Encryption != nil && opts.Encryption.Type() == encrypt.SSEC {
+		encrypt.SSECopy(opts.Encryption).Marshal(header)
+	}
+}
+
+// marshalConditions sets the copy source conditional headers, the
+// server responds with PreconditionFailed if any of them is not met.
+func (opts CopySrcOptions) marshalConditions(header http.Header) {
 	if opts.MatchETag != "" {
 		header.Set("x-amz-copy-source-if-match", opts.MatchETag)
 	}
@@ -207,22 +307,62 @@ func (opts CopySrcOptions) Marshal(header http.Header) {
 	}
 
 	if !opts.MatchModifiedSince.IsZero() {
-		header.Set("x-amz-copy-source-if-modified-since", opts.MatchModifiedSince.Format(http.TimeFormat))
+		header.Set("x-amz-copy-source-if-modified-since", opts.MatchModifiedSince.UTC().Format(http.TimeFormat))
 	}
 	if !opts.MatchUnmodifiedSince.IsZero() {
-		header.Set("x-amz-copy-source-if-unmodified-since", opts.MatchUnmodifiedSince.Format(http.TimeFormat))
+		header.Set("x-amz-copy-source-if-unmodified-since", opts.MatchUnmodifiedSince.UTC().Format(http.TimeFormat))
 	}
+}
 
-	if opts.Encryption != nil {
-		encrypt.SSECopy(opts.Encryption).Marshal(header)
+// pin restricts the copy to the source version described by srcInfo,
+// such that an object overwritten since it was read is not copied.
+func (opts *CopySrcOptions) pin(srcInfo ObjectInfo) {
+	if opts.MatchETag == "" {
+		opts.MatchETag = srcInfo.ETag
+	}
+	if opts.VersionID == "" {
+		opts.VersionID = srcInfo.VersionID
 	}
 }
 
-func (opts CopySrcOptions) validate() (err error) {
-	// Input validation.
-	if err = s3utils.CheckValidBucketName(opts.Bucket); err != nil {
-		return err
+// SetMatchETag - copy only if the source etag matches.
+func (opts *CopySrcOptions) SetMatchETag(etag string) error {
+	if etag == "" {
+		return errInvalidArgument("ETag cannot be empty.")
 	}
+	opts.MatchETag = etag
+	return nil
+}
+
+// SetMatchETagExcept - copy only if the source etag does not match.
+func (opts *CopySrcOptions) SetMatchETagExcept(etag string) error {
+	if etag == "" {
+		return errInvalidArgument("ETag cannot be empty.")
+	}
+	opts.NoMatchETag = etag
+	return nil
+}
+
+// SetModified - copy only if the source was modified since modTime.
+func (opts *CopySrcOptions) SetModified(modTime time.Time) error {
+	if modTime.IsZero() {
+		return errInvalidArgument("Modified since cannot be empty.")
+	}
+	opts.MatchModifiedSince = modTime
+	return nil
+}
+
+// SetUnmodified - copy only if the source was not modified since modTime.
+func (opts *CopySrcOptions) SetUnmodified(modTime time.Time) error {
+	if modTime.IsZero() {
+		return errInvalidArgument("Modified since cannot be empty.")
+	}
+	opts.MatchUnmodifiedSince = modTime
+	return nil
+}
+
+func (opts CopySrcOptions) validate() (err error) {
+	// Input validation, the bucket name is checked by the client.
 	if err = s3utils.CheckValidObjectName(opts.Object); err != nil {
 		return err
 	}
@@ -295,6 +435,7 @@ func (c *Client) copyObjectDo(ctx context.Context, srcBucket, srcObject, destBuc
 	if srcOpts.VersionID != "" {
 		headers.Set("x-amz-copy-source", s3utils.EncodePath(srcBucket+"/"+srcObject)+"?versionId="+srcOpts.VersionID)
 	}
+	srcOpts.marshalConditions(headers)
 	// Send upload-part-copy request
 	resp, err := c.executeMethod(ctx, http.MethodPut, reqMetadata)
 	defer closeResponse(resp)
@@ -409,6 +550,43 @@ func (c *Client) uploadPartCopy(ctx context.Context, bucket, object, uploadID st
 	return p, nil
 }
 
+// UploadPartCopy - creates a part of the multipart upload uploadID by
+// server-side copying the source object, or only the Start to End
+// byte range of it when src.MatchRange is set. The returned part is
+// meant to be passed along with the other parts to complete the
+// multipart upload, which allows building custom multipart flows
+// such as appending new data to an existing object.
+func (c *Client) UploadPartCopy(ctx context.Context, dstBucket, dstObject, uploadID string, partNumber int,
+	src CopySrcOptions,
+) (CompletePart, error) {
+	// Input validation.
+	if err := c.checkBucketName(src.Bucket); err != nil {
+		return CompletePart{}, err
+	}
+	if err := src.validate(); err != nil {
+		return CompletePart{}, err
+	}
+	if err := c.checkBucketName(dstBucket); err != nil {
+		return CompletePart{}, err
+	}
+	if err := s3utils.CheckValidObjectName(dstObject); err != nil {
+		return CompletePart{}, err
+	}
+	if uploadID == "" {
+		return CompletePart{}, errInvalidArgument("Upload ID cannot be empty.")
+	}
+	if partNumber < 1 || partNumber > maxPartsCount {
+		return CompletePart{}, errInvalidArgument(fmt.Sprintf("Part number must be between 1 and %d.", maxPartsCount))
+	}
+
+	h := make(http.Header)
+	src.Marshal(h)
+	if src.MatchRange {
+		h.Set("x-amz-copy-source-range", fmt.Sprintf("bytes=%d-%d", src.Start, src.End))
+	}
+	return c.uploadPartCopy(ctx, dstBucket, dstObject, uploadID, partNumber, h)
+}
+
 // ComposeObject - creates an object using server-side copying
 // of existing objects. It takes a list of source objects (with optional offsets)
 // and concatenates them into a new object using only server-side copying
@@ -420,14 +598,23 @@ func (c *Client) ComposeObject(ctx context.Context, dst CopyDestOptions, srcs ..
 	}
 
 	for _, src := range srcs {
+		if err := c.checkBucketName(src.Bucket); err != nil {
+			return UploadInfo{}, err
+		}
 		if err := src.validate(); err != nil {
 			return UploadInfo{}, err
 		}
 	}
 
+	if err := c.checkBucketName(dst.Bucket); err != nil {
+		return UploadInfo{}, err
+	}
 	if err := dst.validate(); err != nil {
 		return UploadInfo{}, err
 	}
+	if dst.PreserveLastModified && len(srcs) > 1 {
+		return UploadInfo{}, errInvalidArgument("PreserveLastModified requires a single source object.")
+	}
 
 	srcObjectInfos := make([]ObjectInfo, len(srcs))
 	srcObjectSizes := make([]int64, len(srcs))
@@ -479,6 +666,10 @@ func (c *Client) ComposeObject(ctx context.Context, dst CopyDestOptions, srcs ..
 		}
 	}
 
+	if dst.PreserveLastModified {
+		dst = dst.preserveLastModified(srcObjectInfos[0])
+	}
+
 	// Single source object case (i.e. when only one source is
 	// involved, it is being copied wholly and at most 5GiB in
 	// size, emptyfiles are also supported).
@@ -490,8 +681,10 @@ func (c *Client) ComposeObject(ctx context.Context, dst CopyDestOptions, srcs ..
 
 	// 1. Ensure that the object has not been changed while
 	//    we are copying data.
-	for i, src := range srcs {
-		src.MatchETag = srcObjectInfos[i].ETag
+	for i := range srcs {
+		if srcs[i].MatchETag == "" {
+			srcs[i].MatchETag = srcObjectInfos[i].ETag
+		}
 	}
 
 	// 2. Initiate a new multipart upload.
@@ -513,14 +706,27 @@ func (c *Client) ComposeObject(ctx context.Context, dst CopyDestOptions, srcs ..
 		userTags = srcObjectInfos[0].UserTags
 	}
 
-	uploadID, err := c.newUploadID(ctx, dst.Bucket, dst.Object, PutObjectOptions{
+	putOpts := PutObjectOptions{
 		ServerSideEncryption: dst.Encryption,
 		UserMetadata:         userMeta,
 		UserTags:             userTags,
 		Mode:                 dst.Mode,
 		RetainUntilDate:      dst.RetainUntilDate,
 		LegalHold:            dst.LegalHold,
-	})
+		StorageClass:         dst.StorageClass,
+	}
+	// Standard headers are part of the object metadata, they
+	// only apply when the metadata is replaced.
+	if dst.ReplaceMetadata {
+		putOpts.ContentType = dst.ContentType
+		putOpts.ContentEncoding = dst.ContentEncoding
+		putOpts.ContentDisposition = dst.ContentDisposition
+		putOpts.ContentLanguage = dst.ContentLanguage
+		putOpts.CacheControl = dst.CacheControl
+		putOpts.Expires = dst.Expires
+	}
+
+	uploadID, err := c.newUploadID(ctx, dst.Bucket, dst.Object, putOpts)
 	if err != nil {
 		return UploadInfo{}, err
 	}
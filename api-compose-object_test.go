@@ -20,10 +20,16 @@
 package openstor
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/openstor/openstor-go/v7/pkg/encrypt"
 )
 
 const (
@@ -186,3 +192,371 @@ func TestDestOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestCopyEncryptionOptions(t *testing.T) {
+	ssec, err := encrypt.NewSSEC([]byte("01234567890123456789012345678901"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	kmsSrc, err := encrypt.NewSSEKMS("src-key", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kmsDst, err := encrypt.NewSSEKMS("dst-key", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		src, dst encrypt.ServerSide
+		sse      string
+		kmsKey   string
+		copySSEC bool
+	}{
+		{nil, kmsDst, "aws:kms", "dst-key", false},
+		{ssec, kmsDst, "aws:kms", "dst-key", true},
+		{kmsSrc, kmsDst, "aws:kms", "dst-key", false},
+		{kmsSrc, encrypt.NewSSE(), "AES256", "", false},
+		{kmsSrc, nil, "", "", false},
+	}
+
+	for i, testCase := range testCases {
+		h := make(http.Header)
+		src := CopySrcOptions{Bucket: "bucket", Object: "src", Encryption: testCase.src}
+		dst := CopyDestOptions{Bucket: "bucket", Object: "dst", Encryption: testCase.dst}
+		if err := dst.validate(); err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		src.Marshal(h)
+		dst.Marshal(h)
+		if v := h.Get(encrypt.SseGenericHeader); v != testCase.sse {
+			t.Errorf("Test %d: expected %s to be %q, got %q", i+1, encrypt.SseGenericHeader, testCase.sse, v)
+		}
+		if v := h.Get(encrypt.SseKmsKeyID); v != testCase.kmsKey {
+			t.Errorf("Test %d: expected %s to be %q, got %q", i+1, encrypt.SseKmsKeyID, testCase.kmsKey, v)
+		}
+		if copySSEC := h.Get(encrypt.SseCopyCustomerKey) != ""; copySSEC != testCase.copySSEC {
+			t.Errorf("Test %d: expected SSE-C copy headers %t, got %t", i+1, testCase.copySSEC, copySSEC)
+		}
+	}
+
+	dst := CopyDestOptions{Bucket: "bucket", Object: "dst", Encryption: encrypt.SSECopy(ssec)}
+	if err := dst.validate(); err == nil {
+		t.Error("expected SSE-C copy key as destination encryption to fail")
+	}
+}
+
+func TestCopySrcOptionsConditions(t *testing.T) {
+	modTime := time.Date(2024, time.January, 2, 15, 4, 5, 0, time.FixedZone("CET", 3600))
+
+	src := CopySrcOptions{Bucket: "bucket", Object: "object"}
+	if err := src.SetMatchETag(""); err == nil {
+		t.Fatal("expected empty etag to fail")
+	}
+	if err := src.SetModified(time.Time{}); err == nil {
+		t.Fatal("expected zero time to fail")
+	}
+	if err := src.SetMatchETag("etag1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.SetMatchETagExcept("etag2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.SetModified(modTime); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.SetUnmodified(modTime); err != nil {
+		t.Fatal(err)
+	}
+
+	h := make(http.Header)
+	src.Marshal(h)
+
+	expected := map[string]string{
+		"x-amz-copy-source":                     "bucket/object",
+		"x-amz-copy-source-if-match":            "etag1",
+		"x-amz-copy-source-if-none-match":       "etag2",
+		"x-amz-copy-source-if-modified-since":   "Tue, 02 Jan 2024 14:04:05 GMT",
+		"x-amz-copy-source-if-unmodified-since": "Tue, 02 Jan 2024 14:04:05 GMT",
+	}
+	for k, v := range expected {
+		if got := h.Get(k); got != v {
+			t.Errorf("expected %s to be %q, got %q", k, v, got)
+		}
+	}
+}
+
+func TestDestOptionsDirectives(t *testing.T) {
+	testCases := []struct {
+		replaceMetadata bool
+		replaceTags     bool
+	}{
+		{false, false},
+		{true, false},
+		{false, true},
+		{true, true},
+	}
+
+	for i, testCase := range testCases {
+		dst := CopyDestOptions{
+			Bucket:          "bucket",
+			Object:          "object",
+			UserMetadata:    map[string]string{"key": "value"},
+			UserTags:        map[string]string{"tag": "value"},
+			ReplaceMetadata: testCase.replaceMetadata,
+			ReplaceTags:     testCase.replaceTags,
+		}
+		h := make(http.Header)
+		dst.Marshal(h)
+
+		if got := h.Get("x-amz-metadata-directive") == "REPLACE"; got != testCase.replaceMetadata {
+			t.Fatalf("Test %d: expected metadata REPLACE to be %t", i+1, testCase.replaceMetadata)
+		}
+		if got := h.Get("x-amz-meta-key") != ""; got != testCase.replaceMetadata {
+			t.Fatalf("Test %d: expected metadata to be sent: %t", i+1, testCase.replaceMetadata)
+		}
+		if got := h.Get(amzTaggingHeaderDirective) == "REPLACE"; got != testCase.replaceTags {
+			t.Fatalf("Test %d: expected tagging REPLACE to be %t", i+1, testCase.replaceTags)
+		}
+		if got := h.Get(amzTaggingHeader) != ""; got != testCase.replaceTags {
+			t.Fatalf("Test %d: expected tags to be sent: %t", i+1, testCase.replaceTags)
+		}
+	}
+}
+
+func TestUploadPartCopy(t *testing.T) {
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT, got %s", r.Method)
+		}
+		if got := r.URL.Query().Get("uploadId"); got != "upload-1" {
+			t.Errorf("unexpected uploadId %q", got)
+		}
+		if got := r.URL.Query().Get("partNumber"); got != "2" {
+			t.Errorf("unexpected partNumber %q", got)
+		}
+		if got := r.Header.Get("x-amz-copy-source"); got != "src-bucket/log" {
+			t.Errorf("unexpected copy source %q", got)
+		}
+		if got := r.Header.Get("x-amz-copy-source-range"); got != "bytes=0-99" {
+			t.Errorf("unexpected copy source range %q", got)
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `<CopyPartResult><ETag>"part-etag"</ETag><LastModified>2025-01-01T00:00:00.000Z</LastModified></CopyPartResult>`)
+	}))
+
+	src := CopySrcOptions{Bucket: "src-bucket", Object: "log", MatchRange: true, Start: 0, End: 99}
+	part, err := clnt.UploadPartCopy(context.Background(), "bucket", "log", "upload-1", 2, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if part.PartNumber != 2 || part.ETag != `"part-etag"` {
+		t.Fatalf("unexpected part %+v", part)
+	}
+
+	if _, err = clnt.UploadPartCopy(context.Background(), "bucket", "log", "", 2, src); err == nil {
+		t.Fatal("expected empty upload ID to fail")
+	}
+	if _, err = clnt.UploadPartCopy(context.Background(), "bucket", "log", "upload-1", 0, src); err == nil {
+		t.Fatal("expected invalid part number to fail")
+	}
+}
+
+func TestCopyObjectPreconditionFailed(t *testing.T) {
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-amz-copy-source-if-match") != "etag1" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, `<CopyObjectResult><ETag>"etag1"</ETag><LastModified>2025-01-01T00:00:00.000Z</LastModified></CopyObjectResult>`)
+			return
+		}
+		if got := r.Header.Get("x-amz-copy-source-if-modified-since"); got != "Tue, 02 Jan 2024 14:04:05 GMT" {
+			t.Errorf("unexpected if-modified-since %q", got)
+		}
+		w.WriteHeader(http.StatusPreconditionFailed)
+		fmt.Fprint(w, `<Error><Code>PreconditionFailed</Code><Message>At least one of the pre-conditions you specified did not hold</Message></Error>`)
+	}))
+
+	dst := CopyDestOptions{Bucket: "bucket", Object: "dst"}
+	src := CopySrcOptions{
+		Bucket:             "bucket",
+		Object:             "src",
+		MatchETag:          "etag1",
+		MatchModifiedSince: time.Date(2024, time.January, 2, 14, 4, 5, 0, time.UTC),
+	}
+	_, err := clnt.CopyObject(context.Background(), dst, src)
+	if !errors.Is(err, ErrPreconditionFailed) {
+		t.Fatalf("expected ErrPreconditionFailed, got %v", err)
+	}
+
+	if _, err = clnt.CopyObject(context.Background(), dst, CopySrcOptions{Bucket: "bucket", Object: "src"}); err != nil {
+		t.Fatal(err)
+	}
+	if errors.Is(ErrorResponse{Code: NoSuchKey}, ErrPreconditionFailed) {
+		t.Fatal("expected NoSuchKey not to match ErrPreconditionFailed")
+	}
+}
+
+func TestCopyObjectPreserveLastModified(t *testing.T) {
+	testCases := []struct {
+		dst      CopyDestOptions
+		metaKey  string
+		expected map[string]string
+	}{
+		{
+			CopyDestOptions{PreserveLastModified: true},
+			"X-Amz-Meta-Mc-Source-Mtime",
+			map[string]string{"X-Amz-Meta-Owner": "alice", "Content-Type": "text/plain", "Cache-Control": "no-cache"},
+		},
+		{
+			CopyDestOptions{PreserveLastModified: true, LastModifiedMetaKey: "x-amz-meta-original-mtime", ContentType: "text/csv"},
+			"X-Amz-Meta-Original-Mtime",
+			map[string]string{"X-Amz-Meta-Owner": "alice", "Content-Type": "text/csv"},
+		},
+		{
+			CopyDestOptions{PreserveLastModified: true, ReplaceMetadata: true, UserMetadata: map[string]string{"team": "data"}},
+			"X-Amz-Meta-Mc-Source-Mtime",
+			map[string]string{"X-Amz-Meta-Team": "data", "X-Amz-Meta-Owner": ""},
+		},
+	}
+
+	for i, testCase := range testCases {
+		var copyHeader http.Header
+		clnt, srv := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodHead {
+				w.Header().Set("Last-Modified", "Tue, 02 Jan 2024 14:04:05 GMT")
+				w.Header().Set("ETag", `"etag"`)
+				w.Header().Set("Content-Length", "0")
+				w.Header().Set("Content-Type", "text/plain")
+				w.Header().Set("Cache-Control", "no-cache")
+				w.Header().Set("X-Amz-Meta-Owner", "alice")
+				w.Header().Set("X-Amz-Meta-Mc-Source-Mtime", "2000-01-01T00:00:00Z")
+				w.Header().Set("X-Amz-Version-Id", "v1")
+				return
+			}
+			copyHeader = r.Header.Clone()
+			fmt.Fprint(w, `<CopyObjectResult><ETag>"etag"</ETag><LastModified>2025-01-01T00:00:00.000Z</LastModified></CopyObjectResult>`)
+		}))
+
+		dst := testCase.dst
+		dst.Bucket, dst.Object = "bucket", "dst"
+		_, err := clnt.CopyObject(context.Background(), dst, CopySrcOptions{Bucket: "bucket", Object: "src"})
+		srv.Close()
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if got := copyHeader.Get("x-amz-metadata-directive"); got != "REPLACE" {
+			t.Fatalf("Test %d: expected the metadata to be replaced, got %q", i+1, got)
+		}
+		if got := copyHeader.Values(testCase.metaKey); len(got) != 1 || got[0] != "2024-01-02T14:04:05Z" {
+			t.Fatalf("Test %d: unexpected %s %q", i+1, testCase.metaKey, got)
+		}
+		for k, v := range testCase.expected {
+			if got := copyHeader.Get(k); got != v {
+				t.Fatalf("Test %d: expected %s %q, got %q", i+1, k, v, got)
+			}
+		}
+		// The copy is pinned to the version the modification time was read from.
+		if got := copyHeader.Get("x-amz-copy-source"); got != "bucket/src?versionId=v1" {
+			t.Fatalf("Test %d: unexpected copy source %q", i+1, got)
+		}
+		if got := copyHeader.Get("x-amz-copy-source-if-match"); got != "etag" {
+			t.Fatalf("Test %d: unexpected copy source condition %q", i+1, got)
+		}
+	}
+}
+
+func TestCopyObjectMetadata(t *testing.T) {
+	testCases := []struct {
+		size     int64
+		sse      string
+		dst      CopyDestOptions
+		expected map[string]string
+	}{
+		{
+			0,
+			"aws:kms",
+			CopyDestOptions{},
+			map[string]string{"X-Amz-Storage-Class": "GLACIER_IR", "X-Amz-Server-Side-Encryption": "aws:kms", "X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id": "key", "X-Amz-Metadata-Directive": "", "X-Amz-Meta-Owner": "", "X-Amz-Copy-Source": "bucket/src?versionId=v1", "X-Amz-Copy-Source-If-Match": "etag"},
+		},
+		{
+			0,
+			"aws:kms",
+			CopyDestOptions{StorageClass: "STANDARD_IA", Encryption: encrypt.NewSSE()},
+			map[string]string{"X-Amz-Storage-Class": "STANDARD_IA", "X-Amz-Server-Side-Encryption": "AES256", "X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id": ""},
+		},
+		{
+			0,
+			"aws:kms:dsse",
+			CopyDestOptions{},
+			map[string]string{"X-Amz-Server-Side-Encryption": "aws:kms:dsse", "X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id": "key"},
+		},
+		{
+			gb5p1,
+			"aws:kms",
+			CopyDestOptions{},
+			map[string]string{"X-Amz-Storage-Class": "GLACIER_IR", "X-Amz-Server-Side-Encryption": "aws:kms", "X-Amz-Meta-Owner": "alice", "Content-Type": "text/csv", "X-Amz-Tagging": "team=data"},
+		},
+	}
+
+	for i, testCase := range testCases {
+		var header http.Header
+		var parts int
+		clnt, srv := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			switch {
+			case r.Method == http.MethodHead:
+				w.Header().Set("Last-Modified", "Tue, 02 Jan 2024 14:04:05 GMT")
+				w.Header().Set("ETag", `"etag"`)
+				w.Header().Set("Content-Length", fmt.Sprint(testCase.size))
+				w.Header().Set("Content-Type", "text/csv")
+				w.Header().Set("X-Amz-Meta-Owner", "alice")
+				w.Header().Set("X-Amz-Storage-Class", "GLACIER_IR")
+				w.Header().Set("X-Amz-Server-Side-Encryption", testCase.sse)
+				w.Header().Set("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id", "key")
+				w.Header().Set("X-Amz-Tagging-Count", "1")
+				w.Header().Set("X-Amz-Version-Id", "v1")
+			case query.Has("tagging"):
+				fmt.Fprint(w, `<Tagging><TagSet><Tag><Key>team</Key><Value>data</Value></Tag></TagSet></Tagging>`)
+			case query.Has("uploads"):
+				header = r.Header.Clone()
+				fmt.Fprint(w, `<InitiateMultipartUploadResult><Bucket>bucket</Bucket><Key>dst</Key><UploadId>upload</UploadId></InitiateMultipartUploadResult>`)
+			case query.Has("partNumber"):
+				if r.Header.Get("X-Amz-Copy-Source-If-Match") != "etag" || r.Header.Get("X-Amz-Copy-Source") != "bucket/src?versionId=v1" {
+					t.Errorf("Test %d: expected the source version to be matched", i+1)
+				}
+				parts++
+				fmt.Fprint(w, `<CopyPartResult><ETag>"part"</ETag><LastModified>2025-01-01T00:00:00.000Z</LastModified></CopyPartResult>`)
+			case query.Has("uploadId"):
+				fmt.Fprint(w, `<CompleteMultipartUploadResult><Bucket>bucket</Bucket><Key>dst</Key><ETag>"etag-2"</ETag></CompleteMultipartUploadResult>`)
+			default:
+				header = r.Header.Clone()
+				fmt.Fprint(w, `<CopyObjectResult><ETag>"etag"</ETag><LastModified>2025-01-01T00:00:00.000Z</LastModified></CopyObjectResult>`)
+			}
+		}))
+
+		dst := testCase.dst
+		dst.Bucket, dst.Object = "bucket", "dst"
+		_, err := clnt.CopyObjectMetadata(context.Background(), dst, CopySrcOptions{Bucket: "bucket", Object: "src"})
+		srv.Close()
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if testCase.size > gb5 && parts < 2 {
+			t.Fatalf("Test %d: expected a multipart copy, got %d parts", i+1, parts)
+		}
+		for k, v := range testCase.expected {
+			if got := header.Get(k); got != v {
+				t.Fatalf("Test %d: expected %s %q, got %q", i+1, k, v, got)
+			}
+		}
+	}
+
+	clnt, err := New("localhost:9000", &Options{Region: "us-east-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := CopySrcOptions{Bucket: "bucket", Object: "src", MatchRange: true, End: 1}
+	if _, err = clnt.CopyObjectMetadata(context.Background(), CopyDestOptions{Bucket: "bucket", Object: "dst"}, src); ToErrorResponse(err).Code != InvalidArgument {
+		t.Fatalf("expected the source range to be rejected, got %v", err)
+	}
+}
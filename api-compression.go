@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+)
+
+// ContentCompression is the client-side compression PutObject applies
+// to the object content before uploading it.
+type ContentCompression string
+
+const (
+	// CompressionNone uploads the content as is.
+	CompressionNone ContentCompression = ""
+	// CompressionGzip compresses the content with gzip.
+	CompressionGzip ContentCompression = "gzip"
+	// CompressionZstd compresses the content with zstandard.
+	CompressionZstd ContentCompression = "zstd"
+)
+
+// IsValid tells whether the compression is supported.
+func (c ContentCompression) IsValid() bool {
+	switch c {
+	case CompressionNone, CompressionGzip, CompressionZstd:
+		return true
+	}
+	return false
+}
+
+// UncompressedSizeMetaKey is the user-metadata key storing the size of
+// the content before compression, see PutObjectOptions.Compress.
+const UncompressedSizeMetaKey = "Uncompressed-Size"
+
+// compressedContentTypes are content types whose content is already
+// compressed, and not compressed again.
+var compressedContentTypes = []string{
+	"application/gzip",
+	"application/x-gzip",
+	"application/zstd",
+	"application/zip",
+	"application/x-bzip2",
+	"application/x-xz",
+	"application/x-7z-compressed",
+	"application/x-rar-compressed",
+	"application/vnd.rar",
+}
+
+// isCompressedContentType tells whether content of this type is
+// already compressed.
+func isCompressedContentType(contentType string) bool {
+	contentType, _, _ = strings.Cut(strings.ToLower(contentType), ";")
+	contentType = strings.TrimSpace(contentType)
+	for _, prefix := range []string{"image/", "video/", "audio/"} {
+		if strings.HasPrefix(contentType, prefix) {
+			return contentType != "image/svg+xml" && contentType != "image/bmp"
+		}
+	}
+	for _, compressed := range compressedContentTypes {
+		if contentType == compressed {
+			return true
+		}
+	}
+	return false
+}
+
+// compress returns whether the content of the upload is compressed.
+func (opts PutObjectOptions) compress() bool {
+	return opts.Compress != CompressionNone && opts.ContentEncoding == "" && !isCompressedContentType(opts.ContentType)
+}
+
+// compressUpload returns the reader and size of the compressed upload
+// content and the options storing its encoding. Uploads fitting into
+// a single part are compressed in memory to keep their size known,
+// others are compressed while being uploaded. Readers shorter than
+// size fail the upload with an error matching io.ErrUnexpectedEOF.
+// The returned reader must be closed once the upload is done.
+func compressUpload(reader io.Reader, size int64, opts PutObjectOptions) (io.ReadCloser, int64, PutObjectOptions, error) {
+	opts.ContentEncoding = string(opts.Compress)
+	if size >= 0 {
+		opts.UserMetadata = maps.Clone(opts.UserMetadata)
+		if opts.UserMetadata == nil {
+			opts.UserMetadata = make(map[string]string, 1)
+		}
+		opts.UserMetadata[UncompressedSizeMetaKey] = strconv.FormatInt(size, 10)
+	}
+
+	partSize := opts.PartSize
+	if partSize == 0 {
+		partSize = minPartSize
+	}
+	if size >= 0 && size <= int64(partSize) {
+		var buf bytes.Buffer
+		w, err := newCompressWriter(&buf, opts.Compress)
+		if err != nil {
+			return nil, 0, opts, err
+		}
+		n, err := io.Copy(w, io.LimitReader(reader, size))
+		if err != nil {
+			return nil, 0, opts, err
+		}
+		if n < size {
+			return nil, 0, opts, errShortCompressRead(n, size)
+		}
+		if err = w.Close(); err != nil {
+			return nil, 0, opts, err
+		}
+		// Keep the content seekable for retries.
+		return bytesReadCloser{bytes.NewReader(buf.Bytes())}, int64(buf.Len()), opts, nil
+	}
+
+	if size >= 0 {
+		reader = io.LimitReader(reader, size)
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		w, err := newCompressWriter(pw, opts.Compress)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		n, err := io.Copy(w, reader)
+		if err == nil && size >= 0 && n < size {
+			err = errShortCompressRead(n, size)
+		}
+		if err != nil {
+			// Fail the upload before releasing the writer, the
+			// compressed stream of truncated content is never ended.
+			pw.CloseWithError(err)
+			w.Close()
+			return
+		}
+		pw.CloseWithError(w.Close())
+	}()
+	return pr, -1, opts, nil
+}
+
+// errShortCompressRead returns the error of content shorter than the
+// size of the upload. It wraps io.ErrUnexpectedEOF without being equal
+// to it, readFull would take it for the end of the content otherwise.
+func errShortCompressRead(n, size int64) error {
+	return fmt.Errorf("%w: read %d of %d bytes to compress", io.ErrUnexpectedEOF, n, size)
+}
+
+// bytesReadCloser is a bytes.Reader with a no-op Close method.
+type bytesReadCloser struct {
+	*bytes.Reader
+}
+
+func (bytesReadCloser) Close() error { return nil }
+
+// newCompressWriter returns a writer compressing the content written
+// to w.
+func newCompressWriter(w io.Writer, compression ContentCompression) (io.WriteCloser, error) {
+	if compression == CompressionZstd {
+		return zstd.NewWriter(w)
+	}
+	return gzip.NewWriter(w), nil
+}
+
+// newContentDecoder returns a reader decoding r according to the
+// Content-Encoding of an object. Content of other encodings is
+// returned as is.
+func newContentDecoder(contentEncoding string, r io.Reader) (io.ReadCloser, error) {
+	switch strings.ToLower(contentEncoding) {
+	case "gzip", "x-gzip":
+		return gzip.NewReader(r)
+	case "zstd":
+		d, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	}
+	return io.NopCloser(r), nil
+}
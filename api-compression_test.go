@@ -0,0 +1,268 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/openstor/openstor-go/v7/pkg/credentials"
+)
+
+func TestIsCompressedContentType(t *testing.T) {
+	testCases := []struct {
+		contentType string
+		compressed  bool
+	}{
+		{"", false},
+		{"text/plain; charset=utf-8", false},
+		{"application/json", false},
+		{"image/svg+xml", false},
+		{"image/png", true},
+		{"video/mp4", true},
+		{"Application/Gzip", true},
+		{"application/zip", true},
+	}
+	for i, testCase := range testCases {
+		if got := isCompressedContentType(testCase.contentType); got != testCase.compressed {
+			t.Fatalf("Test %d: expected %t for %q, got %t", i+1, testCase.compressed, testCase.contentType, got)
+		}
+	}
+}
+
+func TestPutObjectCompress(t *testing.T) {
+	data := bytes.Repeat([]byte("compressible text "), 1024)
+	var stored []byte
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			header = r.Header.Clone()
+			stored, _ = io.ReadAll(r.Body)
+			w.Header().Set("ETag", `"etag"`)
+		case http.MethodGet:
+			w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+			w.Header().Set("Content-Length", strconv.Itoa(len(stored)))
+			w.Header().Set("Content-Encoding", header.Get("Content-Encoding"))
+			w.Write(stored)
+		}
+	}))
+	defer srv.Close()
+
+	// Use anonymous credentials to receive the raw data.
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:  credentials.NewStaticV4("", "", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	testCases := []struct {
+		opts     PutObjectOptions
+		encoding string
+	}{
+		{PutObjectOptions{Compress: CompressionGzip}, "gzip"},
+		{PutObjectOptions{Compress: CompressionZstd, UserMetadata: map[string]string{"owner": "alice"}}, "zstd"},
+		{PutObjectOptions{Compress: CompressionGzip, ContentType: "image/png"}, ""},
+		{PutObjectOptions{Compress: CompressionGzip, ContentEncoding: "br"}, "br"},
+	}
+	for i, testCase := range testCases {
+		if _, err = clnt.PutObject(ctx, "bucket", "object", bytes.NewReader(data), int64(len(data)), testCase.opts); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if got := header.Get("Content-Encoding"); got != testCase.encoding {
+			t.Fatalf("Test %d: expected Content-Encoding %q, got %q", i+1, testCase.encoding, got)
+		}
+		compressed := testCase.encoding == "gzip" || testCase.encoding == "zstd"
+		if compressed {
+			if len(stored) >= len(data) {
+				t.Fatalf("Test %d: expected the content to be compressed, got %d bytes", i+1, len(stored))
+			}
+			if got := header.Get("X-Amz-Meta-" + UncompressedSizeMetaKey); got != strconv.Itoa(len(data)) {
+				t.Fatalf("Test %d: unexpected uncompressed size %q", i+1, got)
+			}
+		} else if !bytes.Equal(stored, data) {
+			t.Fatalf("Test %d: expected the content to be uploaded as is", i+1)
+		}
+
+		obj, err := clnt.GetObject(ctx, "bucket", "object", GetObjectOptions{Decompress: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(obj)
+		if compressed && (err != nil || !bytes.Equal(got, data)) {
+			t.Fatalf("Test %d: unexpected decompressed content of %d bytes, %v", i+1, len(got), err)
+		}
+		if _, err = obj.Seek(0, io.SeekStart); ToErrorResponse(err).Code != InvalidArgument {
+			t.Fatalf("Test %d: expected Seek to be rejected, got %v", i+1, err)
+		}
+		obj.Close()
+	}
+	if _, ok := testCases[1].opts.UserMetadata[UncompressedSizeMetaKey]; ok {
+		t.Fatal("expected the user metadata of the caller not to be modified")
+	}
+
+	// The stored content is returned unless decompressing.
+	obj, err := clnt.GetObject(ctx, "bucket", "object", GetObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := io.ReadAll(obj); !bytes.Equal(got, stored) {
+		t.Fatal("expected the stored content")
+	}
+	obj.Close()
+
+	if _, err = clnt.PutObject(ctx, "bucket", "object", bytes.NewReader(data), int64(len(data)), PutObjectOptions{Compress: "lz4"}); ToErrorResponse(err).Code != InvalidArgument {
+		t.Fatalf("expected the compression to be rejected, got %v", err)
+	}
+}
+
+func TestPutObjectCompressUnknownSize(t *testing.T) {
+	data := bytes.Repeat([]byte("compressible text "), 64*1024)
+	mpSrv := &multipartServer{
+		parts:    make(map[string]map[int][]byte),
+		partPuts: make(map[int]int),
+	}
+	var encoding string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("uploads") {
+			encoding = r.Header.Get("Content-Encoding")
+		}
+		mpSrv.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	// Use anonymous credentials to receive the raw part data.
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:  credentials.NewStaticV4("", "", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = clnt.PutObject(context.Background(), "bucket", "object", io.MultiReader(bytes.NewReader(data)), -1, PutObjectOptions{
+		Compress: CompressionZstd,
+		PartSize: absMinPartSize,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mpSrv.uploads != 1 || encoding != "zstd" {
+		t.Fatalf("expected a zstd encoded multipart upload, got %d uploads encoded with %q", mpSrv.uploads, encoding)
+	}
+	d, err := zstd.NewReader(bytes.NewReader(mpSrv.completed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if got, err := io.ReadAll(d); err != nil || !bytes.Equal(got, data) {
+		t.Fatalf("unexpected decompressed content of %d bytes, %v", len(got), err)
+	}
+}
+
+func TestPutObjectCompressShortRead(t *testing.T) {
+	mpSrv := &multipartServer{
+		parts:    make(map[string]map[int][]byte),
+		partPuts: make(map[int]int),
+	}
+	srv := httptest.NewServer(mpSrv)
+	defer srv.Close()
+
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:  credentials.NewStaticV4("", "", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("compressible text "), 1024)
+	for i, size := range []int64{int64(len(data)) + 1, 2 * absMinPartSize} {
+		_, err = clnt.PutObject(context.Background(), "bucket", "object", bytes.NewReader(data), size, PutObjectOptions{
+			Compress: CompressionGzip,
+			PartSize: absMinPartSize,
+		})
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("Test %d: expected the short read to fail the upload, got %v", i+1, err)
+		}
+	}
+	if mpSrv.completed != nil {
+		t.Fatalf("expected no upload to be completed, got %d bytes", len(mpSrv.completed))
+	}
+}
+
+func TestPutObjectCompressDisableMultipart(t *testing.T) {
+	data := bytes.Repeat([]byte("compressible text "), 64*1024)
+	var stored []byte
+	var puts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || len(r.URL.Query()) > 0 {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		puts++
+		if r.ContentLength < 0 {
+			t.Error("expected the compressed size to be sent")
+		}
+		stored, _ = io.ReadAll(r.Body)
+		w.Header().Set("ETag", `"etag"`)
+	}))
+	defer srv.Close()
+
+	// Use anonymous credentials to receive the raw data.
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:  credentials.NewStaticV4("", "", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = clnt.PutObject(context.Background(), "bucket", "object", bytes.NewReader(data), int64(len(data)), PutObjectOptions{
+		Compress:         CompressionGzip,
+		PartSize:         absMinPartSize,
+		DisableMultipart: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if puts != 1 {
+		t.Fatalf("expected a single PUT, got %d", puts)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(stored))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := io.ReadAll(r); err != nil || !bytes.Equal(got, data) {
+		t.Fatalf("unexpected decompressed content of %d bytes, %v", len(got), err)
+	}
+}
@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/openstor/openstor-go/v7/pkg/encrypt"
+)
+
+// CrossCopyOptions represents options for CopyObjectCrossClient.
+type CrossCopyOptions struct {
+	// VersionID of the source object, the latest version when empty.
+	VersionID string
+
+	// SrcEncryption is the SSE-C key of the source object, if any.
+	SrcEncryption encrypt.ServerSide
+
+	// DstEncryption is the server side encryption of the destination
+	// object, if any.
+	DstEncryption encrypt.ServerSide
+
+	// PartSize is the size of the ranged GETs on the source and of the
+	// parts uploaded to the destination, computed from the object size
+	// when zero. Objects up to the part size are copied with a single PUT.
+	PartSize uint64
+
+	// NumThreads is the number of parts copied concurrently.
+	NumThreads uint
+
+	// Progress is read as the object is uploaded to the destination.
+	Progress io.Reader
+}
+
+// CopyObjectCrossClient copies an object between two clients, e.g. of
+// different S3 compatible providers, which server side copies cannot
+// cross. The source object is streamed from src into a PUT, or a
+// multipart upload fed by ranged GETs for large objects, on dst without
+// being buffered as a whole. Its content type, content headers, user
+// metadata and tags are preserved. The copy fails with a
+// PreconditionFailed error if the source object is modified meanwhile.
+func CopyObjectCrossClient(ctx context.Context, src *Client, srcBucket, srcObject string, dst *Client, dstBucket, dstObject string, opts CrossCopyOptions) (UploadInfo, error) {
+	if src == nil || dst == nil {
+		return UploadInfo{}, errInvalidArgument("Source and destination clients cannot be nil.")
+	}
+
+	objInfo, err := src.StatObject(ctx, srcBucket, srcObject, StatObjectOptions{
+		VersionID:            opts.VersionID,
+		ServerSideEncryption: opts.SrcEncryption,
+	})
+	if err != nil {
+		return UploadInfo{}, err
+	}
+
+	// Fetch the tags unless all of them were returned with the object.
+	userTags := objInfo.UserTags
+	if objInfo.UserTagCount > len(userTags) {
+		t, err := src.GetObjectTagging(ctx, srcBucket, srcObject, GetObjectTaggingOptions{
+			VersionID: objInfo.VersionID,
+		})
+		if err != nil {
+			return UploadInfo{}, err
+		}
+		userTags = t.ToMap()
+	}
+
+	// Objects up to the part size are uploaded with a single PUT,
+	// others with parts of the size computed by PutObject.
+	threshold := int64(opts.PartSize)
+	if threshold == 0 {
+		threshold = minPartSize
+	}
+	partSize := objInfo.Size
+	if objInfo.Size > threshold {
+		_, partSize, _, err = OptimalPartInfo(objInfo.Size, opts.PartSize)
+		if err != nil {
+			return UploadInfo{}, err
+		}
+	}
+
+	reader := &crossCopyReader{
+		ctx:       ctx,
+		clnt:      src,
+		bucket:    srcBucket,
+		object:    srcObject,
+		versionID: objInfo.VersionID,
+		etag:      objInfo.ETag,
+		sse:       opts.SrcEncryption,
+		size:      objInfo.Size,
+		partSize:  partSize,
+		streams:   make(map[int64]io.ReadCloser),
+	}
+	defer reader.Close()
+
+	return dst.PutObject(ctx, dstBucket, dstObject, reader, objInfo.Size, PutObjectOptions{
+		UserMetadata:         objInfo.UserMetadata,
+		UserTags:             userTags,
+		ContentType:          objInfo.ContentType,
+		ContentEncoding:      objInfo.ContentEncoding,
+		ContentDisposition:   objInfo.Metadata.Get("Content-Disposition"),
+		ContentLanguage:      objInfo.Metadata.Get("Content-Language"),
+		CacheControl:         objInfo.Metadata.Get("Cache-Control"),
+		Expires:              objInfo.Expires,
+		ServerSideEncryption: opts.DstEncryption,
+		PartSize:             opts.PartSize,
+		NumThreads:           opts.NumThreads,
+		Progress:             opts.Progress,
+	})
+}
+
+// crossCopyReader reads the source object of CopyObjectCrossClient
+// through ranged GETs, each spanning up to the end of the part being
+// read. The GETs are kept open for the next read of their part, such
+// that the concurrent part uploads each stream from their own GET.
+type crossCopyReader struct {
+	ctx       context.Context
+	clnt      *Client
+	bucket    string
+	object    string
+	versionID string
+	etag      string
+	sse       encrypt.ServerSide
+	size      int64
+	partSize  int64
+
+	// offset of the next Read.
+	offset int64
+
+	mu sync.Mutex
+	// streams are the open GETs by the offset they read next.
+	streams map[int64]io.ReadCloser
+}
+
+// Read reads the object sequentially.
+func (r *crossCopyReader) Read(p []byte) (int, error) {
+	n, err := r.read(p, r.offset)
+	r.offset += int64(n)
+	return n, err
+}
+
+// ReadAt reads len(p) bytes of the object at offset.
+func (r *crossCopyReader) ReadAt(p []byte, offset int64) (n int, err error) {
+	for n < len(p) && err == nil {
+		var m int
+		m, err = r.read(p[n:], offset+int64(n))
+		n += m
+	}
+	return n, err
+}
+
+// read reads up to len(p) bytes of the object at offset, from the GET
+// left open at that offset or from a new one.
+func (r *crossCopyReader) read(p []byte, offset int64) (int, error) {
+	if offset >= r.size {
+		return 0, io.EOF
+	}
+	end := r.size
+	if r.partSize > 0 {
+		end = min(end, (offset/r.partSize+1)*r.partSize)
+	}
+
+	r.mu.Lock()
+	stream, ok := r.streams[offset]
+	delete(r.streams, offset)
+	r.mu.Unlock()
+	if !ok {
+		opts := GetObjectOptions{
+			VersionID:            r.versionID,
+			ServerSideEncryption: r.sse,
+		}
+		if r.etag != "" {
+			opts.SetMatchETag(r.etag)
+		}
+		if err := opts.SetRange(offset, end-1); err != nil {
+			return 0, err
+		}
+		var err error
+		stream, _, _, err = r.clnt.getObject(r.ctx, r.bucket, r.object, opts)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := stream.Read(p[:min(int64(len(p)), end-offset)])
+	offset += int64(n)
+	if err == io.EOF && offset < end {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil || offset == end {
+		stream.Close()
+		if err == io.EOF {
+			err = nil
+		}
+		return n, err
+	}
+
+	r.mu.Lock()
+	r.streams[offset] = stream
+	r.mu.Unlock()
+	return n, nil
+}
+
+// Close closes the GETs left open.
+func (r *crossCopyReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for offset, stream := range r.streams {
+		stream.Close()
+		delete(r.streams, offset)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openstor/openstor-go/v7/pkg/credentials"
+)
+
+// crossCopySource serves an object with its metadata, tags and ranges.
+type crossCopySource struct {
+	mu     sync.Mutex
+	data   []byte
+	etag   string
+	ranges []string
+}
+
+func (s *crossCopySource) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.URL.Query().Has("tagging") {
+		io.WriteString(w, `<Tagging><TagSet><Tag><Key>project</Key><Value>migration</Value></Tag></TagSet></Tagging>`)
+		return
+	}
+	if r.Method == http.MethodGet {
+		s.ranges = append(s.ranges, r.Header.Get("Range"))
+	}
+	w.Header().Set("ETag", `"`+s.etag+`"`)
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Cache-Control", "max-age=60")
+	w.Header().Set("X-Amz-Meta-Owner", "alice")
+	w.Header().Set("X-Amz-Tagging-Count", "1")
+	http.ServeContent(w, r, "object", time.Now(), bytes.NewReader(s.data))
+}
+
+func TestCopyObjectCrossClient(t *testing.T) {
+	source := &crossCopySource{
+		data: bytes.Repeat([]byte("0123456789abcdef"), 2*absMinPartSize/16+1024),
+		etag: "etag",
+	}
+	srcSrv := httptest.NewServer(source)
+	defer srcSrv.Close()
+
+	mpSrv := &multipartServer{
+		parts:    make(map[string]map[int][]byte),
+		partPuts: make(map[int]int),
+	}
+	var putHeader http.Header
+	var putBody []byte
+	dstSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.Method == http.MethodPost && query.Has("uploads"):
+			putHeader = r.Header.Clone()
+		case r.Method == http.MethodPut && !query.Has("uploadId"):
+			putHeader = r.Header.Clone()
+			putBody, _ = io.ReadAll(r.Body)
+			w.Header().Set("ETag", `"etag"`)
+			return
+		}
+		mpSrv.ServeHTTP(w, r)
+	}))
+	defer dstSrv.Close()
+
+	newClient := func(srv *httptest.Server) *Client {
+		// Use anonymous credentials to receive the raw data.
+		clnt, err := New(srv.Listener.Addr().String(), &Options{
+			Creds:  credentials.NewStaticV4("", "", ""),
+			Region: "us-east-1",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return clnt
+	}
+	src, dst := newClient(srcSrv), newClient(dstSrv)
+	ctx := context.Background()
+
+	checkHeader := func() {
+		t.Helper()
+		if putHeader.Get("Content-Type") != "text/csv" || putHeader.Get("Cache-Control") != "max-age=60" {
+			t.Fatalf("expected the content headers to be preserved, got %v", putHeader)
+		}
+		if putHeader.Get("X-Amz-Meta-Owner") != "alice" || putHeader.Get("X-Amz-Tagging") != "project=migration" {
+			t.Fatalf("expected the metadata and tags to be preserved, got %v", putHeader)
+		}
+	}
+
+	// Copy with ranged GETs feeding a multipart upload.
+	if _, err := CopyObjectCrossClient(ctx, src, "srcbucket", "object", dst, "dstbucket", "object", CrossCopyOptions{
+		PartSize:   absMinPartSize,
+		NumThreads: 2,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if mpSrv.uploads != 1 || !bytes.Equal(mpSrv.completed, source.data) {
+		t.Fatalf("unexpected multipart upload of %d bytes in %d uploads", len(mpSrv.completed), mpSrv.uploads)
+	}
+	checkHeader()
+	if len(source.ranges) != 3 {
+		t.Fatalf("expected a ranged GET per part, got %q", source.ranges)
+	}
+	for _, rng := range source.ranges {
+		if rng == "" {
+			t.Fatalf("expected ranged GETs, got %q", source.ranges)
+		}
+	}
+
+	// Copy with a single PUT.
+	source.data = source.data[:1024]
+	source.ranges = nil
+	if _, err := CopyObjectCrossClient(ctx, src, "srcbucket", "object", dst, "dstbucket", "object", CrossCopyOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(putBody, source.data) || len(source.ranges) != 1 {
+		t.Fatalf("unexpected PUT of %d bytes from %q", len(putBody), source.ranges)
+	}
+	checkHeader()
+
+	// The source changing during the copy fails it.
+	changeSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && !r.URL.Query().Has("tagging") {
+			source.mu.Lock()
+			source.etag = "changed"
+			source.mu.Unlock()
+		}
+		source.ServeHTTP(w, r)
+	}))
+	defer changeSrv.Close()
+	if _, err := CopyObjectCrossClient(ctx, newClient(changeSrv), "srcbucket", "object", dst, "dstbucket", "object", CrossCopyOptions{}); !errors.Is(err, ErrPreconditionFailed) {
+		t.Fatalf("expected the copy to fail on the changed source, got %v", err)
+	}
+
+	if _, err := CopyObjectCrossClient(ctx, nil, "srcbucket", "object", dst, "dstbucket", "object", CrossCopyOptions{}); err == nil {
+		t.Fatal("expected the missing source client to be rejected")
+	}
+}
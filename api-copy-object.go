@@ -24,21 +24,47 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"strings"
+
+	"github.com/openstor/openstor-go/v7/pkg/encrypt"
+	"github.com/openstor/openstor-go/v7/pkg/s3utils"
 )
 
 // CopyObject - copy a source object into a new object
 func (c *Client) CopyObject(ctx context.Context, dst CopyDestOptions, src CopySrcOptions) (UploadInfo, error) {
+	if err := c.checkBucketName(src.Bucket); err != nil {
+		return UploadInfo{}, err
+	}
 	if err := src.validate(); err != nil {
 		return UploadInfo{}, err
 	}
 
+	if err := c.checkBucketName(dst.Bucket); err != nil {
+		return UploadInfo{}, err
+	}
 	if err := dst.validate(); err != nil {
 		return UploadInfo{}, err
 	}
 
+	if dst.PreserveLastModified {
+		srcInfo, err := c.StatObject(ctx, src.Bucket, src.Object, StatObjectOptions{
+			ServerSideEncryption: encrypt.SSE(src.Encryption),
+			VersionID:            src.VersionID,
+		})
+		if err != nil {
+			return UploadInfo{}, err
+		}
+		dst = dst.preserveLastModified(srcInfo)
+		// The preserved modification time must be the one of the
+		// copied version.
+		src.pin(srcInfo)
+	}
+
+	// Source headers are marshaled first so that destination
+	// options always take precedence.
 	header := make(http.Header)
-	dst.Marshal(header)
 	src.Marshal(header)
+	dst.Marshal(header)
 
 	resp, err := c.executeMethod(ctx, http.MethodPut, requestMetadata{
 		bucketName:   dst.Bucket,
@@ -75,5 +101,233 @@ func (c *Client) CopyObject(ctx context.Context, dst CopyDestOptions, src CopySr
 		VersionID:        resp.Header.Get(amzVersionID),
 		Expiration:       expTime,
 		ExpirationRuleID: ruleID,
+
+		ServerSideEncryption: resp.Header.Get(encrypt.SseGenericHeader),
+		SSEKMSKeyID:          resp.Header.Get(encrypt.SseKmsKeyID),
 	}, nil
 }
+
+// CopyObjectMetadata copies the object src to dst server-side, keeping
+// its user metadata, standard headers and tags, as well as its storage
+// class and server-side encryption unless dst sets them. Objects up to
+// 5GiB are copied with a single CopyObject request, larger ones with a
+// multipart copy, fetching the source tags if needed. src must refer
+// to the whole object, the copy failing if the object is overwritten
+// meanwhile. The SSE-KMS encryption context of the source is not
+// carried over, set dst.Encryption to keep it.
+func (c *Client) CopyObjectMetadata(ctx context.Context, dst CopyDestOptions, src CopySrcOptions) (UploadInfo, error) {
+	if err := c.checkBucketName(src.Bucket); err != nil {
+		return UploadInfo{}, err
+	}
+	if err := src.validate(); err != nil {
+		return UploadInfo{}, err
+	}
+	if err := c.checkBucketName(dst.Bucket); err != nil {
+		return UploadInfo{}, err
+	}
+	if err := dst.validate(); err != nil {
+		return UploadInfo{}, err
+	}
+	if src.MatchRange {
+		return UploadInfo{}, errInvalidArgument("CopyObjectMetadata copies whole objects, the source range must not be set.")
+	}
+
+	srcInfo, err := c.StatObject(ctx, src.Bucket, src.Object, StatObjectOptions{
+		ServerSideEncryption: encrypt.SSE(src.Encryption),
+		VersionID:            src.VersionID,
+	})
+	if err != nil {
+		return UploadInfo{}, err
+	}
+
+	// Copies are written with the standard storage class and the
+	// bucket default encryption, unless requested otherwise.
+	if dst.StorageClass == "" {
+		if sc := srcInfo.Metadata.Get(amzStorageClass); sc != "STANDARD" {
+			dst.StorageClass = sc
+		}
+	}
+	if dst.Encryption == nil {
+		dst.Encryption, err = sourceEncryption(srcInfo, src)
+		if err != nil {
+			return UploadInfo{}, err
+		}
+	}
+
+	// The source must not change between the copied requests.
+	src.pin(srcInfo)
+
+	if srcInfo.Size <= maxPartSize {
+		// Metadata and tags are copied by the server.
+		return c.CopyObject(ctx, dst, src)
+	}
+
+	// The multipart copy sets the metadata and tags of the destination
+	// when initiating the upload.
+	if !dst.ReplaceMetadata {
+		dst = dst.replaceMetadata(srcInfo)
+	}
+	if !dst.ReplaceTags && srcInfo.UserTagCount > 0 {
+		t, err := c.GetObjectTagging(ctx, src.Bucket, src.Object, GetObjectTaggingOptions{VersionID: src.VersionID})
+		if err != nil {
+			return UploadInfo{}, err
+		}
+		dst.UserTags = t.ToMap()
+		dst.ReplaceTags = true
+	}
+	return c.ComposeObject(ctx, dst, src)
+}
+
+// SetMetadataOptions represents options for SetObjectMetadata.
+type SetMetadataOptions struct {
+	// VersionID of the object whose metadata is set, the latest version
+	// when empty. A new latest version is created either way in
+	// versioned buckets.
+	VersionID string
+
+	// Merge keeps the user metadata and standard headers of the object
+	// which are not set in the metadata, all of them being replaced
+	// otherwise.
+	Merge bool
+
+	// StorageClass replaces the storage class of the object, which is
+	// kept when empty.
+	StorageClass string
+
+	// SrcEncryption is the SSE-C key of the object, if any.
+	SrcEncryption encrypt.ServerSide
+	// DstEncryption replaces the server-side encryption of the object,
+	// which is kept when nil.
+	DstEncryption encrypt.ServerSide
+}
+
+// SetObjectMetadata replaces the metadata of an object without uploading
+// it again, by copying it onto itself with the REPLACE metadata
+// directive. The metadata holds user metadata, whose keys are prefixed
+// with `x-amz-meta-` if needed, and the standard headers Content-Type,
+// Content-Encoding, Content-Disposition, Content-Language, Cache-Control
+// and Expires. Tags, storage class and server-side encryption of the
+// object are kept unless opts set them. Objects larger than 5GiB are
+// copied with a multipart copy.
+func (c *Client) SetObjectMetadata(ctx context.Context, bucketName, objectName string, metadata map[string]string, opts SetMetadataOptions) error {
+	// Input validation.
+	if err := c.checkBucketName(bucketName); err != nil {
+		return err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return err
+	}
+
+	dst := CopyDestOptions{
+		Bucket:          bucketName,
+		Object:          objectName,
+		Encryption:      opts.DstEncryption,
+		StorageClass:    opts.StorageClass,
+		ReplaceMetadata: true,
+		UserMetadata:    make(map[string]string),
+	}
+	for k, v := range metadata {
+		switch strings.ToLower(k) {
+		case "content-type":
+			dst.ContentType = v
+		case "content-encoding":
+			dst.ContentEncoding = v
+		case "content-disposition":
+			dst.ContentDisposition = v
+		case "content-language":
+			dst.ContentLanguage = v
+		case "cache-control":
+			dst.CacheControl = v
+		case "expires":
+			expires, err := http.ParseTime(v)
+			if err != nil {
+				return errInvalidArgument("Invalid Expires metadata " + v + ", " + err.Error())
+			}
+			dst.Expires = expires
+		default:
+			dst.UserMetadata[k] = v
+		}
+	}
+	src := CopySrcOptions{
+		Bucket:     bucketName,
+		Object:     objectName,
+		VersionID:  opts.VersionID,
+		Encryption: opts.SrcEncryption,
+	}
+
+	if opts.Merge {
+		srcInfo, err := c.StatObject(ctx, bucketName, objectName, StatObjectOptions{
+			ServerSideEncryption: encrypt.SSE(opts.SrcEncryption),
+			VersionID:            opts.VersionID,
+		})
+		if err != nil {
+			return err
+		}
+		userMeta := filterCustomMeta(dst.UserMetadata)
+		dst = dst.replaceMetadata(srcInfo)
+		dst.UserMetadata = mergeUserMetadata(srcInfo.UserMetadata, userMeta)
+		// Copy the version that was merged.
+		src.VersionID = srcInfo.VersionID
+		src.MatchETag = srcInfo.ETag
+	}
+
+	_, err := c.CopyObjectMetadata(ctx, dst, src)
+	return err
+}
+
+// mergeUserMetadata returns the user metadata meta overriding the user
+// metadata base, keys comparing case-insensitively.
+func mergeUserMetadata(base, meta map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(meta))
+	for k, v := range base {
+		overridden := false
+		for mk := range meta {
+			if strings.EqualFold(k, mk) {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged[k] = v
+		}
+	}
+	for k, v := range meta {
+		merged[k] = v
+	}
+	return merged
+}
+
+// dsseKMS is the dual-layer SSE-KMS encryption of a copied object,
+// not provided by the encrypt package.
+type dsseKMS struct {
+	key string
+}
+
+func (s dsseKMS) Type() encrypt.Type { return encrypt.KMS }
+
+func (s dsseKMS) Marshal(h http.Header) {
+	h.Set(encrypt.SseGenericHeader, "aws:kms:dsse")
+	if s.key != "" {
+		h.Set(encrypt.SseKmsKeyID, s.key)
+	}
+}
+
+// sourceEncryption returns the server-side encryption of the object
+// src, nil if it is not encrypted. The SSE-KMS encryption context is
+// not returned by the server, thus not carried over.
+func sourceEncryption(srcInfo ObjectInfo, src CopySrcOptions) (encrypt.ServerSide, error) {
+	switch {
+	case srcInfo.Metadata.Get(encrypt.SseCustomerAlgorithm) != "":
+		if src.Encryption == nil {
+			return nil, errInvalidArgument("The SSE-C key of the source object is required.")
+		}
+		return encrypt.SSE(src.Encryption), nil
+	case srcInfo.Metadata.Get(encrypt.SseGenericHeader) == "AES256":
+		return encrypt.NewSSE(), nil
+	case srcInfo.Metadata.Get(encrypt.SseGenericHeader) == "aws:kms:dsse":
+		return dsseKMS{key: srcInfo.Metadata.Get(encrypt.SseKmsKeyID)}, nil
+	case strings.HasPrefix(srcInfo.Metadata.Get(encrypt.SseGenericHeader), "aws:kms"):
+		return encrypt.NewSSEKMS(srcInfo.Metadata.Get(encrypt.SseKmsKeyID), nil)
+	}
+	return nil, nil
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestSetObjectMetadata(t *testing.T) {
+	testCases := []struct {
+		size     int64
+		metadata map[string]string
+		opts     SetMetadataOptions
+		expected map[string]string
+	}{
+		{
+			0,
+			map[string]string{"Content-Type": "application/json", "cache-control": "no-cache", "project": "apollo"},
+			SetMetadataOptions{},
+			map[string]string{"X-Amz-Metadata-Directive": "REPLACE", "Content-Type": "application/json", "Cache-Control": "no-cache", "X-Amz-Meta-Project": "apollo", "X-Amz-Meta-Owner": "", "X-Amz-Storage-Class": "GLACIER_IR", "X-Amz-Server-Side-Encryption": "aws:kms", "X-Amz-Copy-Source": "bucket/object"},
+		},
+		{
+			0,
+			map[string]string{"X-Amz-Meta-Project": "apollo", "owner": "bob"},
+			SetMetadataOptions{Merge: true, StorageClass: "STANDARD_IA"},
+			map[string]string{"X-Amz-Metadata-Directive": "REPLACE", "Content-Type": "text/csv", "X-Amz-Meta-Project": "apollo", "X-Amz-Meta-Owner": "bob", "X-Amz-Meta-Team": "data", "X-Amz-Storage-Class": "STANDARD_IA"},
+		},
+		{
+			gb5p1,
+			map[string]string{"Content-Type": "application/json"},
+			SetMetadataOptions{},
+			map[string]string{"Content-Type": "application/json", "X-Amz-Meta-Owner": "", "X-Amz-Storage-Class": "GLACIER_IR", "X-Amz-Server-Side-Encryption": "aws:kms", "X-Amz-Tagging": "team=data"},
+		},
+	}
+
+	for i, testCase := range testCases {
+		var header http.Header
+		var parts int
+		clnt, srv := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			switch {
+			case r.Method == http.MethodHead:
+				w.Header().Set("Last-Modified", "Tue, 02 Jan 2024 14:04:05 GMT")
+				w.Header().Set("ETag", `"etag"`)
+				w.Header().Set("Content-Length", fmt.Sprint(testCase.size))
+				w.Header().Set("Content-Type", "text/csv")
+				w.Header().Set("X-Amz-Meta-Owner", "alice")
+				w.Header().Set("X-Amz-Meta-Team", "data")
+				w.Header().Set("X-Amz-Storage-Class", "GLACIER_IR")
+				w.Header().Set("X-Amz-Server-Side-Encryption", "aws:kms")
+				w.Header().Set("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id", "key")
+				w.Header().Set("X-Amz-Tagging-Count", "1")
+			case query.Has("tagging"):
+				fmt.Fprint(w, `<Tagging><TagSet><Tag><Key>team</Key><Value>data</Value></Tag></TagSet></Tagging>`)
+			case query.Has("uploads"):
+				header = r.Header.Clone()
+				fmt.Fprint(w, `<InitiateMultipartUploadResult><Bucket>bucket</Bucket><Key>object</Key><UploadId>upload</UploadId></InitiateMultipartUploadResult>`)
+			case query.Has("partNumber"):
+				parts++
+				fmt.Fprint(w, `<CopyPartResult><ETag>"part"</ETag><LastModified>2025-01-01T00:00:00.000Z</LastModified></CopyPartResult>`)
+			case query.Has("uploadId"):
+				fmt.Fprint(w, `<CompleteMultipartUploadResult><Bucket>bucket</Bucket><Key>object</Key><ETag>"etag-2"</ETag></CompleteMultipartUploadResult>`)
+			default:
+				header = r.Header.Clone()
+				fmt.Fprint(w, `<CopyObjectResult><ETag>"etag"</ETag><LastModified>2025-01-01T00:00:00.000Z</LastModified></CopyObjectResult>`)
+			}
+		}))
+
+		err := clnt.SetObjectMetadata(context.Background(), "bucket", "object", testCase.metadata, testCase.opts)
+		srv.Close()
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if testCase.size > gb5 && parts < 2 {
+			t.Fatalf("Test %d: expected a multipart copy, got %d parts", i+1, parts)
+		}
+		for k, v := range testCase.expected {
+			if got := header.Get(k); got != v {
+				t.Fatalf("Test %d: expected %s %q, got %q", i+1, k, v, got)
+			}
+		}
+	}
+
+	clnt, err := New("localhost:9000", &Options{Region: "us-east-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = clnt.SetObjectMetadata(context.Background(), "bucket", "object", map[string]string{"Expires": "tomorrow"}, SetMetadataOptions{}); ToErrorResponse(err).Code != InvalidArgument {
+		t.Fatalf("expected the invalid Expires to be rejected, got %v", err)
+	}
+}
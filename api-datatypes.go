@@ -140,6 +140,11 @@ type UploadInfo struct {
 	Location     string
 	VersionID    string
 
+	// PartSize is the part size chosen for a multipart upload. For uploads
+	// of unknown size, whose part size grows, it is the part size in use
+	// when the upload completed. Zero if the object was not uploaded in parts.
+	PartSize int64
+
 	// Lifecycle expiry-date and ruleID associated with the expiry
 	// not to be confused with `Expires` HTTP header.
 	Expiration       time.Time
@@ -154,6 +159,16 @@ type UploadInfo struct {
 	ChecksumSHA256    string
 	ChecksumCRC64NVME string
 	ChecksumMode      string
+
+	// Server-side encryption applied to the object by the server, if any.
+	// ServerSideEncryption is either "AES256" or "aws:kms".
+	ServerSideEncryption string
+	SSEKMSKeyID          string
+
+	// RequestCharged is set when the server confirmed, with
+	// x-amz-request-charged, that the requester was charged for the
+	// upload to a Requester Pays bucket.
+	RequestCharged bool
 }
 
 // RestoreInfo contains information of the restore operation of an archived object
@@ -177,6 +192,17 @@ type ObjectInfo struct {
 	ContentType  string    `json:"contentType"`  // A standard MIME type describing the format of the object data.
 	Expires      time.Time `json:"expires"`      // The date and time at which the object is no longer able to be cached.
 
+	// FullSize is the size of the whole object as returned by
+	// StatObject and GetObject. For ranged responses it is parsed from
+	// Content-Range, -1 if the server does not report it or reports it
+	// in an unexpected format, otherwise it equals Size.
+	FullSize int64 `json:"fullSize,omitempty"`
+
+	// ContentEncoding is the Content-Encoding the object was stored
+	// with, such as gzip. Object contents are returned as stored,
+	// without being decoded.
+	ContentEncoding string `json:"contentEncoding,omitempty"`
+
 	// Collection of additional metadata on the object.
 	// eg: x-amz-meta-*, content-encoding etc.
 	Metadata http.Header `json:"metadata" xml:"-"`
@@ -185,11 +211,21 @@ type ObjectInfo struct {
 	// Only returned by MinIO servers.
 	UserMetadata StringMap `json:"userMetadata,omitempty"`
 
+	// x-amz-meta-* headers with all their values as returned, keyed
+	// by the header names as received without being normalized nor
+	// decoded. Note that the net/http client canonicalizes HTTP/1.x
+	// header names, the casing of the server is only kept by
+	// transports which do not.
+	UserMetadataRaw http.Header `json:"userMetadataRaw,omitempty" xml:"-"`
+
 	// x-amz-tagging values in their k/v values.
 	// Only returned by MinIO servers.
 	UserTags URLMap `json:"userTags,omitempty" xml:"UserTags"`
 
-	// x-amz-tagging-count value
+	// UserTagCount is the number of tags of the object, from the
+	// x-amz-tagging-count header returned by StatObject and GetObject.
+	// Zero when the object has no tags, GetObjectTagging can then be
+	// skipped.
 	UserTagCount int
 
 	// Owner name.
@@ -220,6 +256,9 @@ type ObjectInfo struct {
 	ExpirationRuleID string
 	// NumVersions is the number of versions of the object.
 	NumVersions int
+	// PartsCount is the number of parts of a multipart object, only
+	// returned when a part was requested through PartNumber.
+	PartsCount int
 
 	Restore *RestoreInfo
 
@@ -231,6 +270,16 @@ type ObjectInfo struct {
 	ChecksumCRC64NVME string
 	ChecksumMode      string
 
+	// ChecksumUnverified is set by GetObject with AutoVerify when the
+	// object was stored without a checksum, its content not being
+	// verified.
+	ChecksumUnverified bool `json:"checksumUnverified,omitempty"`
+
+	// RequestCharged is set when the server confirmed, with
+	// x-amz-request-charged, that the requester was charged for the
+	// request to a Requester Pays bucket.
+	RequestCharged bool `json:"requestCharged,omitempty"`
+
 	Internal *struct {
 		K int // Data blocks
 		M int // Parity blocks
@@ -64,6 +64,106 @@ type ErrorResponse struct {
 
 	// Underlying HTTP status code for the returned error
 	StatusCode int `xml:"-" json:"-"`
+
+	// ExpectedBucketOwner is set on AccessDenied errors to the account ID
+	// sent as x-amz-expected-bucket-owner with the failed request, once
+	// a HEAD of the bucket was found to be denied when asserting its
+	// owner and allowed without.
+	ExpectedBucketOwner string `xml:"-" json:"-"`
+
+	// Raw x-amz-request-id and x-amz-id-2 response headers.
+	amzRequestID string
+	amzID2       string
+
+	// sentinel is the sentinel matched, see Is, by errors raised by
+	// the client itself rather than returned by the server.
+	sentinel error
+}
+
+// AmzRequestID returns the x-amz-request-id header of the response the
+// error was read from, also set for errors without an XML body, e.g. in
+// responses to HEAD requests.
+func (e ErrorResponse) AmzRequestID() string {
+	return e.amzRequestID
+}
+
+// AmzID2 returns the x-amz-id-2 header, the extended request ID, of the
+// response the error was read from. Along with AmzRequestID it
+// identifies the request in the server logs.
+func (e ErrorResponse) AmzID2() string {
+	return e.amzID2
+}
+
+// IsBucketOwnerMismatch returns true if err is an AccessDenied error
+// returned because the bucket is not owned by the expected account.
+// S3 reports such mismatches as plain AccessDenied errors, they are
+// only told apart when a HEAD of the bucket is denied when asserting
+// its owner and allowed without, other permission failures are left
+// as AccessDenied.
+func IsBucketOwnerMismatch(err error) bool {
+	errResp := ToErrorResponse(err)
+	return errResp.Code == AccessDenied && errResp.ExpectedBucketOwner != ""
+}
+
+// IsKMSUnavailable returns true if err reports that the requested SSE-KMS
+// encryption cannot be applied, because the server has no KMS configured
+// or the KMS key cannot be used. Such requests can be retried with the
+// encrypt.Fallback of the server-side-encryption, if any.
+func IsKMSUnavailable(err error) bool {
+	errResp := ToErrorResponse(err)
+	if strings.HasPrefix(errResp.Code, "KMS") {
+		return true
+	}
+	return errResp.Code == NotImplemented && strings.Contains(errResp.Message, "KMS")
+}
+
+// ErrPreconditionFailed matches, through errors.Is, the PreconditionFailed
+// errors returned when a conditional request is rejected, for example a
+// copy whose source no longer matches CopySrcOptions.MatchETag or a
+// removal of an object no longer matching RemoveObjectOptions.MatchETag.
+var ErrPreconditionFailed = errors.New(s3ErrorResponseMap[PreconditionFailed])
+
+// ErrInvalidExpiry matches, through errors.Is, the errors returned when
+// a presigned URL expiry is not between 1 second and the SigV4 maximum
+// of 7 days (604800 seconds).
+var ErrInvalidExpiry = errors.New("presigned URL expiry must be between 1 second and 7 days (604800 seconds)")
+
+// ErrWriteOffsetMismatch matches, through errors.Is, the errors returned
+// when an append is rejected because the object size is not the write
+// offset of the append, e.g. after a concurrent append.
+var ErrWriteOffsetMismatch = errors.New(s3ErrorResponseMap[InvalidWriteOffset])
+
+// ErrInvalidRetentionDate matches, through errors.Is, the errors returned
+// when an object retention date is not in the future or is beyond the
+// year 9999, their message includes the date and the current time.
+var ErrInvalidRetentionDate = errors.New("retain until date must be in the future and before the year 10000")
+
+// ErrObjectTooLarge matches, through errors.Is, the errors returned by
+// GetObjectBytes when the object is larger than the maximum size read in
+// memory, their message includes the maximum size.
+var ErrObjectTooLarge = errors.New("object is larger than the maximum size read in memory")
+
+// Presigned URL expiry validation messages.
+const (
+	expiryTooShortMessage = "Expires must be at least 1 second, zero and negative values are invalid."
+	expiryTooLongMessage  = "Expires cannot be greater than 7 days (604800 seconds)."
+)
+
+// Is reports whether the error matches target, only used to match
+// the ErrPreconditionFailed, ErrInvalidExpiry, ErrWriteOffsetMismatch,
+// ErrInvalidRetentionDate and ErrObjectTooLarge sentinels.
+func (e ErrorResponse) Is(target error) bool {
+	if e.sentinel != nil && e.sentinel == target {
+		return true
+	}
+	switch target {
+	case ErrPreconditionFailed:
+		return e.Code == PreconditionFailed
+	case ErrWriteOffsetMismatch:
+		// Some S3 compatible servers report it as OffsetMismatch.
+		return e.Code == InvalidWriteOffset || e.Code == "OffsetMismatch"
+	}
+	return false
 }
 
 // ToErrorResponse - Returns parsed ErrorResponse struct from body and
@@ -177,6 +277,14 @@ func httpRespToErrorResponse(resp *http.Response, bucketName, objectName string)
 				Message:    s3ErrorResponseMap[Conflict],
 				BucketName: bucketName,
 			}
+		case http.StatusMovedPermanently:
+			errResp = ErrorResponse{
+				StatusCode: resp.StatusCode,
+				Code:       PermanentRedirect,
+				Message:    s3ErrorResponseMap[PermanentRedirect],
+				BucketName: bucketName,
+				Key:        objectName,
+			}
 		case http.StatusPreconditionFailed:
 			errResp = ErrorResponse{
 				StatusCode: resp.StatusCode,
@@ -213,11 +321,13 @@ func httpRespToErrorResponse(resp *http.Response, bucketName, objectName string)
 
 	// Save hostID, requestID and region information
 	// from headers if not available through error XML.
+	errResp.amzRequestID = resp.Header.Get("x-amz-request-id")
+	errResp.amzID2 = resp.Header.Get("x-amz-id-2")
 	if errResp.RequestID == "" {
-		errResp.RequestID = resp.Header.Get("x-amz-request-id")
+		errResp.RequestID = errResp.amzRequestID
 	}
 	if errResp.HostID == "" {
-		errResp.HostID = resp.Header.Get("x-amz-id-2")
+		errResp.HostID = errResp.amzID2
 	}
 	if errResp.Region == "" {
 		errResp.Region = resp.Header.Get("x-amz-bucket-region")
@@ -252,6 +362,19 @@ func errEntityTooLarge(totalSize, maxObjectSize int64, bucketName, objectName st
 	}
 }
 
+// errObjectTooLarge - Object size is larger than the size read in memory.
+func errObjectTooLarge(maxBytes int64, bucketName, objectName string) error {
+	msg := fmt.Sprintf("Object size exceeds the maximum size ‘%d’ read in memory.", maxBytes)
+	return ErrorResponse{
+		StatusCode: http.StatusBadRequest,
+		Code:       EntityTooLarge,
+		Message:    msg,
+		BucketName: bucketName,
+		Key:        objectName,
+		sentinel:   ErrObjectTooLarge,
+	}
+}
+
 // errEntityTooSmall - Input size is smaller than supported minimum.
 func errEntityTooSmall(totalSize int64, bucketName, objectName string) error {
 	msg := fmt.Sprintf("Your proposed upload size ‘%d’ is below the minimum allowed object size ‘0B’ for single PUT operation.", totalSize)
@@ -276,6 +399,18 @@ func errUnexpectedEOF(totalRead, totalSize int64, bucketName, objectName string)
 	}
 }
 
+// errChecksumMismatch - Content read does not match the stored checksum.
+func errChecksumMismatch(checksum ChecksumType, bucketName, objectName string) error {
+	msg := fmt.Sprintf("The %s checksum of the content read does not match the checksum stored with the object.", checksum)
+	return ErrorResponse{
+		StatusCode: http.StatusBadRequest,
+		Code:       BadDigest,
+		Message:    msg,
+		BucketName: bucketName,
+		Key:        objectName,
+	}
+}
+
 // errInvalidArgument - Invalid argument response.
 func errInvalidArgument(message string) error {
 	return ErrorResponse{
@@ -286,6 +421,18 @@ func errInvalidArgument(message string) error {
 	}
 }
 
+// errInvalidArgumentMatching - invalid argument error of the client's
+// own validation, matching sentinel through errors.Is.
+func errInvalidArgumentMatching(sentinel error, message string) error {
+	return ErrorResponse{
+		StatusCode: http.StatusBadRequest,
+		Code:       InvalidArgument,
+		Message:    message,
+		RequestID:  "minio",
+		sentinel:   sentinel,
+	}
+}
+
 // errAPINotSupported - API not supported response
 // The specified API call is not supported
 func errAPINotSupported(message string) error {
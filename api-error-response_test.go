@@ -28,6 +28,7 @@ import (
 	"net/http"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -84,6 +85,9 @@ func TestHttpRespToErrorResponse(t *testing.T) {
 			RequestID:  resp.Header.Get("x-amz-request-id"),
 			HostID:     resp.Header.Get("x-amz-id-2"),
 			Region:     resp.Header.Get("x-amz-bucket-region"),
+
+			amzRequestID: resp.Header.Get("x-amz-request-id"),
+			amzID2:       resp.Header.Get("x-amz-id-2"),
 		}
 		return errResp
 	}
@@ -289,3 +293,37 @@ func TestErrorResponseComparable(t *testing.T) {
 		t.Fatalf("ErrorResponse should be comparable")
 	}
 }
+
+func TestErrorResponseRequestIDs(t *testing.T) {
+	testCases := []struct {
+		body              string
+		requestID, hostID string
+	}{
+		// The raw headers are returned when the XML holds other IDs.
+		{`<Error><Code>AccessDenied</Code><Message>Access Denied</Message><RequestId>xml-request-id</RequestId><HostId>xml-host-id</HostId></Error>`, "xml-request-id", "xml-host-id"},
+		// Errors without body only carry the headers.
+		{"", "header-request-id", "header-id-2"},
+	}
+
+	for i, testCase := range testCases {
+		resp := &http.Response{
+			StatusCode: http.StatusForbidden,
+			Status:     http.StatusText(http.StatusForbidden),
+			Header:     make(http.Header),
+			Body:       io.NopCloser(strings.NewReader(testCase.body)),
+		}
+		resp.Header.Set("x-amz-request-id", "header-request-id")
+		resp.Header.Set("x-amz-id-2", "header-id-2")
+
+		errResp := ToErrorResponse(httpRespToErrorResponse(resp, "bucket", "object"))
+		if errResp.Code != AccessDenied {
+			t.Fatalf("Test %d: expected AccessDenied, got %s", i+1, errResp.Code)
+		}
+		if errResp.RequestID != testCase.requestID || errResp.HostID != testCase.hostID {
+			t.Fatalf("Test %d: expected request ID %s and host ID %s, got %s and %s", i+1, testCase.requestID, testCase.hostID, errResp.RequestID, errResp.HostID)
+		}
+		if errResp.AmzRequestID() != "header-request-id" || errResp.AmzID2() != "header-id-2" {
+			t.Fatalf("Test %d: unexpected headers %s and %s", i+1, errResp.AmzRequestID(), errResp.AmzID2())
+		}
+	}
+}
@@ -25,14 +25,52 @@ import (
 	"encoding/xml"
 	"net/http"
 	"net/url"
+
+	"github.com/openstor/openstor-go/v7/pkg/s3utils"
 )
 
 // Grantee represents the person being granted permissions.
 type Grantee struct {
-	XMLName     xml.Name `xml:"Grantee"`
-	ID          string   `xml:"ID"`
+	XMLName xml.Name `xml:"Grantee"`
+	// Type is one of GranteeCanonicalUser, GranteeEmail or GranteeGroup.
+	Type         string `xml:"type,attr"`
+	ID           string `xml:"ID"`
+	DisplayName  string `xml:"DisplayName"`
+	EmailAddress string `xml:"EmailAddress"`
+	URI          string `xml:"URI"`
+}
+
+// grantee is the wire format of Grantee and LoggingGrantee, with the
+// grantee type set as an xsi:type attribute.
+type grantee struct {
+	XMLNSXSI     string `xml:"xmlns:xsi,attr,omitempty"`
+	XSIType      string `xml:"xsi:type,attr,omitempty"`
+	ID           string `xml:"ID,omitempty"`
+	DisplayName  string `xml:"DisplayName,omitempty"`
+	EmailAddress string `xml:"EmailAddress,omitempty"`
+	URI          string `xml:"URI,omitempty"`
+}
+
+// MarshalXML encodes the grantee with its xsi:type attribute.
+func (g Grantee) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	v := grantee{
+		XSIType:      g.Type,
+		ID:           g.ID,
+		DisplayName:  g.DisplayName,
+		EmailAddress: g.EmailAddress,
+		URI:          g.URI,
+	}
+	if v.XSIType != "" {
+		v.XMLNSXSI = "http://www.w3.org/2001/XMLSchema-instance"
+	}
+	return e.EncodeElement(v, start)
+}
+
+// ACLOwner - owner of an object ACL.
+type ACLOwner struct {
+	XMLName     xml.Name `xml:"Owner"`
 	DisplayName string   `xml:"DisplayName"`
-	URI         string   `xml:"URI"`
+	ID          string   `xml:"ID"`
 }
 
 // Grant holds grant information
@@ -46,17 +84,34 @@ type Grant struct {
 type AccessControlList struct {
 	XMLName    xml.Name `xml:"AccessControlList"`
 	Grant      []Grant
-	Permission string `xml:"Permission"`
+	Permission string `xml:"Permission,omitempty"`
 }
 
-type accessControlPolicy struct {
+// AccessControlPolicy - owner and grants of an object ACL.
+type AccessControlPolicy struct {
 	XMLName           xml.Name `xml:"AccessControlPolicy"`
-	Owner             Owner
+	Owner             ACLOwner
 	AccessControlList AccessControlList
+
+	cannedACL string
 }
 
-// GetObjectACL get object ACLs
-func (c *Client) GetObjectACL(ctx context.Context, bucketName, objectName string) (*ObjectInfo, error) {
+// SetCannedACL sets a canned ACL such as "private" or "public-read",
+// sent through the x-amz-acl header instead of the owner and grants.
+func (a *AccessControlPolicy) SetCannedACL(acl string) {
+	a.cannedACL = acl
+}
+
+// GetObjectAccessControlPolicy - get the owner and grants of an object ACL.
+func (c *Client) GetObjectAccessControlPolicy(ctx context.Context, bucketName, objectName string) (*AccessControlPolicy, error) {
+	// Input validation.
+	if err := c.checkBucketName(bucketName); err != nil {
+		return nil, err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return nil, err
+	}
+
 	resp, err := c.executeMethod(ctx, http.MethodGet, requestMetadata{
 		bucketName: bucketName,
 		objectName: objectName,
@@ -73,11 +128,20 @@ func (c *Client) GetObjectACL(ctx context.Context, bucketName, objectName string
 		return nil, httpRespToErrorResponse(resp, bucketName, objectName)
 	}
 
-	res := &accessControlPolicy{}
+	res := &AccessControlPolicy{}
 
 	if err := xmlDecoder(resp.Body, res); err != nil {
 		return nil, err
 	}
+	return res, nil
+}
+
+// GetObjectACL get object ACLs
+func (c *Client) GetObjectACL(ctx context.Context, bucketName, objectName string) (*ObjectInfo, error) {
+	res, err := c.GetObjectAccessControlPolicy(ctx, bucketName, objectName)
+	if err != nil {
+		return nil, err
+	}
 
 	objInfo, err := c.StatObject(ctx, bucketName, objectName, StatObjectOptions{})
 	if err != nil {
@@ -103,7 +167,7 @@ func (c *Client) GetObjectACL(ctx context.Context, bucketName, objectName string
 	return &objInfo, nil
 }
 
-func getCannedACL(aCPolicy *accessControlPolicy) string {
+func getCannedACL(aCPolicy *AccessControlPolicy) string {
 	grants := aCPolicy.AccessControlList.Grant
 
 	switch {
@@ -133,7 +197,7 @@ func getCannedACL(aCPolicy *accessControlPolicy) string {
 	return ""
 }
 
-func getAmzGrantACL(aCPolicy *accessControlPolicy) map[string][]string {
+func getAmzGrantACL(aCPolicy *AccessControlPolicy) map[string][]string {
 	grants := aCPolicy.AccessControlList.Grant
 	res := map[string][]string{}
 
@@ -23,9 +23,12 @@ import (
 	"context"
 	"encoding/xml"
 	"errors"
+	"iter"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/openstor/openstor-go/v7/pkg/encrypt"
@@ -45,11 +48,31 @@ import (
 //
 // - ServerSideEncryption
 // The server-side encryption algorithm used when storing this object in Minio
+//
+// - Attributes
+// The object attributes to be returned, any of "ETag", "Checksum", "StorageClass",
+// "ObjectSize" and "ObjectParts" (default: all of them, see GetObjectAttributesTags)
 type ObjectAttributesOptions struct {
 	MaxParts             int
 	VersionID            string
 	PartNumberMarker     int
 	ServerSideEncryption encrypt.ServerSide
+	Attributes           []string
+}
+
+// objectAttributesHeader returns the value of the x-amz-object-attributes header.
+func (opts ObjectAttributesOptions) objectAttributesHeader() (string, error) {
+	if len(opts.Attributes) == 0 {
+		return GetObjectAttributesTags, nil
+	}
+	for _, attr := range opts.Attributes {
+		switch attr {
+		case "ETag", "Checksum", "StorageClass", "ObjectSize", "ObjectParts":
+		default:
+			return "", errInvalidArgument("Unsupported object attribute: " + attr)
+		}
+	}
+	return strings.Join(opts.Attributes, ","), nil
 }
 
 // ObjectAttributes is the response object returned by the GetObjectAttributes API
@@ -91,10 +114,12 @@ type ObjectAttributesResponse struct {
 	StorageClass string
 	ObjectSize   int
 	Checksum     struct {
-		ChecksumCRC32  string `xml:",omitempty"`
-		ChecksumCRC32C string `xml:",omitempty"`
-		ChecksumSHA1   string `xml:",omitempty"`
-		ChecksumSHA256 string `xml:",omitempty"`
+		ChecksumCRC32     string `xml:",omitempty"`
+		ChecksumCRC32C    string `xml:",omitempty"`
+		ChecksumSHA1      string `xml:",omitempty"`
+		ChecksumSHA256    string `xml:",omitempty"`
+		ChecksumCRC64NVME string `xml:",omitempty"`
+		ChecksumType      string `xml:",omitempty"`
 	}
 	ObjectParts struct {
 		PartsCount           int
@@ -108,12 +133,13 @@ type ObjectAttributesResponse struct {
 
 // ObjectAttributePart is used by ObjectAttributesResponse to describe an object part
 type ObjectAttributePart struct {
-	ChecksumCRC32  string `xml:",omitempty"`
-	ChecksumCRC32C string `xml:",omitempty"`
-	ChecksumSHA1   string `xml:",omitempty"`
-	ChecksumSHA256 string `xml:",omitempty"`
-	PartNumber     int
-	Size           int
+	ChecksumCRC32     string `xml:",omitempty"`
+	ChecksumCRC32C    string `xml:",omitempty"`
+	ChecksumSHA1      string `xml:",omitempty"`
+	ChecksumSHA256    string `xml:",omitempty"`
+	ChecksumCRC64NVME string `xml:",omitempty"`
+	PartNumber        int
+	Size              int
 }
 
 func (o *ObjectAttributes) parseResponse(resp *http.Response) (err error) {
@@ -136,7 +162,7 @@ func (o *ObjectAttributes) parseResponse(resp *http.Response) (err error) {
 // GetObjectAttributes API combines HeadObject and ListParts.
 // More details on usage can be found in the documentation for ObjectAttributesOptions{}
 func (c *Client) GetObjectAttributes(ctx context.Context, bucketName, objectName string, opts ObjectAttributesOptions) (*ObjectAttributes, error) {
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return nil, err
 	}
 
@@ -150,8 +176,13 @@ func (c *Client) GetObjectAttributes(ctx context.Context, bucketName, objectName
 		urlValues.Add("versionId", opts.VersionID)
 	}
 
+	attributes, err := opts.objectAttributesHeader()
+	if err != nil {
+		return nil, err
+	}
+
 	headers := make(http.Header)
-	headers.Set(amzObjectAttributes, GetObjectAttributesTags)
+	headers.Set(amzObjectAttributes, attributes)
 
 	if opts.PartNumberMarker > 0 {
 		headers.Set(amzPartNumberMarker, strconv.Itoa(opts.PartNumberMarker))
@@ -202,3 +233,111 @@ func (c *Client) GetObjectAttributes(ctx context.Context, bucketName, objectName
 
 	return OA, nil
 }
+
+// GetObjectAttributesParts lists all the parts of a multipart object by
+// paginating GetObjectAttributes over ObjectParts, starting after
+// opts.PartNumberMarker. Only the "ObjectParts" attribute is requested.
+func (c *Client) GetObjectAttributesParts(ctx context.Context, bucketName, objectName string, opts ObjectAttributesOptions) (iter.Seq2[*ObjectAttributePart, error], error) {
+	if err := c.checkBucketName(bucketName); err != nil {
+		return nil, err
+	}
+
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return nil, err
+	}
+
+	opts.Attributes = []string{"ObjectParts"}
+
+	return func(yield func(*ObjectAttributePart, error) bool) {
+		for {
+			attrs, err := c.GetObjectAttributes(ctx, bucketName, objectName, opts)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, part := range attrs.ObjectParts.Parts {
+				if !yield(part, nil) {
+					return
+				}
+			}
+			if !attrs.ObjectParts.IsTruncated || attrs.ObjectParts.NextPartNumberMarker <= opts.PartNumberMarker {
+				// nothing to continue
+				return
+			}
+			opts.PartNumberMarker = attrs.ObjectParts.NextPartNumberMarker
+		}
+	}, nil
+}
+
+// ObjectPartChecksums is the response object returned by the
+// GetObjectPartChecksums API
+//
+// - ETag, ChecksumType and the Checksum* values
+// Refer to the whole object, for multipart objects the checksums
+// are usually a checksum of the checksum of each part
+//
+// - PartsCount
+// The total number of parts of the object
+//
+// - Parts
+// The parts of the object, sorted by PartNumber
+type ObjectPartChecksums struct {
+	VersionID         string
+	ETag              string
+	ObjectSize        int
+	ChecksumType      string
+	ChecksumCRC32     string
+	ChecksumCRC32C    string
+	ChecksumSHA1      string
+	ChecksumSHA256    string
+	ChecksumCRC64NVME string
+	PartsCount        int
+	Parts             []ObjectAttributePart
+}
+
+// GetObjectPartChecksums returns the object checksum along with the
+// number, size and checksum of every part of the object, paginating
+// GetObjectAttributes over all the parts.
+func (c *Client) GetObjectPartChecksums(ctx context.Context, bucketName, objectName string, opts ObjectAttributesOptions) (ObjectPartChecksums, error) {
+	opts.Attributes = []string{"ETag", "Checksum", "ObjectSize", "ObjectParts"}
+	opts.PartNumberMarker = 0
+
+	var result ObjectPartChecksums
+	for {
+		attrs, err := c.GetObjectAttributes(ctx, bucketName, objectName, opts)
+		if err != nil {
+			return ObjectPartChecksums{}, err
+		}
+		if opts.PartNumberMarker == 0 {
+			result = ObjectPartChecksums{
+				VersionID:         attrs.VersionID,
+				ETag:              trimEtag(attrs.ETag),
+				ObjectSize:        attrs.ObjectSize,
+				ChecksumType:      attrs.Checksum.ChecksumType,
+				ChecksumCRC32:     attrs.Checksum.ChecksumCRC32,
+				ChecksumCRC32C:    attrs.Checksum.ChecksumCRC32C,
+				ChecksumSHA1:      attrs.Checksum.ChecksumSHA1,
+				ChecksumSHA256:    attrs.Checksum.ChecksumSHA256,
+				ChecksumCRC64NVME: attrs.Checksum.ChecksumCRC64NVME,
+				PartsCount:        attrs.ObjectParts.PartsCount,
+				Parts:             make([]ObjectAttributePart, 0, attrs.ObjectParts.PartsCount),
+			}
+			// Pin the version so all pages describe the same object.
+			if opts.VersionID == "" {
+				opts.VersionID = attrs.VersionID
+			}
+		}
+		for _, part := range attrs.ObjectParts.Parts {
+			result.Parts = append(result.Parts, *part)
+		}
+		if !attrs.ObjectParts.IsTruncated || attrs.ObjectParts.NextPartNumberMarker <= opts.PartNumberMarker {
+			break
+		}
+		opts.PartNumberMarker = attrs.ObjectParts.NextPartNumberMarker
+	}
+
+	sort.Slice(result.Parts, func(i, j int) bool {
+		return result.Parts[i].PartNumber < result.Parts[j].PartNumber
+	})
+	return result, nil
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestGetObjectAttributesParts(t *testing.T) {
+	const totalParts = 5
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get(amzObjectAttributes); got != "ObjectParts" {
+			t.Errorf("expected %s to be ObjectParts, got %s", amzObjectAttributes, got)
+		}
+		marker, _ := strconv.Atoi(r.Header.Get(amzPartNumberMarker))
+		maxParts, _ := strconv.Atoi(r.Header.Get(amzMaxParts))
+
+		last := min(marker+maxParts, totalParts)
+		var parts string
+		for i := marker + 1; i <= last; i++ {
+			parts += fmt.Sprintf("<Part><PartNumber>%d</PartNumber><Size>%d</Size></Part>", i, i*100)
+		}
+
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `<GetObjectAttributesOutput><ObjectParts><PartsCount>%d</PartsCount><PartNumberMarker>%d</PartNumberMarker><NextPartNumberMarker>%d</NextPartNumberMarker><MaxParts>%d</MaxParts><IsTruncated>%t</IsTruncated>%s</ObjectParts></GetObjectAttributesOutput>`,
+			totalParts, marker, last, maxParts, last < totalParts, parts)
+	}))
+
+	parts, err := clnt.GetObjectAttributesParts(context.Background(), "bucket", "object", ObjectAttributesOptions{MaxParts: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	partNumber := 0
+	for part, err := range parts {
+		if err != nil {
+			t.Fatal(err)
+		}
+		partNumber++
+		if part.PartNumber != partNumber || part.Size != partNumber*100 {
+			t.Fatalf("unexpected part %+v", part)
+		}
+	}
+	if partNumber != totalParts {
+		t.Fatalf("expected %d parts, got %d", totalParts, partNumber)
+	}
+
+	_, err = clnt.GetObjectAttributes(context.Background(), "bucket", "object", ObjectAttributesOptions{Attributes: []string{"Owner"}})
+	if err == nil {
+		t.Fatal("expected unsupported attribute to fail")
+	}
+}
+
+func TestGetObjectPartChecksums(t *testing.T) {
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get(amzObjectAttributes); got != "ETag,Checksum,ObjectSize,ObjectParts" {
+			t.Errorf("unexpected %s: %s", amzObjectAttributes, got)
+		}
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set(amzVersionID, "version-1")
+		w.WriteHeader(http.StatusOK)
+		if r.Header.Get(amzPartNumberMarker) == "" {
+			fmt.Fprint(w, `<GetObjectAttributesOutput><ETag>"etag-2"</ETag><ObjectSize>300</ObjectSize><Checksum><ChecksumCRC32C>object-crc</ChecksumCRC32C><ChecksumType>COMPOSITE</ChecksumType></Checksum><ObjectParts><PartsCount>2</PartsCount><NextPartNumberMarker>1</NextPartNumberMarker><MaxParts>1</MaxParts><IsTruncated>true</IsTruncated><Part><PartNumber>1</PartNumber><Size>100</Size><ChecksumCRC32C>crc-1</ChecksumCRC32C></Part></ObjectParts></GetObjectAttributesOutput>`)
+			return
+		}
+		if r.URL.Query().Get("versionId") != "version-1" {
+			t.Errorf("expected version to be pinned, got %q", r.URL.Query().Get("versionId"))
+		}
+		fmt.Fprint(w, `<GetObjectAttributesOutput><ETag>"etag-2"</ETag><ObjectSize>300</ObjectSize><ObjectParts><PartsCount>2</PartsCount><PartNumberMarker>1</PartNumberMarker><NextPartNumberMarker>2</NextPartNumberMarker><MaxParts>1</MaxParts><IsTruncated>false</IsTruncated><Part><PartNumber>2</PartNumber><Size>200</Size><ChecksumCRC32C>crc-2</ChecksumCRC32C></Part></ObjectParts></GetObjectAttributesOutput>`)
+	}))
+
+	checksums, err := clnt.GetObjectPartChecksums(context.Background(), "bucket", "object", ObjectAttributesOptions{MaxParts: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checksums.ETag != "etag-2" || checksums.ChecksumCRC32C != "object-crc" || checksums.ChecksumType != "COMPOSITE" || checksums.PartsCount != 2 {
+		t.Fatalf("unexpected object checksums %+v", checksums)
+	}
+	if len(checksums.Parts) != 2 {
+		t.Fatalf("expected 2 parts, got %d", len(checksums.Parts))
+	}
+	for i, part := range checksums.Parts {
+		if part.PartNumber != i+1 || part.Size != (i+1)*100 || part.ChecksumCRC32C != fmt.Sprintf("crc-%d", i+1) {
+			t.Fatalf("unexpected part %+v", part)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math"
+)
+
+// GetObjectBytes returns the content of an object read in memory, along
+// with its info, for small objects such as configuration files. Objects
+// larger than maxBytes are rejected with an error matching
+// ErrObjectTooLarge, from their Content-Length before any content is
+// read, or once more than maxBytes were read if their size is unknown.
+func (c *Client) GetObjectBytes(ctx context.Context, bucketName, objectName string, maxBytes int64, opts GetObjectOptions) ([]byte, ObjectInfo, error) {
+	if maxBytes <= 0 {
+		return nil, ObjectInfo{}, errInvalidArgument("The maximum size read in memory must be positive.")
+	}
+
+	obj, err := c.GetObject(ctx, bucketName, objectName, opts)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	defer obj.Close()
+
+	objInfo, err := obj.Stat()
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	if objInfo.Size > maxBytes {
+		return nil, ObjectInfo{}, errObjectTooLarge(maxBytes, bucketName, objectName)
+	}
+
+	var buf bytes.Buffer
+	if objInfo.Size > 0 {
+		buf.Grow(int(objInfo.Size))
+	}
+	// Read one more byte to detect objects of unknown size which are
+	// larger than maxBytes, without overflowing math.MaxInt64.
+	n, err := buf.ReadFrom(io.LimitReader(obj, min(maxBytes, math.MaxInt64-1)+1))
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	if n > maxBytes {
+		return nil, ObjectInfo{}, errObjectTooLarge(maxBytes, bucketName, objectName)
+	}
+	return buf.Bytes(), objInfo, nil
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestGetObjectBytes(t *testing.T) {
+	var (
+		content []byte
+		chunked bool
+	)
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("Content-Type", "application/json")
+		if !chunked {
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		}
+		w.Write(content)
+		if chunked {
+			w.(http.Flusher).Flush()
+		}
+	}))
+
+	testCases := []struct {
+		content  []byte
+		chunked  bool
+		maxBytes int64
+		tooLarge bool
+	}{
+		{[]byte(`{"config":true}`), false, 1024, false},
+		{[]byte(`{"config":true}`), false, 15, false},
+		{[]byte(`{"config":true}`), false, 14, true},
+		{[]byte{}, false, 1, false},
+		{[]byte(`{"config":true}`), true, 15, false},
+		{bytes.Repeat([]byte("x"), 4096), true, 1024, true},
+		{[]byte(`{"config":true}`), false, math.MaxInt64, false},
+		{[]byte(`{"config":true}`), true, math.MaxInt64, false},
+	}
+	for i, testCase := range testCases {
+		content, chunked = testCase.content, testCase.chunked
+		data, info, err := clnt.GetObjectBytes(context.Background(), "bucket", "object", testCase.maxBytes, GetObjectOptions{})
+		if testCase.tooLarge {
+			if !errors.Is(err, ErrObjectTooLarge) || data != nil {
+				t.Fatalf("Test %d: expected the object to be too large, got %v", i+1, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if !bytes.Equal(data, testCase.content) || info.ContentType != "application/json" {
+			t.Fatalf("Test %d: unexpected object %q of type %q", i+1, data, info.ContentType)
+		}
+	}
+
+	if _, _, err := clnt.GetObjectBytes(context.Background(), "bucket", "object", 0, GetObjectOptions{}); ToErrorResponse(err).Code != InvalidArgument {
+		t.Fatalf("expected the zero maximum size to be rejected, got %v", err)
+	}
+}
@@ -33,7 +33,7 @@ import (
 // The options can be used to specify the GET request further.
 func (c *Client) FGetObject(ctx context.Context, bucketName, objectName, filePath string, opts GetObjectOptions) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 	if err := s3utils.CheckValidObjectName(objectName); err != nil {
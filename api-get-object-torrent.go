@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/openstor/openstor-go/v7/pkg/s3utils"
+)
+
+// GetObjectTorrent returns the torrent file of an object, to distribute
+// it over BitTorrent. The returned reader must be closed. Servers not
+// serving torrents are reported with an APINotSupported error.
+func (c *Client) GetObjectTorrent(ctx context.Context, bucketName, objectName string) (io.ReadCloser, error) {
+	// Input validation.
+	if err := c.checkBucketName(bucketName); err != nil {
+		return nil, err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return nil, err
+	}
+
+	urlValues := make(url.Values)
+	urlValues.Set("torrent", "")
+
+	// Execute GET on object to get its torrent file.
+	resp, err := c.executeMethod(ctx, http.MethodGet, requestMetadata{
+		bucketName:       bucketName,
+		objectName:       objectName,
+		queryValues:      urlValues,
+		contentSHA256Hex: emptySHA256Hex,
+	})
+	if err == nil && resp.StatusCode != http.StatusOK {
+		err = httpRespToErrorResponse(resp, bucketName, objectName)
+	}
+	if err != nil {
+		closeResponse(resp)
+		if errResp := ToErrorResponse(err); isTorrentUnsupported(errResp) {
+			return nil, errAPINotSupported("Torrents of objects are not supported by the server, " + errResp.Error())
+		}
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// isTorrentUnsupported tells whether the error reports that the server
+// does not serve torrents.
+func isTorrentUnsupported(errResp ErrorResponse) bool {
+	switch errResp.StatusCode {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return errResp.Code == NotImplemented || errResp.Code == MethodNotAllowed
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestGetObjectTorrent(t *testing.T) {
+	const torrent = "d8:announce0:4:infod6:lengthi5e4:name6:objectee"
+	testCases := []struct {
+		status int
+		body   string
+		code   string
+	}{
+		{http.StatusOK, torrent, ""},
+		{http.StatusNotImplemented, `<Error><Code>NotImplemented</Code><Message>A header you provided implies functionality that is not implemented</Message></Error>`, APINotSupported},
+		{http.StatusMethodNotAllowed, "", APINotSupported},
+		{http.StatusNotFound, `<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`, NoSuchKey},
+	}
+
+	for i, testCase := range testCases {
+		clnt, srv := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !r.URL.Query().Has("torrent") || r.URL.Path != "/bucket/object" {
+				t.Errorf("Test %d: unexpected request %s", i+1, r.URL)
+			}
+			w.WriteHeader(testCase.status)
+			io.WriteString(w, testCase.body)
+		}))
+
+		rc, err := clnt.GetObjectTorrent(context.Background(), "bucket", "object")
+		if testCase.code == "" {
+			if err != nil {
+				t.Fatalf("Test %d: unexpected error %v", i+1, err)
+			}
+			got, err := io.ReadAll(rc)
+			rc.Close()
+			if err != nil || string(got) != torrent {
+				t.Fatalf("Test %d: unexpected torrent %q, %v", i+1, got, err)
+			}
+		} else if code := ToErrorResponse(err).Code; code != testCase.code {
+			t.Fatalf("Test %d: expected %s, got %v", i+1, testCase.code, err)
+		}
+		srv.Close()
+	}
+}
@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"encoding/base64"
+	"hash"
+	"maps"
+	"strings"
+)
+
+// checksumVerifier verifies the content of an object read sequentially
+// against the checksum stored with it, see GetObjectOptions.AutoVerify.
+type checksumVerifier struct {
+	bucketName string
+	objectName string
+	checksum   ChecksumType
+	// expected is the encoded stored checksum, without the parts count
+	// of composite checksums.
+	expected string
+	hasher   hash.Hash
+
+	// parts are the sizes of the parts of objects with a composite
+	// checksum, nil for full object checksums.
+	parts []int64
+	// part is the index of the part being read, partRead the bytes of
+	// it read so far.
+	part     int
+	partRead int64
+	// partSums are the raw checksums of the parts read.
+	partSums []byte
+	// overflow is set once more content than the parts was read.
+	overflow bool
+
+	done bool
+	err  error
+}
+
+// newChecksumVerifier learns the checksum stored with the object with
+// GetObjectPartChecksums, pinning opts to the object version and ETag
+// it describes. The returned verifier is nil if the object was stored
+// without a checksum, or without the part sizes of a composite one.
+func (c *Client) newChecksumVerifier(ctx context.Context, bucketName, objectName string, opts *GetObjectOptions) (*checksumVerifier, error) {
+	if _, ok := opts.headers["Range"]; ok || opts.PartNumber > 0 {
+		return nil, errInvalidArgument("AutoVerify is not supported when reading a range or a part of the object.")
+	}
+
+	stored, err := c.GetObjectPartChecksums(ctx, bucketName, objectName, ObjectAttributesOptions{
+		VersionID:            opts.VersionID,
+		ServerSideEncryption: opts.ServerSideEncryption,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the version that was described.
+	opts.headers = maps.Clone(opts.headers)
+	if opts.VersionID == "" {
+		opts.VersionID = stored.VersionID
+	}
+	if _, ok := opts.headers["If-Match"]; !ok && stored.ETag != "" {
+		opts.SetMatchETag(stored.ETag)
+	}
+
+	checksum, expected := ChecksumNone, ""
+	for _, sum := range []struct {
+		checksum ChecksumType
+		value    string
+	}{
+		{ChecksumCRC64NVME, stored.ChecksumCRC64NVME},
+		{ChecksumCRC32C, stored.ChecksumCRC32C},
+		{ChecksumCRC32, stored.ChecksumCRC32},
+		{ChecksumSHA256, stored.ChecksumSHA256},
+		{ChecksumSHA1, stored.ChecksumSHA1},
+	} {
+		if sum.value != "" {
+			checksum, expected = sum.checksum, sum.value
+			break
+		}
+	}
+	if !checksum.IsSet() {
+		return nil, nil
+	}
+
+	v := &checksumVerifier{
+		bucketName: bucketName,
+		objectName: objectName,
+		checksum:   checksum,
+		expected:   expected,
+		hasher:     checksum.Hasher(),
+	}
+
+	// Composite checksums of multipart objects are the checksum of the
+	// checksums of their parts, e.g. "<checksum>-<parts count>".
+	composite := stored.ChecksumType == ChecksumCompositeMode.String()
+	if i := strings.LastIndexByte(expected, '-'); i >= 0 {
+		v.expected, composite = expected[:i], true
+	}
+	if !composite {
+		return v, nil
+	}
+	if len(stored.Parts) == 0 {
+		return nil, nil
+	}
+	for _, part := range stored.Parts {
+		v.parts = append(v.parts, int64(part.Size))
+	}
+	return v, nil
+}
+
+// Write hashes the content p read next.
+func (v *checksumVerifier) Write(p []byte) (int, error) {
+	n := len(p)
+	if v.parts == nil {
+		return v.hasher.Write(p)
+	}
+	for len(p) > 0 {
+		if v.part == len(v.parts) {
+			v.overflow = true
+			break
+		}
+		m := min(int64(len(p)), v.parts[v.part]-v.partRead)
+		v.hasher.Write(p[:m])
+		p = p[m:]
+		if v.partRead += m; v.partRead == v.parts[v.part] {
+			v.partSums = v.hasher.Sum(v.partSums)
+			v.hasher.Reset()
+			v.part++
+			v.partRead = 0
+		}
+	}
+	return n, nil
+}
+
+// verify compares the checksum of the content read with the stored
+// checksum, once the whole content was read.
+func (v *checksumVerifier) verify() error {
+	if v.done {
+		return v.err
+	}
+	v.done = true
+
+	sum := v.hasher.Sum(nil)
+	if v.parts != nil {
+		if v.overflow || v.part != len(v.parts) {
+			sum = nil
+		} else {
+			h := v.checksum.Hasher()
+			h.Write(v.partSums)
+			sum = h.Sum(nil)
+		}
+	}
+	if base64.StdEncoding.EncodeToString(sum) != v.expected {
+		v.err = errChecksumMismatch(v.checksum, v.bucketName, v.objectName)
+	}
+	return v.err
+}
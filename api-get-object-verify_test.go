@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestGetObjectAutoVerify(t *testing.T) {
+	data := bytes.Repeat([]byte("verified download "), 1024)
+	parts := [][]byte{data[:10000], data[10000:]}
+
+	var partSums []byte
+	for _, part := range parts {
+		partSums = append(partSums, ChecksumSHA256.ChecksumBytes(part).Raw()...)
+	}
+	composite := ChecksumSHA256.ChecksumBytes(partSums).Encoded() + "-2"
+	partsXML := fmt.Sprintf(`<ObjectParts><PartsCount>2</PartsCount><IsTruncated>false</IsTruncated><Part><PartNumber>1</PartNumber><Size>%d</Size></Part><Part><PartNumber>2</PartNumber><Size>%d</Size></Part></ObjectParts>`, len(parts[0]), len(parts[1]))
+
+	var (
+		checksum string
+		content  []byte
+		ifMatch  string
+	)
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set(amzVersionID, "v1")
+		if r.URL.Query().Has("attributes") {
+			fmt.Fprintf(w, `<GetObjectAttributesOutput><ETag>etag</ETag><Checksum>%s</Checksum>%s</GetObjectAttributesOutput>`, checksum, partsXML)
+			return
+		}
+		ifMatch = r.Header.Get("If-Match")
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.Write(content)
+	}))
+
+	tampered := bytes.Clone(data)
+	tampered[len(tampered)-1] = '!'
+	testCases := []struct {
+		checksum   string
+		content    []byte
+		mismatch   bool
+		unverified bool
+	}{
+		{"<ChecksumCRC32C>" + ChecksumCRC32C.ChecksumBytes(data).Encoded() + "</ChecksumCRC32C>", data, false, false},
+		{"<ChecksumCRC32C>" + ChecksumCRC32C.ChecksumBytes(data).Encoded() + "</ChecksumCRC32C>", tampered, true, false},
+		{"<ChecksumCRC64NVME>" + ChecksumCRC64NVME.ChecksumBytes(data).Encoded() + "</ChecksumCRC64NVME><ChecksumType>FULL_OBJECT</ChecksumType>", data, false, false},
+		{"<ChecksumSHA256>" + composite + "</ChecksumSHA256><ChecksumType>COMPOSITE</ChecksumType>", data, false, false},
+		{"<ChecksumSHA256>" + composite + "</ChecksumSHA256><ChecksumType>COMPOSITE</ChecksumType>", tampered, true, false},
+		{"<ChecksumSHA256>" + composite + "</ChecksumSHA256><ChecksumType>COMPOSITE</ChecksumType>", data[:len(data)-1], true, false},
+		{"", data, false, true},
+	}
+	for i, testCase := range testCases {
+		checksum, content = testCase.checksum, testCase.content
+		obj, err := clnt.GetObject(context.Background(), "bucket", "object", GetObjectOptions{AutoVerify: true})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		got, err := io.ReadAll(obj)
+		if testCase.mismatch {
+			if ToErrorResponse(err).Code != BadDigest {
+				t.Fatalf("Test %d: expected a checksum mismatch, got %v", i+1, err)
+			}
+		} else if err != nil || !bytes.Equal(got, data) {
+			t.Fatalf("Test %d: unexpected content of %d bytes, %v", i+1, len(got), err)
+		}
+		if ifMatch != `"etag"` {
+			t.Fatalf("Test %d: expected the download to match the ETag, got %q", i+1, ifMatch)
+		}
+		if _, err = obj.ReadAt(make([]byte, 1), 0); !testCase.mismatch && !testCase.unverified && ToErrorResponse(err).Code != InvalidArgument {
+			t.Fatalf("Test %d: expected ReadAt to be rejected, got %v", i+1, err)
+		}
+		info, err := obj.Stat()
+		if err != nil && !testCase.mismatch {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if err == nil && info.ChecksumUnverified != testCase.unverified {
+			t.Fatalf("Test %d: expected ChecksumUnverified %t", i+1, testCase.unverified)
+		}
+		obj.Close()
+	}
+
+	opts := GetObjectOptions{AutoVerify: true}
+	opts.SetRange(0, 10)
+	if _, err := clnt.GetObject(context.Background(), "bucket", "object", opts); ToErrorResponse(err).Code != InvalidArgument {
+		t.Fatalf("expected the range to be rejected, got %v", err)
+	}
+}
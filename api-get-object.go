@@ -21,6 +21,7 @@
 package openstor
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
@@ -34,7 +35,7 @@ import (
 // GetObject wrapper function that accepts a request context
 func (c *Client) GetObject(ctx context.Context, bucketName, objectName string, opts GetObjectOptions) (*Object, error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return nil, ErrorResponse{
 			StatusCode: http.StatusBadRequest,
 			Code:       InvalidBucketName,
@@ -49,6 +50,15 @@ func (c *Client) GetObject(ctx context.Context, bucketName, objectName string, o
 		}
 	}
 
+	var verifier *checksumVerifier
+	if opts.AutoVerify {
+		var err error
+		verifier, err = c.newChecksumVerifier(ctx, bucketName, objectName, &opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	gctx, cancel := context.WithCancel(ctx)
 
 	// Detect if snowball is server location we are talking to.
@@ -250,7 +260,16 @@ func (c *Client) GetObject(ctx context.Context, bucketName, objectName string, o
 	}()
 
 	// Create a newObject through the information sent back by reqCh.
-	return newObject(gctx, cancel, reqCh, resCh), nil
+	obj := newObject(gctx, cancel, reqCh, resCh)
+	obj.decompress = opts.Decompress
+	if opts.AutoVerify {
+		obj.verifier = verifier
+		obj.checksumUnverified = verifier == nil
+	}
+	if opts.ProgressFunc != nil {
+		obj.progress = newProgressFuncHook(nil, opts.ProgressFunc, -1)
+	}
+	return obj, nil
 }
 
 // get request message container to communicate with internal
@@ -305,6 +324,19 @@ type Object struct {
 
 	// Keeps track of if objectInfo has been set yet.
 	objectInfoSet bool
+
+	// Reports the bytes read, if requested by the caller.
+	progress *progressFuncHook
+
+	// Set if the content is decoded with decoder, created upon
+	// the first read.
+	decompress bool
+	decoder    io.ReadCloser
+
+	// Set if the content read is verified, see AutoVerify, and
+	// checksumUnverified if the object has no checksum to verify.
+	verifier           *checksumVerifier
+	checksumUnverified bool
 }
 
 // doGetRequest - sends and blocks on the firstReqCh and reqCh of an object.
@@ -334,6 +366,7 @@ func (o *Object) doGetRequest(request getRequest) (getResponse, error) {
 	// and it hasn't been set before.
 	if !o.objectInfoSet && !request.isReadAt {
 		o.objectInfo = response.objectInfo
+		o.objectInfo.ChecksumUnverified = o.checksumUnverified
 		o.objectInfoSet = true
 	}
 	// Set beenRead only if it has not been set before.
@@ -370,6 +403,44 @@ func (o *Object) Read(b []byte) (n int, err error) {
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
 
+	if o.decompress {
+		return o.readDecompressed(b)
+	}
+	return o.read(b)
+}
+
+// readDecompressed reads the decoded content of the object, the
+// decoder is chosen by the Content-Encoding of the first response.
+func (o *Object) readDecompressed(b []byte) (n int, err error) {
+	if o.decoder == nil {
+		if o.prevErr != nil || o.isClosed {
+			return 0, o.prevErr
+		}
+		raw := bufio.NewReader(objectReader{o})
+		if _, err = raw.Peek(1); err != nil && err != io.EOF {
+			return 0, err
+		}
+		o.decoder, err = newContentDecoder(o.objectInfo.ContentEncoding, raw)
+		if err != nil {
+			o.prevErr = err
+			return 0, err
+		}
+	}
+	return o.decoder.Read(b)
+}
+
+// objectReader reads the raw content of an object, its mutex being
+// held by the caller.
+type objectReader struct {
+	o *Object
+}
+
+func (r objectReader) Read(b []byte) (int, error) {
+	return r.o.read(b)
+}
+
+// read reads the raw content of the object, see Read.
+func (o *Object) read(b []byte) (n int, err error) {
 	// prevErr is previous error saved from previous operation.
 	if o.prevErr != nil || o.isClosed {
 		return 0, o.prevErr
@@ -401,19 +472,50 @@ func (o *Object) Read(b []byte) (n int, err error) {
 
 	// Bytes read.
 	bytesRead := int64(response.Size)
+	o.reportProgress(bytesRead)
+	if o.verifier != nil {
+		o.verifier.Write(b[:response.Size])
+	}
 
 	// Set the new offset.
 	oerr := o.setOffset(bytesRead)
 	if oerr != nil {
 		// Save the error for future calls.
-		o.prevErr = oerr
-		return response.Size, oerr
+		o.prevErr = o.verify(oerr)
+		return response.Size, o.prevErr
+	}
+	if err = o.verify(err); err != nil && err != io.EOF {
+		o.prevErr = err
 	}
 
 	// Return the response.
 	return response.Size, err
 }
 
+// verify returns the error of verifying the content read once its end
+// was reached with io.EOF, err otherwise.
+func (o *Object) verify(err error) error {
+	if err != io.EOF || o.verifier == nil {
+		return err
+	}
+	if verr := o.verifier.verify(); verr != nil {
+		return verr
+	}
+	return err
+}
+
+// reportProgress reports bytesRead to the progress callback, if any.
+func (o *Object) reportProgress(bytesRead int64) {
+	if o.progress == nil {
+		return
+	}
+	total := int64(-1)
+	if o.objectInfoSet {
+		total = o.objectInfo.Size
+	}
+	o.progress.report(bytesRead, total)
+}
+
 // Stat returns the ObjectInfo structure describing Object.
 func (o *Object) Stat() (ObjectInfo, error) {
 	if o == nil {
@@ -461,6 +563,13 @@ func (o *Object) ReadAt(b []byte, offset int64) (n int, err error) {
 		return 0, o.prevErr
 	}
 
+	if o.decompress {
+		return 0, errInvalidArgument("ReadAt is not supported when decompressing the object.")
+	}
+	if o.verifier != nil {
+		return 0, errInvalidArgument("ReadAt is not supported when verifying the object.")
+	}
+
 	// Set the current offset to ReadAt offset, because the current offset will be shifted at the end of this method.
 	o.currOffset = offset
 
@@ -497,6 +606,7 @@ func (o *Object) ReadAt(b []byte, offset int64) (n int, err error) {
 	}
 	// Bytes read.
 	bytesRead := int64(response.Size)
+	o.reportProgress(bytesRead)
 	// There is no valid objectInfo yet
 	// 	to compare against for EOF.
 	if !o.objectInfoSet {
@@ -538,6 +648,13 @@ func (o *Object) Seek(offset int64, whence int) (n int64, err error) {
 		return 0, o.prevErr
 	}
 
+	if o.decompress {
+		return 0, errInvalidArgument("Seek is not supported when decompressing the object.")
+	}
+	if o.verifier != nil {
+		return 0, errInvalidArgument("Seek is not supported when verifying the object.")
+	}
+
 	// Negative offset is valid for whence of '2'.
 	if offset < 0 && whence != 2 {
 		return 0, errInvalidArgument(fmt.Sprintf("Negative position not allowed for %d", whence))
@@ -626,6 +743,9 @@ func (o *Object) Close() (err error) {
 
 	// Close successfully.
 	o.cancel()
+	if o.decoder != nil {
+		o.decoder.Close()
+	}
 
 	// Close the request channel to indicate the internal go-routine to exit.
 	close(o.reqCh)
@@ -659,7 +779,7 @@ func newObject(ctx context.Context, cancel context.CancelFunc, reqCh chan<- getR
 // go to http://www.w3.org/Protocols/rfc2616/rfc2616-sec14.html#sec14.35.
 func (c *Client) getObject(ctx context.Context, bucketName, objectName string, opts GetObjectOptions) (io.ReadCloser, ObjectInfo, http.Header, error) {
 	// Validate input arguments.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return nil, ObjectInfo{}, nil, ErrorResponse{
 			StatusCode: http.StatusBadRequest,
 			Code:       InvalidBucketName,
@@ -674,13 +794,24 @@ func (c *Client) getObject(ctx context.Context, bucketName, objectName string, o
 		}
 	}
 
+	// Ask for the stored bytes, such that transports with compression
+	// enabled do not transparently decompress objects stored with a
+	// Content-Encoding, unless the caller set its own Accept-Encoding.
+	headers := opts.Header()
+	if headers.Get("Accept-Encoding") == "" {
+		headers.Set("Accept-Encoding", "identity")
+	}
+
 	// Execute GET on objectName.
 	resp, err := c.executeMethod(ctx, http.MethodGet, requestMetadata{
-		bucketName:       bucketName,
-		objectName:       objectName,
-		queryValues:      opts.toQueryValues(),
-		customHeader:     opts.Header(),
-		contentSHA256Hex: emptySHA256Hex,
+		bucketName:        bucketName,
+		objectName:        objectName,
+		bucketLocation:    opts.Region,
+		queryValues:       opts.toQueryValues(),
+		customHeader:      headers,
+		contentSHA256Hex:  emptySHA256Hex,
+		userAgent:         opts.UserAgent,
+		streamingResponse: true,
 	})
 	if err != nil {
 		return nil, ObjectInfo{}, nil, err
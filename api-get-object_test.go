@@ -21,12 +21,20 @@
 package openstor
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/md5"
 	"crypto/rand"
+	"encoding/base64"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
+
+	"github.com/openstor/openstor-go/v7/pkg/encrypt"
 )
 
 func TestGetObjectReturnSuccess(t *testing.T) {
@@ -149,3 +157,297 @@ func TestGetObjectReturnErrorIfServerSendsMore(t *testing.T) {
 		t.Fatalf("Expected %v, got %v", io.ErrUnexpectedEOF, err)
 	}
 }
+
+func TestGetObjectProgressFunc(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+		w.Header().Set("Content-Length", "10")
+		w.Write([]byte("0123456789"))
+	}))
+	defer srv.Close()
+
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var transferred, total int64
+	obj, err := clnt.GetObject(context.Background(), "bucketName", "objectName", GetObjectOptions{
+		ProgressFunc: func(bytesTransferred, totalBytes int64) {
+			if bytesTransferred < transferred {
+				t.Errorf("progress went backwards from %d to %d", transferred, bytesTransferred)
+			}
+			transferred, total = bytesTransferred, totalBytes
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 3)
+	for {
+		if _, err = obj.Read(buf); err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if transferred != 10 || total != 10 {
+		t.Fatalf("expected 10/10 bytes reported, got %d/%d", transferred, total)
+	}
+}
+
+func TestGetObjectChecksumMode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+		w.Header().Set("Content-Length", "5")
+		if r.Header.Get("x-amz-checksum-mode") == "ENABLED" {
+			w.Header().Set("x-amz-checksum-crc32c", "yZRlqg==")
+			w.Header().Set("x-amz-checksum-sha256", "WZRHGrsBESr8wYFZ9sx0tPURuZgG2lmzyvWpwXPKz8U=")
+			w.Header().Set("x-amz-checksum-type", "FULL_OBJECT")
+		}
+		w.Write([]byte("12345"))
+	}))
+	defer srv.Close()
+
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, checksum := range []bool{false, true} {
+		obj, err := clnt.GetObject(context.Background(), "bucketName", "objectName", GetObjectOptions{Checksum: checksum})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = io.ReadAll(obj); err != nil {
+			t.Fatal(err)
+		}
+		info, err := obj.Stat()
+		if err != nil {
+			t.Fatal(err)
+		}
+		obj.Close()
+
+		if !checksum {
+			if info.ChecksumCRC32C != "" || info.ChecksumSHA256 != "" {
+				t.Fatalf("expected no checksums without checksum mode, got %+v", info)
+			}
+			continue
+		}
+		if info.ChecksumCRC32C != "yZRlqg==" || info.ChecksumSHA256 != "WZRHGrsBESr8wYFZ9sx0tPURuZgG2lmzyvWpwXPKz8U=" || info.ChecksumMode != "FULL_OBJECT" {
+			t.Fatalf("expected the server checksums, got %+v", info)
+		}
+	}
+}
+
+func TestGetObjectIdentityEncoding(t *testing.T) {
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write([]byte("hello, world"))
+	gz.Close()
+
+	var acceptEncoding string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("Content-Length", strconv.Itoa(compressed.Len()))
+		w.Write(compressed.Bytes())
+	}))
+	defer srv.Close()
+
+	// A transport with transparent decompression enabled.
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Region:    "us-east-1",
+		Transport: http.DefaultTransport.(*http.Transport).Clone(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		acceptEncoding string
+		expected       string
+	}{
+		{"", "identity"},
+		{"gzip", "gzip"},
+	}
+	for i, testCase := range testCases {
+		opts := GetObjectOptions{}
+		if testCase.acceptEncoding != "" {
+			opts.Set("Accept-Encoding", testCase.acceptEncoding)
+		}
+		obj, err := clnt.GetObject(context.Background(), "bucketName", "objectName", opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf, err := io.ReadAll(obj)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		info, err := obj.Stat()
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		obj.Close()
+
+		if acceptEncoding != testCase.expected {
+			t.Fatalf("Test %d: expected Accept-Encoding %s, got %s", i+1, testCase.expected, acceptEncoding)
+		}
+		if !bytes.Equal(buf, compressed.Bytes()) {
+			t.Fatalf("Test %d: expected the stored bytes, got %q", i+1, buf)
+		}
+		if info.ContentEncoding != "gzip" || info.Size != int64(compressed.Len()) {
+			t.Fatalf("Test %d: unexpected object info %+v", i+1, info)
+		}
+	}
+}
+
+func TestGetObjectFullSize(t *testing.T) {
+	testCases := []struct {
+		rangeHeader  string
+		contentRange string
+		size         int64
+		fullSize     int64
+	}{
+		{"", "", 10, 10},
+		{"bytes=2-5", "bytes 2-5/10", 4, 10},
+		{"bytes=2-5", "bytes 2-5/*", 4, -1},
+		{"bytes=2-5", "bytes 2-5/ten", 4, -1},
+		{"bytes=2-5", "items 2-5", 4, -1},
+	}
+
+	for i, testCase := range testCases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got := r.Header.Get("Range"); got != testCase.rangeHeader {
+				t.Errorf("Test %d: unexpected Range %q", i+1, got)
+			}
+			w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+			w.Header().Set("Content-Length", strconv.FormatInt(testCase.size, 10))
+			if testCase.contentRange != "" {
+				w.Header().Set("Content-Range", testCase.contentRange)
+				w.WriteHeader(http.StatusPartialContent)
+			}
+			w.Write(bytes.Repeat([]byte("a"), int(testCase.size)))
+		}))
+
+		clnt, err := New(srv.Listener.Addr().String(), &Options{
+			Region: "us-east-1",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		opts := GetObjectOptions{}
+		if testCase.rangeHeader != "" {
+			opts.SetRange(2, 5)
+		}
+		obj, err := clnt.GetObject(context.Background(), "bucketName", "objectName", opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The object info of a read reflects the GET response.
+		if _, err = io.ReadAll(obj); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		info, err := obj.Stat()
+		obj.Close()
+		srv.Close()
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if info.Size != testCase.size || info.FullSize != testCase.fullSize {
+			t.Fatalf("Test %d: expected size %d of %d, got %d of %d", i+1, testCase.size, testCase.fullSize, info.Size, info.FullSize)
+		}
+	}
+}
+
+func TestGetObjectSSEC(t *testing.T) {
+	data := []byte("customer encrypted content")
+	var stored []byte
+	var storedKeyMD5 string
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Verify the customer key headers as S3 does.
+		key, err := base64.StdEncoding.DecodeString(r.Header.Get(encrypt.SseCustomerKey))
+		keyMD5 := md5.Sum(key)
+		if r.Header.Get(encrypt.SseCustomerAlgorithm) != "AES256" || err != nil || len(key) != 32 ||
+			r.Header.Get(encrypt.SseCustomerKeyMD5) != base64.StdEncoding.EncodeToString(keyMD5[:]) {
+			w.WriteHeader(http.StatusBadRequest)
+			if r.Method != http.MethodHead {
+				io.WriteString(w, `<Error><Code>InvalidRequest</Code><Message>The object was stored using a form of Server Side Encryption. The correct parameters must be provided to retrieve the object.</Message></Error>`)
+			}
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			stored, _, _ = decodeAWSChunked(r.Body)
+			storedKeyMD5 = r.Header.Get(encrypt.SseCustomerKeyMD5)
+			w.Header().Set("ETag", `"etag"`)
+		case http.MethodGet, http.MethodHead:
+			if r.Header.Get(encrypt.SseCustomerKeyMD5) != storedKeyMD5 {
+				w.WriteHeader(http.StatusForbidden)
+				if r.Method == http.MethodGet {
+					io.WriteString(w, `<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`)
+				}
+				return
+			}
+			w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+			w.Header().Set("Content-Length", strconv.Itoa(len(stored)))
+			w.Header().Set(encrypt.SseCustomerAlgorithm, "AES256")
+			w.Header().Set(encrypt.SseCustomerKeyMD5, storedKeyMD5)
+			if r.Method == http.MethodGet {
+				w.Write(stored)
+			}
+		}
+	}))
+
+	sse, err := encrypt.NewSSEC(bytes.Repeat([]byte("k"), 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if _, err = clnt.PutObject(ctx, "bucket", "object", bytes.NewReader(data), int64(len(data)), PutObjectOptions{ServerSideEncryption: sse}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A key passed in its copy form is sent as the customer key too.
+	for i, key := range []encrypt.ServerSide{sse, encrypt.SSECopy(sse)} {
+		obj, err := clnt.GetObject(ctx, "bucket", "object", GetObjectOptions{ServerSideEncryption: key})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		got, err := io.ReadAll(obj)
+		obj.Close()
+		if err != nil || !bytes.Equal(got, data) {
+			t.Fatalf("Test %d: unexpected content %q, %v", i+1, got, err)
+		}
+		info, err := clnt.StatObject(ctx, "bucket", "object", StatObjectOptions{ServerSideEncryption: key})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if info.Size != int64(len(data)) {
+			t.Fatalf("Test %d: unexpected size %d", i+1, info.Size)
+		}
+	}
+
+	// Reading with another key or without a key fails.
+	otherKey, err := encrypt.NewSSEC(bytes.Repeat([]byte("o"), 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, key := range []encrypt.ServerSide{otherKey, nil} {
+		obj, err := clnt.GetObject(ctx, "bucket", "object", GetObjectOptions{ServerSideEncryption: key})
+		if err == nil {
+			_, err = obj.Stat()
+			obj.Close()
+		}
+		if err == nil {
+			t.Fatalf("Test %d: expected reading the object to fail", i+1)
+		}
+	}
+}
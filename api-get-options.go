@@ -44,13 +44,51 @@ type GetObjectOptions struct {
 	reqParams            url.Values
 	ServerSideEncryption encrypt.ServerSide
 	VersionID            string
-	PartNumber           int
+
+	// PartNumber requests a single part of a multipart object. With
+	// StatObject the size, ETag and, with Checksum, the checksum of
+	// the part are returned along with the number of parts.
+	PartNumber int
 
 	// Include any checksums, if object was uploaded with checksum.
 	// For multipart objects this is a checksum of part checksums.
 	// https://docs.aws.amazon.com/AmazonS3/latest/userguide/checking-object-integrity.html
 	Checksum bool
 
+	// ProgressFunc is invoked by the returned Object with the number of
+	// bytes read so far and the size from Content-Length after every
+	// read. Calls are never made concurrently.
+	ProgressFunc func(bytesTransferred, totalBytes int64)
+
+	// Region is the region the request is signed for, bypassing the
+	// cached bucket location. Useful when the location guessed for a
+	// bucket on an S3 compatible backend is wrong.
+	Region string
+
+	// UserAgent is a token appended to the User-Agent of the client
+	// for this request, e.g. "myapp/1.0".
+	UserAgent string
+
+	// Decompress decodes the content read from the returned Object
+	// according to its gzip or zstd Content-Encoding, as set by
+	// PutObjectOptions.Compress. Stat still reports the stored size,
+	// ReadAt and Seek are not supported. Ignored by StatObject.
+	Decompress bool
+
+	// RequestPayer sends "x-amz-request-payer: requester" to access
+	// objects of Requester Pays buckets, acknowledging that the
+	// requester is charged for the request.
+	RequestPayer bool
+
+	// AutoVerify verifies the content read from the returned Object
+	// against the checksum stored with it, whose type is learned with
+	// GetObjectAttributes before the download. Reading the end of the
+	// content fails with a BadDigest error on a mismatch. Objects stored
+	// without a checksum are not verified, as reported by Stat in
+	// ChecksumUnverified. ReadAt, Seek and ranges are not supported.
+	// Ignored by StatObject.
+	AutoVerify bool
+
 	// To be not used by external applications
 	Internal AdvancedGetOptions
 }
@@ -66,7 +104,9 @@ func (o GetObjectOptions) Header() http.Header {
 		headers.Set(k, v)
 	}
 	if o.ServerSideEncryption != nil && o.ServerSideEncryption.Type() == encrypt.SSEC {
-		o.ServerSideEncryption.Marshal(headers)
+		// Send the customer key headers, also for a key passed
+		// through encrypt.SSECopy.
+		encrypt.SSE(o.ServerSideEncryption).Marshal(headers)
 	}
 	// this header is set for active-active replication scenario where GET/HEAD
 	// to site A is proxy'd to site B if object/version missing on site A.
@@ -76,6 +116,9 @@ func (o GetObjectOptions) Header() http.Header {
 	if o.Checksum {
 		headers.Set("x-amz-checksum-mode", "ENABLED")
 	}
+	if o.RequestPayer {
+		headers.Set(amzRequestPayer, requestPayerRequester)
+	}
 	return headers
 }
 
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"net/http"
+	"slices"
+)
+
+// signatureHeaders - headers computed for the signature of a request,
+// which request interceptors must not set.
+var signatureHeaders = []string{
+	"Authorization",
+	"X-Amz-Date",
+	"X-Amz-Content-Sha256",
+	"X-Amz-Decoded-Content-Length",
+	"X-Amz-Security-Token",
+	"Content-Md5",
+}
+
+// interceptRequest - runs the request interceptors in order on req, before
+// it is signed. An error is returned if an interceptor failed or changed
+// the request such that it would no longer match its signature or body.
+func (c *Client) interceptRequest(req *http.Request) error {
+	if len(c.requestInterceptors) == 0 {
+		return nil
+	}
+
+	method, targetURL, host, contentLength := req.Method, req.URL.String(), req.Host, req.ContentLength
+	reserved := make(map[string][]string, len(signatureHeaders))
+	for _, k := range signatureHeaders {
+		reserved[k] = slices.Clone(req.Header.Values(k))
+	}
+
+	for _, intercept := range c.requestInterceptors {
+		if err := intercept(req); err != nil {
+			return err
+		}
+	}
+
+	if req.Method != method || req.URL.String() != targetURL || req.Host != host || req.ContentLength != contentLength {
+		return errInvalidArgument("Request interceptors must not change the method, URL, host or content length of a request.")
+	}
+	for k, v := range reserved {
+		if !slices.Equal(req.Header.Values(k), v) {
+			return errInvalidArgument("Request interceptors must not set the " + k + " header, it is computed for the signature.")
+		}
+	}
+	return nil
+}
+
+// interceptorError - error returned by a response interceptor, such
+// requests are not retried.
+type interceptorError struct {
+	err error
+}
+
+func (e interceptorError) Error() string {
+	return e.err.Error()
+}
+
+func (e interceptorError) Unwrap() error {
+	return e.err
+}
+
+// interceptResponse - runs the response interceptors in order on resp,
+// before it is processed.
+func (c *Client) interceptResponse(resp *http.Response) error {
+	for _, intercept := range c.responseInterceptors {
+		if err := intercept(resp); err != nil {
+			return interceptorError{err: err}
+		}
+	}
+	return nil
+}
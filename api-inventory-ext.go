@@ -29,8 +29,6 @@ import (
 	"net/url"
 	"strings"
 	"time"
-
-	"github.com/openstor/openstor-go/v7/pkg/s3utils"
 )
 
 // This file contains the inventory API extension for MinIO server. It is not
@@ -62,7 +60,7 @@ func makeInventoryReqMetadata(bucket string, urlParams ...string) requestMetadat
 //
 // Returns a YAML template string that can be customized and used with PutBucketInventoryConfiguration.
 func (c *Client) GenerateInventoryConfigYAML(ctx context.Context, bucket, id string) (string, error) {
-	if err := s3utils.CheckValidBucketName(bucket); err != nil {
+	if err := c.checkBucketName(bucket); err != nil {
 		return "", err
 	}
 	if id == "" {
@@ -102,7 +100,7 @@ type InventoryPutConfigOption func(*inventoryPutConfigOpts)
 //
 // Returns an error if the operation fails, or if bucket name, id, or yamlDef is empty.
 func (c *Client) PutBucketInventoryConfiguration(ctx context.Context, bucket string, id string, yamlDef string, _ ...InventoryPutConfigOption) error {
-	if err := s3utils.CheckValidBucketName(bucket); err != nil {
+	if err := c.checkBucketName(bucket); err != nil {
 		return err
 	}
 	if id == "" {
@@ -137,7 +135,7 @@ func (c *Client) PutBucketInventoryConfiguration(ctx context.Context, bucket str
 //
 // Returns the inventory configuration or an error if the operation fails or if the configuration doesn't exist.
 func (c *Client) GetBucketInventoryConfiguration(ctx context.Context, bucket, id string) (*InventoryConfiguration, error) {
-	if err := s3utils.CheckValidBucketName(bucket); err != nil {
+	if err := c.checkBucketName(bucket); err != nil {
 		return nil, err
 	}
 	if id == "" {
@@ -171,7 +169,7 @@ func (c *Client) GetBucketInventoryConfiguration(ctx context.Context, bucket, id
 //
 // Returns an error if the operation fails or if the configuration doesn't exist.
 func (c *Client) DeleteBucketInventoryConfiguration(ctx context.Context, bucket, id string) error {
-	if err := s3utils.CheckValidBucketName(bucket); err != nil {
+	if err := c.checkBucketName(bucket); err != nil {
 		return err
 	}
 	if id == "" {
@@ -214,7 +212,7 @@ type InventoryListResult struct {
 //
 // Returns a list result with configurations and a continuation token for the next page, or an error.
 func (c *Client) ListBucketInventoryConfigurations(ctx context.Context, bucket, continuationToken string) (lr *InventoryListResult, err error) {
-	if err := s3utils.CheckValidBucketName(bucket); err != nil {
+	if err := c.checkBucketName(bucket); err != nil {
 		return nil, err
 	}
 	reqMeta := makeInventoryReqMetadata(bucket, "continuation-token", continuationToken)
@@ -246,7 +244,7 @@ func (c *Client) ListBucketInventoryConfigurations(ctx context.Context, bucket,
 // handles pagination and fetches all configurations.
 func (c *Client) ListBucketInventoryConfigurationsIterator(ctx context.Context, bucket string) iter.Seq2[InventoryConfiguration, error] {
 	return func(yield func(InventoryConfiguration, error) bool) {
-		if err := s3utils.CheckValidBucketName(bucket); err != nil {
+		if err := c.checkBucketName(bucket); err != nil {
 			yield(InventoryConfiguration{}, err)
 			return
 		}
@@ -310,7 +308,7 @@ type InventoryJobStatus struct {
 //
 // Returns the inventory job status including execution state, progress, and error information, or an error if the operation fails.
 func (c *Client) GetBucketInventoryJobStatus(ctx context.Context, bucket, id string) (*InventoryJobStatus, error) {
-	if err := s3utils.CheckValidBucketName(bucket); err != nil {
+	if err := c.checkBucketName(bucket); err != nil {
 		return nil, err
 	}
 	if id == "" {
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// noSuchObjectLockConfiguration is returned for object versions without
+// a retention period or legal hold.
+const noSuchObjectLockConfiguration = "NoSuchObjectLockConfiguration"
+
+// defaultRetainedObjectsConcurrency is the number of object versions
+// whose retention and legal hold are fetched concurrently by default.
+const defaultRetainedObjectsConcurrency = 4
+
+// ListRetainedObjectsOptions holds all options of a ListRetainedObjects call.
+type ListRetainedObjectsOptions struct {
+	// Only list versions of objects with the prefix
+	Prefix string
+	// The maximum number of versions requested per
+	// batch, advanced use-case not useful for most
+	// applications
+	MaxKeys int
+
+	// Concurrency is the number of object versions whose retention
+	// and legal hold are fetched concurrently, 4 if not set.
+	Concurrency int
+	// SkipLegalHold skips fetching the legal hold of the object
+	// versions, halving the number of requests. Versions protected
+	// only by a legal hold are then not returned.
+	SkipLegalHold bool
+}
+
+// RetainedObject is an object version protected by object lock, either
+// by an active retention period or by a legal hold.
+type RetainedObject struct {
+	ObjectInfo
+
+	// Mode and RetainUntilDate are only set if the retention period
+	// of the version has not expired yet.
+	Mode            RetentionMode
+	RetainUntilDate time.Time
+
+	// LegalHold is set if a legal hold is placed on the version.
+	LegalHold bool
+}
+
+// ListRetainedObjects returns the object versions of the bucket which
+// currently cannot be removed because of object lock: versions with a
+// retention period expiring in the future or with a legal hold. One
+// request per version is sent to fetch its retention, and another one
+// for its legal hold unless opts.SkipLegalHold is set, opts.Concurrency
+// of them at a time. Versions are returned in the listing order, delete
+// markers are skipped.
+//
+// The listing stops after the first error.
+func (c *Client) ListRetainedObjects(ctx context.Context, bucketName string, opts ListRetainedObjectsOptions) iter.Seq2[RetainedObject, error] {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultRetainedObjectsConcurrency
+	}
+
+	type pending struct {
+		done     chan struct{}
+		obj      RetainedObject
+		retained bool
+		err      error
+	}
+
+	return func(yield func(RetainedObject, error) bool) {
+		fetchCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		versions := c.listObjectVersions(fetchCtx, bucketName, ListObjectsOptions{
+			WithVersions: true,
+			Prefix:       opts.Prefix,
+			Recursive:    true,
+			MaxKeys:      opts.MaxKeys,
+		})
+
+		// Versions are fetched in the listing order and queued, such
+		// that they are yielded in that order as well.
+		queue := make(chan *pending, concurrency)
+		sem := make(chan struct{}, concurrency)
+		go func() {
+			defer close(queue)
+			for info := range versions {
+				if info.IsDeleteMarker {
+					continue
+				}
+				p := &pending{done: make(chan struct{})}
+				if info.Err != nil {
+					p.err = info.Err
+					close(p.done)
+				} else {
+					select {
+					case sem <- struct{}{}:
+					case <-fetchCtx.Done():
+						return
+					}
+					go func() {
+						defer func() { <-sem }()
+						defer close(p.done)
+						p.obj, p.retained, p.err = c.getRetainedObject(fetchCtx, bucketName, info, opts.SkipLegalHold)
+					}()
+				}
+				select {
+				case queue <- p:
+				case <-fetchCtx.Done():
+					return
+				}
+				if info.Err != nil {
+					return
+				}
+			}
+		}()
+
+		for p := range queue {
+			<-p.done
+			if p.err != nil {
+				yield(RetainedObject{}, p.err)
+				return
+			}
+			if p.retained && !yield(p.obj, nil) {
+				return
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			yield(RetainedObject{}, err)
+		}
+	}
+}
+
+// getRetainedObject fetches the retention and legal hold of an object
+// version, returning true if either of them is active.
+func (c *Client) getRetainedObject(ctx context.Context, bucketName string, info ObjectInfo, skipLegalHold bool) (obj RetainedObject, retained bool, err error) {
+	obj = RetainedObject{ObjectInfo: info}
+
+	retention, err := c.GetObjectRetentionInfo(ctx, bucketName, info.Key, info.VersionID)
+	if err != nil {
+		return obj, false, err
+	}
+	if retention.Mode.IsValid() && retention.Remaining > 0 {
+		obj.Mode = retention.Mode
+		obj.RetainUntilDate = retention.RetainUntilDate
+		retained = true
+	}
+
+	if !skipLegalHold {
+		status, err := c.GetObjectLegalHold(ctx, bucketName, info.Key, GetObjectLegalHoldOptions{VersionID: info.VersionID})
+		if err != nil && ToErrorResponse(err).Code != noSuchObjectLockConfiguration {
+			return obj, false, err
+		}
+		if status != nil && *status == LegalHoldEnabled {
+			obj.LegalHold = true
+			retained = true
+		}
+	}
+	return obj, retained, nil
+}
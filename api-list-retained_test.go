@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestListRetainedObjects(t *testing.T) {
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	retentions := map[string]string{
+		"a/v2": `<Retention><Mode>GOVERNANCE</Mode><RetainUntilDate>` + future + `</RetainUntilDate></Retention>`,
+		"a/v1": `<Retention><Mode>COMPLIANCE</Mode><RetainUntilDate>` + past + `</RetainUntilDate></Retention>`,
+		"c/v1": `<Retention><Mode>COMPLIANCE</Mode><RetainUntilDate>` + future + `</RetainUntilDate></Retention>`,
+	}
+	legalHolds := map[string]string{
+		"b/v1": `<LegalHold><Status>ON</Status></LegalHold>`,
+		"c/v1": `<LegalHold><Status>OFF</Status></LegalHold>`,
+	}
+	var legalHoldRequests int32
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		version := r.URL.Path[len("/bucket/"):] + "/" + query.Get("versionId")
+		var body string
+		var ok bool
+		switch {
+		case query.Has("versions"):
+			fmt.Fprint(w, `<ListVersionsResult><Name>bucket</Name><IsTruncated>false</IsTruncated>`+
+				`<DeleteMarker><Key>a</Key><VersionId>dm1</VersionId><IsLatest>true</IsLatest></DeleteMarker>`+
+				`<Version><Key>a</Key><VersionId>v2</VersionId><IsLatest>false</IsLatest></Version>`+
+				`<Version><Key>a</Key><VersionId>v1</VersionId><IsLatest>false</IsLatest></Version>`+
+				`<Version><Key>b</Key><VersionId>v1</VersionId><IsLatest>true</IsLatest></Version>`+
+				`<Version><Key>c</Key><VersionId>v1</VersionId><IsLatest>true</IsLatest></Version>`+
+				`<Version><Key>d</Key><VersionId>v1</VersionId><IsLatest>true</IsLatest></Version></ListVersionsResult>`)
+			return
+		case query.Has("retention"):
+			body, ok = retentions[version]
+		case query.Has("legal-hold"):
+			atomic.AddInt32(&legalHoldRequests, 1)
+			body, ok = legalHolds[version]
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<Error><Code>NoSuchObjectLockConfiguration</Code><Message>The specified object does not have a ObjectLock configuration</Message></Error>`)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+
+	testCases := []struct {
+		opts     ListRetainedObjectsOptions
+		expected []string
+	}{
+		{ListRetainedObjectsOptions{}, []string{"a/v2/GOVERNANCE/false", "b/v1//true", "c/v1/COMPLIANCE/false"}},
+		{ListRetainedObjectsOptions{Concurrency: 1}, []string{"a/v2/GOVERNANCE/false", "b/v1//true", "c/v1/COMPLIANCE/false"}},
+		{ListRetainedObjectsOptions{SkipLegalHold: true}, []string{"a/v2/GOVERNANCE/false", "c/v1/COMPLIANCE/false"}},
+	}
+
+	for i, testCase := range testCases {
+		atomic.StoreInt32(&legalHoldRequests, 0)
+		var got []string
+		for obj, err := range clnt.ListRetainedObjects(context.Background(), "bucket", testCase.opts) {
+			if err != nil {
+				t.Fatalf("Test %d: unexpected error %v", i+1, err)
+			}
+			if obj.Mode != "" && !obj.RetainUntilDate.After(time.Now()) {
+				t.Fatalf("Test %d: unexpected retain until date %s", i+1, obj.RetainUntilDate)
+			}
+			got = append(got, fmt.Sprintf("%s/%s/%s/%t", obj.Key, obj.VersionID, obj.Mode, obj.LegalHold))
+		}
+		if fmt.Sprint(got) != fmt.Sprint(testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+		if n := atomic.LoadInt32(&legalHoldRequests); testCase.opts.SkipLegalHold && n != 0 {
+			t.Fatalf("Test %d: expected no legal hold requests, got %d", i+1, n)
+		}
+	}
+
+	// Stopping the iteration early does not block.
+	for range clnt.ListRetainedObjects(context.Background(), "bucket", ListRetainedObjectsOptions{}) {
+		break
+	}
+}
@@ -21,12 +21,16 @@
 package openstor
 
 import (
+	"bufio"
 	"context"
+	"encoding/xml"
 	"fmt"
 	"iter"
 	"net/http"
 	"net/url"
 	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/openstor/openstor-go/v7/pkg/s3utils"
@@ -61,6 +65,99 @@ func (c *Client) ListBuckets(ctx context.Context) ([]BucketInfo, error) {
 	return listAllMyBucketsResult.Buckets.Bucket, nil
 }
 
+// ListBucketsOptions holds all options of a paginated list buckets request.
+type ListBucketsOptions struct {
+	// BucketRegion only lists the buckets located in this region.
+	BucketRegion string
+	// Prefix only lists the buckets with names starting with it.
+	Prefix string
+	// MaxBuckets is the number of buckets listed per request, between
+	// 1 and 10000, defaults to 10000.
+	MaxBuckets int
+}
+
+// ListBucketsWithOpts lists the buckets owned by this authenticated user,
+// page by page through the continuation token. The region of each bucket
+// is set when the server provides it. Servers ignoring the filters are
+// filtered client side, buckets without a region reported are then kept.
+//
+// api := client.New(....)
+// buckets, err := api.ListBucketsWithOpts(context.Background(), ListBucketsOptions{BucketRegion: "us-east-1"})
+func (c *Client) ListBucketsWithOpts(ctx context.Context, opts ListBucketsOptions) (iter.Seq2[BucketInfo, error], error) {
+	if opts.MaxBuckets < 0 || opts.MaxBuckets > 10000 {
+		return nil, errInvalidArgument("MaxBuckets must be between 1 and 10000.")
+	}
+	if opts.MaxBuckets == 0 {
+		opts.MaxBuckets = 10000
+	}
+
+	fetchBuckets := func(continuationToken string) ([]BucketInfo, string, error) {
+		metadata := requestMetadata{contentSHA256Hex: emptySHA256Hex}
+		metadata.queryValues = url.Values{}
+		metadata.queryValues.Set("max-buckets", strconv.Itoa(opts.MaxBuckets))
+		if opts.BucketRegion != "" {
+			metadata.queryValues.Set("bucket-region", opts.BucketRegion)
+		}
+		if opts.Prefix != "" {
+			metadata.queryValues.Set("prefix", opts.Prefix)
+		}
+		if continuationToken != "" {
+			metadata.queryValues.Set("continuation-token", continuationToken)
+		}
+
+		// Execute GET on service.
+		resp, err := c.executeMethod(ctx, http.MethodGet, metadata)
+		defer closeResponse(resp)
+		if err != nil {
+			return nil, "", err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, "", httpRespToErrorResponse(resp, "", "")
+		}
+
+		results := listAllMyBucketsResult{}
+		if err = xmlDecoder(resp.Body, &results); err != nil {
+			return nil, "", err
+		}
+
+		return results.Buckets.Bucket, results.ContinuationToken, nil
+	}
+
+	return func(yield func(BucketInfo, error) bool) {
+		var continuationToken string
+		for {
+			buckets, token, err := fetchBuckets(continuationToken)
+			if err != nil {
+				yield(BucketInfo{}, err)
+				return
+			}
+			for _, bucket := range buckets {
+				if !strings.HasPrefix(bucket.Name, opts.Prefix) {
+					continue
+				}
+				if opts.BucketRegion != "" && bucket.BucketRegion != "" && bucket.BucketRegion != opts.BucketRegion {
+					continue
+				}
+				if !yield(bucket, nil) {
+					return
+				}
+			}
+			if token == "" {
+				// nothing to continue
+				return
+			}
+			if token == continuationToken {
+				yield(BucketInfo{}, ErrorResponse{
+					Code:    NotImplemented,
+					Message: "Listing should advance the continuation token",
+				})
+				return
+			}
+			continuationToken = token
+		}
+	}, nil
+}
+
 // ListDirectoryBuckets list all buckets owned by this authenticated user.
 //
 // This call requires explicit authentication, no anonymous requests are
@@ -121,6 +218,10 @@ func (c *Client) ListDirectoryBuckets(ctx context.Context) (iter.Seq2[BucketInfo
 
 // Bucket List Operations.
 func (c *Client) listObjectsV2(ctx context.Context, bucketName string, opts ListObjectsOptions) iter.Seq[ObjectInfo] {
+	if opts.Minimal {
+		return c.listObjectsV2Minimal(ctx, bucketName, opts)
+	}
+
 	// Default listing is delimited at "/"
 	delimiter := "/"
 	if opts.Recursive {
@@ -137,7 +238,7 @@ func (c *Client) listObjectsV2(ctx context.Context, bucketName string, opts List
 		}
 
 		// Validate bucket name.
-		if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		if err := c.checkBucketName(bucketName); err != nil {
 			yield(ObjectInfo{Err: err})
 			return
 		}
@@ -157,7 +258,7 @@ func (c *Client) listObjectsV2(ctx context.Context, bucketName string, opts List
 
 			// Get list of objects a maximum of 1000 per request.
 			result, err := c.listObjectsV2Query(ctx, bucketName, opts.Prefix, continuationToken,
-				fetchOwner, opts.WithMetadata, delimiter, opts.StartAfter, opts.MaxKeys, opts.headers)
+				fetchOwner, opts.WithMetadata, delimiter, opts.StartAfter, opts.MaxKeys, opts.requestHeaders(), opts.Region, opts.UserAgent)
 			if err != nil {
 				yield(ObjectInfo{Err: err})
 				return
@@ -212,14 +313,178 @@ func (c *Client) listObjectsV2(ctx context.Context, bucketName string, opts List
 // ?delimiter - A delimiter is a character you use to group keys.
 // ?start-after - Sets a marker to start listing lexically at this key onwards.
 // ?max-keys - Sets the maximum number of keys returned in the response body.
-func (c *Client) listObjectsV2Query(ctx context.Context, bucketName, objectPrefix, continuationToken string, fetchOwner, metadata bool, delimiter, startAfter string, maxkeys int, headers http.Header) (ListBucketV2Result, error) {
-	// Validate bucket name.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+func (c *Client) listObjectsV2Query(ctx context.Context, bucketName, objectPrefix, continuationToken string, fetchOwner, metadata bool, delimiter, startAfter string, maxkeys int, headers http.Header, region, userAgent string) (ListBucketV2Result, error) {
+	resp, err := c.listObjectsV2Request(ctx, bucketName, objectPrefix, continuationToken, fetchOwner, metadata, delimiter, startAfter, maxkeys, headers, region, userAgent)
+	defer closeResponse(resp)
+	if err != nil {
 		return ListBucketV2Result{}, err
 	}
+
+	// Decode listBuckets XML.
+	listBucketResult := ListBucketV2Result{}
+	if err = xmlDecoder(resp.Body, &listBucketResult); err != nil {
+		return listBucketResult, err
+	}
+
+	// This is an additional verification check to make
+	// sure proper responses are received.
+	if listBucketResult.IsTruncated && listBucketResult.NextContinuationToken == "" {
+		return listBucketResult, ErrorResponse{
+			Code:    NotImplemented,
+			Message: "Truncated response should have continuation token set",
+		}
+	}
+
+	for i, obj := range listBucketResult.Contents {
+		listBucketResult.Contents[i].Key, err = decodeS3Name(obj.Key, listBucketResult.EncodingType)
+		if err != nil {
+			return listBucketResult, err
+		}
+		listBucketResult.Contents[i].LastModified = listBucketResult.Contents[i].LastModified.Truncate(time.Millisecond)
+	}
+
+	for i, obj := range listBucketResult.CommonPrefixes {
+		listBucketResult.CommonPrefixes[i].Prefix, err = decodeS3Name(obj.Prefix, listBucketResult.EncodingType)
+		if err != nil {
+			return listBucketResult, err
+		}
+	}
+
+	// Success.
+	return listBucketResult, nil
+}
+
+// listObjectMinimal holds the object fields decoded when listing with
+// ListObjectsOptions.Minimal.
+type listObjectMinimal struct {
+	Key          string
+	LastModified time.Time
+	ETag         string
+	Size         int64
+	StorageClass string
+}
+
+// listBucketV2MinimalResult is the List Objects V2 result decoded when
+// listing with ListObjectsOptions.Minimal.
+type listBucketV2MinimalResult struct {
+	CommonPrefixes        []CommonPrefix
+	Contents              []listObjectMinimal
+	EncodingType          string
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+// listObjectsV2Minimal lists the objects like listObjectsV2 without
+// requesting their owner and metadata. The pages reuse the slices of
+// the result and the read buffer, each page still allocating its XML
+// decoder and the decoded strings.
+func (c *Client) listObjectsV2Minimal(ctx context.Context, bucketName string, opts ListObjectsOptions) iter.Seq[ObjectInfo] {
+	// Default listing is delimited at "/"
+	delimiter := "/"
+	if opts.Recursive {
+		// If recursive we do not delimit.
+		delimiter = ""
+	}
+
+	return func(yield func(ObjectInfo) bool) {
+		if contextCanceled(ctx) {
+			return
+		}
+
+		var result listBucketV2MinimalResult
+		br := bufio.NewReader(nil)
+		var continuationToken string
+		for {
+			if contextCanceled(ctx) {
+				return
+			}
+
+			err := c.listObjectsV2MinimalQuery(ctx, bucketName, continuationToken, delimiter, opts, br, &result)
+			if err != nil {
+				yield(ObjectInfo{Err: err})
+				return
+			}
+
+			for _, object := range result.Contents {
+				key, err := decodeS3Name(object.Key, result.EncodingType)
+				if err != nil {
+					yield(ObjectInfo{Err: err})
+					return
+				}
+				if !yield(ObjectInfo{
+					Key:          key,
+					LastModified: object.LastModified.Truncate(time.Millisecond),
+					ETag:         trimEtag(object.ETag),
+					Size:         object.Size,
+					StorageClass: object.StorageClass,
+				}) {
+					return
+				}
+			}
+
+			// Send all common prefixes if any.
+			for _, obj := range result.CommonPrefixes {
+				prefix, err := decodeS3Name(obj.Prefix, result.EncodingType)
+				if err != nil {
+					yield(ObjectInfo{Err: err})
+					return
+				}
+				if !yield(ObjectInfo{Key: prefix}) {
+					return
+				}
+			}
+
+			// Listing ends result is not truncated, return right here.
+			if !result.IsTruncated {
+				return
+			}
+			continuationToken = result.NextContinuationToken
+		}
+	}
+}
+
+// listObjectsV2MinimalQuery lists a page of objects into result, reusing
+// its slices, and reads the response through br.
+func (c *Client) listObjectsV2MinimalQuery(ctx context.Context, bucketName, continuationToken, delimiter string, opts ListObjectsOptions, br *bufio.Reader, result *listBucketV2MinimalResult) error {
+	resp, err := c.listObjectsV2Request(ctx, bucketName, opts.Prefix, continuationToken, false, false, delimiter, opts.StartAfter, opts.MaxKeys, opts.requestHeaders(), opts.Region, opts.UserAgent)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	// Clear the previous page, the decoder appends to the slices.
+	clear(result.Contents)
+	clear(result.CommonPrefixes)
+	*result = listBucketV2MinimalResult{
+		Contents:       result.Contents[:0],
+		CommonPrefixes: result.CommonPrefixes[:0],
+	}
+	br.Reset(resp.Body)
+	if err = xml.NewDecoder(br).Decode(result); err != nil {
+		return err
+	}
+
+	// This is an additional verification check to make
+	// sure proper responses are received.
+	if result.IsTruncated && result.NextContinuationToken == "" {
+		return ErrorResponse{
+			Code:    NotImplemented,
+			Message: "Truncated response should have continuation token set",
+		}
+	}
+	return nil
+}
+
+// listObjectsV2Request sends a List Objects V2 request, see
+// listObjectsV2Query, the returned response must be closed.
+func (c *Client) listObjectsV2Request(ctx context.Context, bucketName, objectPrefix, continuationToken string, fetchOwner, metadata bool, delimiter, startAfter string, maxkeys int, headers http.Header, region, userAgent string) (*http.Response, error) {
+	// Validate bucket name.
+	if err := c.checkBucketName(bucketName); err != nil {
+		return nil, err
+	}
 	// Validate object prefix.
 	if err := s3utils.CheckValidObjectNamePrefix(objectPrefix); err != nil {
-		return ListBucketV2Result{}, err
+		return nil, err
 	}
 	// Get resources properly escaped and lined up before
 	// using them in http request.
@@ -264,52 +529,19 @@ func (c *Client) listObjectsV2Query(ctx context.Context, bucketName, objectPrefi
 	// Execute GET on bucket to list objects.
 	resp, err := c.executeMethod(ctx, http.MethodGet, requestMetadata{
 		bucketName:       bucketName,
+		bucketLocation:   region,
 		queryValues:      urlValues,
 		contentSHA256Hex: emptySHA256Hex,
 		customHeader:     headers,
+		userAgent:        userAgent,
 	})
-	defer closeResponse(resp)
 	if err != nil {
-		return ListBucketV2Result{}, err
-	}
-	if resp != nil {
-		if resp.StatusCode != http.StatusOK {
-			return ListBucketV2Result{}, httpRespToErrorResponse(resp, bucketName, "")
-		}
-	}
-
-	// Decode listBuckets XML.
-	listBucketResult := ListBucketV2Result{}
-	if err = xmlDecoder(resp.Body, &listBucketResult); err != nil {
-		return listBucketResult, err
-	}
-
-	// This is an additional verification check to make
-	// sure proper responses are received.
-	if listBucketResult.IsTruncated && listBucketResult.NextContinuationToken == "" {
-		return listBucketResult, ErrorResponse{
-			Code:    NotImplemented,
-			Message: "Truncated response should have continuation token set",
-		}
-	}
-
-	for i, obj := range listBucketResult.Contents {
-		listBucketResult.Contents[i].Key, err = decodeS3Name(obj.Key, listBucketResult.EncodingType)
-		if err != nil {
-			return listBucketResult, err
-		}
-		listBucketResult.Contents[i].LastModified = listBucketResult.Contents[i].LastModified.Truncate(time.Millisecond)
+		return resp, err
 	}
-
-	for i, obj := range listBucketResult.CommonPrefixes {
-		listBucketResult.CommonPrefixes[i].Prefix, err = decodeS3Name(obj.Prefix, listBucketResult.EncodingType)
-		if err != nil {
-			return listBucketResult, err
-		}
+	if resp.StatusCode != http.StatusOK {
+		return resp, httpRespToErrorResponse(resp, bucketName, "")
 	}
-
-	// Success.
-	return listBucketResult, nil
+	return resp, nil
 }
 
 func (c *Client) listObjects(ctx context.Context, bucketName string, opts ListObjectsOptions) iter.Seq[ObjectInfo] {
@@ -326,7 +558,7 @@ func (c *Client) listObjects(ctx context.Context, bucketName string, opts ListOb
 		}
 
 		// Validate bucket name.
-		if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		if err := c.checkBucketName(bucketName); err != nil {
 			yield(ObjectInfo{Err: err})
 			return
 		}
@@ -344,7 +576,7 @@ func (c *Client) listObjects(ctx context.Context, bucketName string, opts ListOb
 			}
 
 			// Get list of objects a maximum of 1000 per request.
-			result, err := c.listObjectsQuery(ctx, bucketName, opts.Prefix, marker, delimiter, opts.MaxKeys, opts.headers)
+			result, err := c.listObjectsQuery(ctx, bucketName, opts.Prefix, marker, delimiter, opts.MaxKeys, opts.requestHeaders(), opts.Region, opts.UserAgent)
 			if err != nil {
 				yield(ObjectInfo{Err: err})
 				return
@@ -395,7 +627,7 @@ func (c *Client) listObjectVersions(ctx context.Context, bucketName string, opts
 		}
 
 		// Validate bucket name.
-		if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		if err := c.checkBucketName(bucketName); err != nil {
 			yield(ObjectInfo{Err: err})
 			return
 		}
@@ -495,13 +727,13 @@ func (c *Client) listObjectVersions(ctx context.Context, bucketName string, opts
 				}
 			}
 
-			// If next key marker is present, save it for next request.
+			// If next key marker is present, save it for next request
+			// along with the version id marker, which is empty if the
+			// page ended on a key boundary or a common prefix.
 			if result.NextKeyMarker != "" {
 				keyMarker = result.NextKeyMarker
-			}
-
-			// If next version id marker is present, save it for next request.
-			if result.NextVersionIDMarker != "" {
+				versionIDMarker = result.NextVersionIDMarker
+			} else if result.NextVersionIDMarker != "" {
 				versionIDMarker = result.NextVersionIDMarker
 			}
 
@@ -532,7 +764,7 @@ func (c *Client) listObjectVersions(ctx context.Context, bucketName string, opts
 // ?max-keys - Sets the maximum number of keys returned in the response body.
 func (c *Client) listObjectVersionsQuery(ctx context.Context, bucketName string, opts ListObjectsOptions, keyMarker, versionIDMarker, delimiter string) (ListVersionsResult, error) {
 	// Validate bucket name.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return ListVersionsResult{}, err
 	}
 	// Validate object prefix.
@@ -577,9 +809,11 @@ func (c *Client) listObjectVersionsQuery(ctx context.Context, bucketName string,
 	// Execute GET on bucket to list objects.
 	resp, err := c.executeMethod(ctx, http.MethodGet, requestMetadata{
 		bucketName:       bucketName,
+		bucketLocation:   opts.Region,
 		queryValues:      urlValues,
 		contentSHA256Hex: emptySHA256Hex,
-		customHeader:     opts.headers,
+		customHeader:     opts.requestHeaders(),
+		userAgent:        opts.UserAgent,
 	})
 	defer closeResponse(resp)
 	if err != nil {
@@ -631,9 +865,9 @@ func (c *Client) listObjectVersionsQuery(ctx context.Context, bucketName string,
 // ?delimiter - A delimiter is a character you use to group keys.
 // ?prefix - Limits the response to keys that begin with the specified prefix.
 // ?max-keys - Sets the maximum number of keys returned in the response body.
-func (c *Client) listObjectsQuery(ctx context.Context, bucketName, objectPrefix, objectMarker, delimiter string, maxkeys int, headers http.Header) (ListBucketResult, error) {
+func (c *Client) listObjectsQuery(ctx context.Context, bucketName, objectPrefix, objectMarker, delimiter string, maxkeys int, headers http.Header, region, userAgent string) (ListBucketResult, error) {
 	// Validate bucket name.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return ListBucketResult{}, err
 	}
 	// Validate object prefix.
@@ -666,9 +900,11 @@ func (c *Client) listObjectsQuery(ctx context.Context, bucketName, objectPrefix,
 	// Execute GET on bucket to list objects.
 	resp, err := c.executeMethod(ctx, http.MethodGet, requestMetadata{
 		bucketName:       bucketName,
+		bucketLocation:   region,
 		queryValues:      urlValues,
 		contentSHA256Hex: emptySHA256Hex,
 		customHeader:     headers,
+		userAgent:        userAgent,
 	})
 	defer closeResponse(resp)
 	if err != nil {
@@ -735,6 +971,25 @@ type ListObjectsOptions struct {
 	// Use the deprecated list objects V1 API
 	UseV1 bool
 
+	// Minimal only lists the key, size, ETag, last modification time
+	// and storage class of the objects, without requesting their owner
+	// or metadata, to reduce the allocations of listing huge buckets.
+	// WithMetadata is ignored. It only applies to the default List
+	// Objects V2 listing, not with WithVersions or UseV1.
+	Minimal bool
+
+	// Region is the region the requests are signed for, bypassing
+	// the cached bucket location.
+	Region string
+
+	// UserAgent is a token appended to the User-Agent of the client
+	// for the list requests, e.g. "myapp/1.0".
+	UserAgent string
+
+	// RequestPayer sends "x-amz-request-payer: requester" with the
+	// list requests, to list Requester Pays buckets.
+	RequestPayer bool
+
 	headers http.Header
 }
 
@@ -748,6 +1003,19 @@ func (o *ListObjectsOptions) Set(key, value string) {
 	o.headers.Set(key, value)
 }
 
+// requestHeaders returns the headers of the list requests.
+func (o ListObjectsOptions) requestHeaders() http.Header {
+	if !o.RequestPayer {
+		return o.headers
+	}
+	headers := o.headers.Clone()
+	if headers == nil {
+		headers = make(http.Header)
+	}
+	headers.Set(amzRequestPayer, requestPayerRequester)
+	return headers
+}
+
 // ListObjects returns objects list after evaluating the passed options.
 //
 //	api := client.New(....)
@@ -828,6 +1096,52 @@ func (c *Client) ListObjectsIter(ctx context.Context, bucketName string, opts Li
 	return c.listObjectsV2(ctx, bucketName, opts)
 }
 
+// ListObjectVersionsOptions holds all options of a list object versions request.
+type ListObjectVersionsOptions struct {
+	// Only list versions of objects with the prefix
+	Prefix string
+	// Ignore '/' delimiter
+	Recursive bool
+	// Include objects metadata in the listing
+	WithMetadata bool
+	// The maximum number of versions requested per
+	// batch, advanced use-case not useful for most
+	// applications
+	MaxKeys int
+}
+
+// ListObjectVersionsIter returns all versions and delete markers of the
+// objects in the bucket, in the order returned by the server: keys
+// lexically and versions of a key from the newest to the oldest.
+// ObjectInfo.IsDeleteMarker and ObjectInfo.IsLatest are set for each of
+// them. When not recursive, common prefixes are returned as entries only
+// holding a Key.
+//
+// The listing stops after the first error.
+func (c *Client) ListObjectVersionsIter(ctx context.Context, bucketName string, opts ListObjectVersionsOptions) iter.Seq2[ObjectInfo, error] {
+	versions := c.listObjectVersions(ctx, bucketName, ListObjectsOptions{
+		WithVersions: true,
+		WithMetadata: opts.WithMetadata,
+		Prefix:       opts.Prefix,
+		Recursive:    opts.Recursive,
+		MaxKeys:      opts.MaxKeys,
+	})
+	return func(yield func(ObjectInfo, error) bool) {
+		for info := range versions {
+			if info.Err != nil {
+				yield(ObjectInfo{}, info.Err)
+				return
+			}
+			if !yield(info, nil) {
+				return
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			yield(ObjectInfo{}, err)
+		}
+	}
+}
+
 // ListIncompleteUploads - List incompletely uploaded multipart objects.
 //
 // ListIncompleteUploads lists all incompleted objects matching the
@@ -848,6 +1162,88 @@ func (c *Client) ListIncompleteUploads(ctx context.Context, bucketName, objectPr
 	return c.listIncompleteUploads(ctx, bucketName, objectPrefix, recursive)
 }
 
+// ListIncompleteUploadsIter - similar to ListIncompleteUploads() but
+// returns an iterator. Each incomplete upload is returned with its
+// UploadID, initiated time and the aggregate size of its uploaded parts.
+// With recursive set to false common prefixes are returned as entries
+// with only their Key set.
+//
+//	api := client.New(....)
+//	for upload, err := range api.ListIncompleteUploadsIter(context.Background(), "mytestbucket", "starthere", true) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(upload.Key, upload.UploadID, upload.Size)
+//	}
+func (c *Client) ListIncompleteUploadsIter(ctx context.Context, bucketName, objectPrefix string, recursive bool) iter.Seq2[ObjectMultipartInfo, error] {
+	return c.listIncompleteUploadsIter(ctx, bucketName, objectPrefix, recursive, true)
+}
+
+// listIncompleteUploadsIter lists all incomplete uploads, if aggregateSize
+// is set the parts of every upload are listed to compute its size.
+func (c *Client) listIncompleteUploadsIter(ctx context.Context, bucketName, objectPrefix string, recursive, aggregateSize bool) iter.Seq2[ObjectMultipartInfo, error] {
+	return func(yield func(ObjectMultipartInfo, error) bool) {
+		// Validate bucket name.
+		if err := c.checkBucketName(bucketName); err != nil {
+			yield(ObjectMultipartInfo{}, err)
+			return
+		}
+		// Validate incoming object prefix.
+		if err := s3utils.CheckValidObjectNamePrefix(objectPrefix); err != nil {
+			yield(ObjectMultipartInfo{}, err)
+			return
+		}
+
+		// Delimiter is set to "/" by default.
+		delimiter := "/"
+		if recursive {
+			// If recursive do not delimit.
+			delimiter = ""
+		}
+
+		// object and upload ID marker for future requests.
+		var objectMarker string
+		var uploadIDMarker string
+		for {
+			// list all multipart uploads.
+			result, err := c.listMultipartUploadsQuery(ctx, bucketName, objectMarker, uploadIDMarker, objectPrefix, delimiter, 0)
+			if err != nil {
+				yield(ObjectMultipartInfo{}, err)
+				return
+			}
+			objectMarker = result.NextKeyMarker
+			uploadIDMarker = result.NextUploadIDMarker
+
+			for _, obj := range result.Uploads {
+				if aggregateSize {
+					partsInfo, err := c.listObjectParts(ctx, bucketName, obj.Key, obj.UploadID)
+					if err != nil {
+						yield(ObjectMultipartInfo{}, err)
+						return
+					}
+					obj.Size = 0
+					for _, part := range partsInfo {
+						obj.Size += part.Size
+					}
+				}
+				if !yield(obj, nil) {
+					return
+				}
+			}
+			// NOTE: prefixes are only present if the request is delimited.
+			for _, obj := range result.CommonPrefixes {
+				if !yield(ObjectMultipartInfo{Key: obj.Prefix}, nil) {
+					return
+				}
+			}
+			// Listing ends if result not truncated, return right here.
+			if !result.IsTruncated {
+				return
+			}
+		}
+	}
+}
+
 // contextCanceled returns whether a context is canceled.
 func contextCanceled(ctx context.Context) bool {
 	select {
@@ -869,7 +1265,7 @@ func (c *Client) listIncompleteUploads(ctx context.Context, bucketName, objectPr
 		delimiter = ""
 	}
 	// Validate bucket name.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		defer close(objectMultipartStatCh)
 		objectMultipartStatCh <- ObjectMultipartInfo{
 			Err: err,
@@ -1031,8 +1427,6 @@ func (c *Client) listMultipartUploadsQuery(ctx context.Context, bucketName, keyM
 }
 
 // listObjectParts list all object parts recursively.
-//
-//lint:ignore U1000 Keep this around
 func (c *Client) listObjectParts(ctx context.Context, bucketName, objectName, uploadID string) (partsInfo map[int]ObjectPart, err error) {
 	// Part number marker for the next batch of request.
 	var nextPartNumberMarker int
@@ -1061,6 +1455,92 @@ func (c *Client) listObjectParts(ctx context.Context, bucketName, objectName, up
 	return partsInfo, nil
 }
 
+// ListPartsOptions holds all options of a list object parts request.
+type ListPartsOptions struct {
+	// Only list the parts after this part number
+	PartNumberMarker int
+	// The maximum number of parts requested per
+	// batch, up to 1000
+	MaxParts int
+}
+
+// ObjectPartsResult - all the parts uploaded so far for a multipart upload.
+type ObjectPartsResult struct {
+	Bucket   string
+	Key      string
+	UploadID string
+
+	StorageClass string
+
+	// ChecksumAlgorithm will be CRC32, CRC32C, etc.
+	ChecksumAlgorithm string
+
+	// ChecksumType is FULL_OBJECT or COMPOSITE (assume COMPOSITE when unset)
+	ChecksumType string
+
+	// Parts in ascending part number order.
+	Parts []ObjectPart
+}
+
+// ListObjectParts lists all the parts uploaded so far for the multipart
+// upload uploadID, along with their number, ETag, size and checksums.
+// This is useful to resume an interrupted upload, only the missing parts
+// need to be uploaded again.
+func (c *Client) ListObjectParts(ctx context.Context, bucketName, objectName, uploadID string, opts ListPartsOptions) (ObjectPartsResult, error) {
+	// Input validation.
+	if err := c.checkBucketName(bucketName); err != nil {
+		return ObjectPartsResult{}, err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return ObjectPartsResult{}, err
+	}
+	if uploadID == "" {
+		return ObjectPartsResult{}, errInvalidArgument("Upload ID cannot be empty.")
+	}
+	if opts.PartNumberMarker < 0 {
+		return ObjectPartsResult{}, errInvalidArgument("Part number marker cannot be negative.")
+	}
+	if opts.MaxParts < 0 || opts.MaxParts > 1000 {
+		return ObjectPartsResult{}, errInvalidArgument("Max parts must be between 0 and 1000.")
+	}
+	maxParts := opts.MaxParts
+	if maxParts == 0 {
+		maxParts = 1000
+	}
+
+	result := ObjectPartsResult{
+		Bucket:   bucketName,
+		Key:      objectName,
+		UploadID: uploadID,
+	}
+	partNumberMarker := opts.PartNumberMarker
+	for {
+		listObjPartsResult, err := c.listObjectPartsQuery(ctx, bucketName, objectName, uploadID, partNumberMarker, maxParts)
+		if err != nil {
+			return ObjectPartsResult{}, err
+		}
+		result.StorageClass = listObjPartsResult.StorageClass
+		result.ChecksumAlgorithm = listObjPartsResult.ChecksumAlgorithm
+		result.ChecksumType = listObjPartsResult.ChecksumType
+		for _, part := range listObjPartsResult.ObjectParts {
+			// Trim off the odd double quotes from ETag in the beginning and end.
+			part.ETag = trimEtag(part.ETag)
+			result.Parts = append(result.Parts, part)
+		}
+		if !listObjPartsResult.IsTruncated {
+			break
+		}
+		if listObjPartsResult.NextPartNumberMarker <= partNumberMarker {
+			return ObjectPartsResult{}, ErrorResponse{
+				Code:    NotImplemented,
+				Message: "Truncated response should advance the part number marker",
+			}
+		}
+		partNumberMarker = listObjPartsResult.NextPartNumberMarker
+	}
+	return result, nil
+}
+
 // findUploadIDs lists all incomplete uploads and find the uploadIDs of the matching object name.
 func (c *Client) findUploadIDs(ctx context.Context, bucketName, objectName string) ([]string, error) {
 	var uploadIDs []string
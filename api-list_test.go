@@ -0,0 +1,328 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openstor/openstor-go/v7/pkg/credentials"
+)
+
+func TestListIncompleteUploadsIter(t *testing.T) {
+	oldUpload := time.Now().UTC().Add(-48 * time.Hour).Format(time.RFC3339)
+	newUpload := time.Now().UTC().Format(time.RFC3339)
+
+	var mu sync.Mutex
+	var aborted []string
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.Method == http.MethodGet && query.Has("uploads"):
+			fmt.Fprintf(w, `<ListMultipartUploadsResult><Bucket>bucket</Bucket><IsTruncated>false</IsTruncated>`+
+				`<Upload><Key>old</Key><UploadId>upload-1</UploadId><Initiated>%s</Initiated></Upload>`+
+				`<Upload><Key>new</Key><UploadId>upload-2</UploadId><Initiated>%s</Initiated></Upload>`+
+				`</ListMultipartUploadsResult>`, oldUpload, newUpload)
+		case r.Method == http.MethodGet && query.Has("uploadId"):
+			fmt.Fprint(w, `<ListPartsResult><Bucket>bucket</Bucket><IsTruncated>false</IsTruncated>`+
+				`<Part><PartNumber>1</PartNumber><ETag>"etag1"</ETag><Size>5242880</Size></Part>`+
+				`<Part><PartNumber>2</PartNumber><ETag>"etag2"</ETag><Size>100</Size></Part>`+
+				`</ListPartsResult>`)
+		case r.Method == http.MethodDelete:
+			mu.Lock()
+			aborted = append(aborted, query.Get("uploadId"))
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+
+	var uploads []ObjectMultipartInfo
+	for upload, err := range clnt.ListIncompleteUploadsIter(context.Background(), "bucket", "", true) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		uploads = append(uploads, upload)
+	}
+	if len(uploads) != 2 {
+		t.Fatalf("expected 2 uploads, got %d", len(uploads))
+	}
+	for _, upload := range uploads {
+		if upload.UploadID == "" || upload.Initiated.IsZero() || upload.Size != 5242980 {
+			t.Fatalf("unexpected upload %+v", upload)
+		}
+	}
+
+	n, err := clnt.AbortIncompleteUploadsOlderThan(context.Background(), "bucket", "", 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || len(aborted) != 1 || aborted[0] != "upload-1" {
+		t.Fatalf("expected only upload-1 to be aborted, got %d %v", n, aborted)
+	}
+}
+
+func TestListObjectVersionsIter(t *testing.T) {
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		const header = `<ListVersionsResult><Name>bucket</Name>`
+		switch marker := query.Get("key-marker") + "/" + query.Get("version-id-marker"); marker {
+		case "/":
+			fmt.Fprint(w, header+`<IsTruncated>true</IsTruncated><NextKeyMarker>a</NextKeyMarker><NextVersionIdMarker>v2</NextVersionIdMarker>`+
+				`<DeleteMarker><Key>a</Key><VersionId>dm1</VersionId><IsLatest>true</IsLatest></DeleteMarker>`+
+				`<Version><Key>a</Key><VersionId>v2</VersionId><IsLatest>false</IsLatest></Version></ListVersionsResult>`)
+		case "a/v2":
+			fmt.Fprint(w, header+`<IsTruncated>true</IsTruncated><NextKeyMarker>b</NextKeyMarker>`+
+				`<Version><Key>a</Key><VersionId>v1</VersionId><IsLatest>false</IsLatest></Version>`+
+				`<Version><Key>b</Key><VersionId>v1</VersionId><IsLatest>true</IsLatest></Version></ListVersionsResult>`)
+		case "b/":
+			fmt.Fprint(w, header+`<IsTruncated>false</IsTruncated>`+
+				`<Version><Key>c</Key><VersionId>v1</VersionId><IsLatest>true</IsLatest></Version></ListVersionsResult>`)
+		default:
+			t.Errorf("unexpected markers %s", marker)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+
+	expected := []struct {
+		key, versionID         string
+		isLatest, deleteMarker bool
+	}{
+		{"a", "dm1", true, true},
+		{"a", "v2", false, false},
+		{"a", "v1", false, false},
+		{"b", "v1", true, false},
+		{"c", "v1", true, false},
+	}
+
+	i := 0
+	for info, err := range clnt.ListObjectVersionsIter(context.Background(), "bucket", ListObjectVersionsOptions{Recursive: true}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i >= len(expected) {
+			t.Fatalf("unexpected version %s (%s)", info.Key, info.VersionID)
+		}
+		e := expected[i]
+		if info.Key != e.key || info.VersionID != e.versionID || info.IsLatest != e.isLatest || info.IsDeleteMarker != e.deleteMarker {
+			t.Fatalf("Test %d: expected %+v, got %s (%s) latest=%t deleteMarker=%t", i+1, e, info.Key, info.VersionID, info.IsLatest, info.IsDeleteMarker)
+		}
+		i++
+	}
+	if i != len(expected) {
+		t.Fatalf("expected %d versions, got %d", len(expected), i)
+	}
+}
+
+func TestListObjectParts(t *testing.T) {
+	const totalParts = 5
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("uploadId") != "upload-1" {
+			t.Errorf("unexpected uploadId %q", query.Get("uploadId"))
+		}
+		var marker, maxParts int
+		fmt.Sscan(query.Get("part-number-marker"), &marker)
+		fmt.Sscan(query.Get("max-parts"), &maxParts)
+
+		last := min(marker+maxParts, totalParts)
+		var parts string
+		for i := marker + 1; i <= last; i++ {
+			parts += fmt.Sprintf(`<Part><PartNumber>%d</PartNumber><ETag>"etag-%d"</ETag><Size>%d</Size><ChecksumCRC32C>crc-%d</ChecksumCRC32C></Part>`, i, i, i*100, i)
+		}
+		fmt.Fprintf(w, `<ListPartsResult><Bucket>bucket</Bucket><Key>object</Key><UploadId>upload-1</UploadId><StorageClass>STANDARD</StorageClass><ChecksumAlgorithm>CRC32C</ChecksumAlgorithm><PartNumberMarker>%d</PartNumberMarker><NextPartNumberMarker>%d</NextPartNumberMarker><MaxParts>%d</MaxParts><IsTruncated>%t</IsTruncated>%s</ListPartsResult>`,
+			marker, last, maxParts, last < totalParts, parts)
+	}))
+
+	testCases := []struct {
+		opts  ListPartsOptions
+		first int
+	}{
+		{ListPartsOptions{}, 1},
+		{ListPartsOptions{MaxParts: 2}, 1},
+		{ListPartsOptions{PartNumberMarker: 2, MaxParts: 1}, 3},
+	}
+	for i, testCase := range testCases {
+		result, err := clnt.ListObjectParts(context.Background(), "bucket", "object", "upload-1", testCase.opts)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if result.UploadID != "upload-1" || result.StorageClass != "STANDARD" || result.ChecksumAlgorithm != "CRC32C" {
+			t.Fatalf("Test %d: unexpected result %+v", i+1, result)
+		}
+		if len(result.Parts) != totalParts-testCase.first+1 {
+			t.Fatalf("Test %d: expected %d parts, got %d", i+1, totalParts-testCase.first+1, len(result.Parts))
+		}
+		for j, part := range result.Parts {
+			n := testCase.first + j
+			if part.PartNumber != n || part.ETag != fmt.Sprintf("etag-%d", n) || part.Size != int64(n*100) || part.ChecksumCRC32C != fmt.Sprintf("crc-%d", n) {
+				t.Fatalf("Test %d: unexpected part %+v", i+1, part)
+			}
+		}
+	}
+
+	if _, err := clnt.ListObjectParts(context.Background(), "bucket", "object", "", ListPartsOptions{}); err == nil {
+		t.Fatal("expected empty upload ID to fail")
+	}
+	if _, err := clnt.ListObjectParts(context.Background(), "bucket", "object", "upload-1", ListPartsOptions{MaxParts: 1001}); err == nil {
+		t.Fatal("expected max parts above 1000 to fail")
+	}
+}
+
+func TestListBucketsWithOpts(t *testing.T) {
+	pages := map[string]string{
+		"": `<ListAllMyBucketsResult><Buckets>` +
+			`<Bucket><Name>logs-east</Name><CreationDate>2025-01-01T00:00:00.000Z</CreationDate><BucketRegion>us-east-1</BucketRegion></Bucket>` +
+			`<Bucket><Name>logs-west</Name><CreationDate>2025-01-02T00:00:00.000Z</CreationDate><BucketRegion>us-west-2</BucketRegion></Bucket>` +
+			`</Buckets><ContinuationToken>page-2</ContinuationToken></ListAllMyBucketsResult>`,
+		"page-2": `<ListAllMyBucketsResult><Buckets>` +
+			`<Bucket><Name>logs-archive</Name><CreationDate>2025-01-03T00:00:00.000Z</CreationDate><BucketRegion>us-east-1</BucketRegion></Bucket>` +
+			`<Bucket><Name>data</Name><CreationDate>2025-01-04T00:00:00.000Z</CreationDate><BucketRegion>us-east-1</BucketRegion></Bucket>` +
+			`</Buckets></ListAllMyBucketsResult>`,
+	}
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("max-buckets") != "2" || query.Get("bucket-region") != "us-east-1" || query.Get("prefix") != "logs-" {
+			t.Errorf("unexpected list buckets query %s", r.URL.RawQuery)
+		}
+		// The filters are ignored, as by servers not supporting them.
+		fmt.Fprint(w, pages[query.Get("continuation-token")])
+	}))
+
+	if _, err := clnt.ListBucketsWithOpts(context.Background(), ListBucketsOptions{MaxBuckets: 10001}); err == nil {
+		t.Fatal("expected an invalid MaxBuckets to fail")
+	}
+
+	buckets, err := clnt.ListBucketsWithOpts(context.Background(), ListBucketsOptions{
+		BucketRegion: "us-east-1",
+		Prefix:       "logs-",
+		MaxBuckets:   2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for bucket, err := range buckets {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bucket.BucketRegion != "us-east-1" || bucket.CreationDate.IsZero() {
+			t.Fatalf("unexpected bucket %+v", bucket)
+		}
+		names = append(names, bucket.Name)
+	}
+	if len(names) != 2 || names[0] != "logs-east" || names[1] != "logs-archive" {
+		t.Fatalf("unexpected buckets %v", names)
+	}
+}
+
+func TestListObjectsMinimal(t *testing.T) {
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Has("fetch-owner") || query.Has("metadata") {
+			t.Errorf("expected neither the owner nor the metadata to be requested, got %s", r.URL.RawQuery)
+		}
+		if query.Get("continuation-token") == "" {
+			fmt.Fprint(w, `<ListBucketResult><Name>bucket</Name><IsTruncated>true</IsTruncated><NextContinuationToken>token</NextContinuationToken>`+
+				`<Contents><Key>a%2Bb</Key><LastModified>2025-01-02T03:04:05.678Z</LastModified><ETag>"etag1"</ETag><Size>10</Size><StorageClass>COLD</StorageClass>`+
+				`<Owner><ID>owner</ID></Owner><UserMetadata><X-Amz-Meta-Key>value</X-Amz-Meta-Key></UserMetadata></Contents>`+
+				`<Contents><Key>b</Key><Size>20</Size><StorageClass>STANDARD</StorageClass></Contents>`+
+				`<EncodingType>url</EncodingType></ListBucketResult>`)
+			return
+		}
+		fmt.Fprint(w, `<ListBucketResult><Name>bucket</Name><IsTruncated>false</IsTruncated>`+
+			`<Contents><Key>c</Key><Size>30</Size></Contents>`+
+			`<CommonPrefixes><Prefix>d%2F</Prefix></CommonPrefixes>`+
+			`<EncodingType>url</EncodingType></ListBucketResult>`)
+	}))
+
+	var objects []ObjectInfo
+	for object := range clnt.ListObjectsIter(context.Background(), "bucket", ListObjectsOptions{Minimal: true, WithMetadata: true}) {
+		if object.Err != nil {
+			t.Fatal(object.Err)
+		}
+		objects = append(objects, object)
+	}
+	expected := []ObjectInfo{
+		{Key: "a+b", LastModified: time.Date(2025, time.January, 2, 3, 4, 5, 678000000, time.UTC), ETag: "etag1", Size: 10, StorageClass: "COLD"},
+		{Key: "b", Size: 20, StorageClass: "STANDARD"},
+		// Fields of the previous page are not kept.
+		{Key: "c", Size: 30},
+		{Key: "d/"},
+	}
+	if len(objects) != len(expected) {
+		t.Fatalf("expected %d objects, got %+v", len(expected), objects)
+	}
+	for i, object := range objects {
+		e := expected[i]
+		if object.Key != e.Key || !object.LastModified.Equal(e.LastModified) || object.ETag != e.ETag || object.Size != e.Size ||
+			object.StorageClass != e.StorageClass || object.Owner != (Owner{}) || object.UserMetadata != nil {
+			t.Fatalf("Test %d: expected %+v, got %+v", i+1, e, object)
+		}
+	}
+}
+
+func BenchmarkListObjects(b *testing.B) {
+	var page strings.Builder
+	page.WriteString(`<ListBucketResult><Name>bucket</Name><IsTruncated>false</IsTruncated>`)
+	for i := range 1000 {
+		fmt.Fprintf(&page, `<Contents><Key>prefix/object-%d</Key><LastModified>2025-01-02T03:04:05.678Z</LastModified>`+
+			`<ETag>"d41d8cd98f00b204e9800998ecf8427e"</ETag><Size>%d</Size><StorageClass>STANDARD</StorageClass>`+
+			`<Owner><ID>02d6176db174dc93cb1b899f7c6078f08654445fe8cf1b6ce98d8855f66bdbf4</ID><DisplayName>owner</DisplayName></Owner></Contents>`, i, i)
+	}
+	page.WriteString(`<EncodingType>url</EncodingType></ListBucketResult>`)
+	body := page.String()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, body)
+	}))
+	defer srv.Close()
+
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:  credentials.NewStaticV4("accessKey", "secretKey", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, minimal := range []bool{false, true} {
+		b.Run(fmt.Sprintf("minimal=%t", minimal), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				for object := range clnt.ListObjectsIter(context.Background(), "bucket", ListObjectsOptions{Recursive: true, Minimal: minimal}) {
+					if object.Err != nil {
+						b.Fatal(object.Err)
+					}
+				}
+			}
+		})
+	}
+}
@@ -95,7 +95,7 @@ func newObjectLegalHold(status *LegalHoldStatus) (*objectLegalHold, error) {
 // Returns an error if the operation fails or if the status is invalid.
 func (c *Client) PutObjectLegalHold(ctx context.Context, bucketName, objectName string, opts PutObjectLegalHoldOptions) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 
@@ -157,7 +157,7 @@ func (c *Client) PutObjectLegalHold(ctx context.Context, bucketName, objectName
 // Returns the legal hold status (LegalHoldEnabled or LegalHoldDisabled) or an error if the operation fails.
 func (c *Client) GetObjectLegalHold(ctx context.Context, bucketName, objectName string, opts GetObjectLegalHoldOptions) (status *LegalHoldStatus, err error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return nil, err
 	}
 
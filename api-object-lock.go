@@ -23,13 +23,13 @@ package openstor
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
-
-	"github.com/openstor/openstor-go/v7/pkg/s3utils"
 )
 
 // RetentionMode - object retention mode.
@@ -52,6 +52,59 @@ func (r RetentionMode) IsValid() bool {
 	return r == Governance || r == Compliance
 }
 
+// MarshalText encodes the retention mode, an empty mode is encoded
+// as is while any other invalid mode is rejected.
+func (r RetentionMode) MarshalText() ([]byte, error) {
+	if r != "" && !r.IsValid() {
+		return nil, fmt.Errorf("invalid retention mode `%v`", string(r))
+	}
+	return []byte(r), nil
+}
+
+// UnmarshalText decodes the retention mode case-insensitively, an
+// empty mode is decoded as is while any other invalid mode is rejected.
+func (r *RetentionMode) UnmarshalText(text []byte) error {
+	mode := RetentionMode(strings.ToUpper(string(text)))
+	if mode != "" && !mode.IsValid() {
+		return fmt.Errorf("invalid retention mode `%v`", string(text))
+	}
+	*r = mode
+	return nil
+}
+
+// UnmarshalXML decodes the retention mode as received, server
+// responses are not validated so that GetObjectRetention and
+// GetObjectLockConfig keep reporting modes this client does not know.
+func (r *RetentionMode) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var text string
+	if err := d.DecodeElement(&text, &start); err != nil {
+		return err
+	}
+	*r = RetentionMode(text)
+	return nil
+}
+
+// MarshalJSON encodes the retention mode as a JSON string.
+func (r RetentionMode) MarshalJSON() ([]byte, error) {
+	text, err := r.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decodes the retention mode from a JSON string.
+func (r *RetentionMode) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return r.UnmarshalText([]byte(text))
+}
+
 // ValidityUnit - retention validity unit.
 type ValidityUnit string
 
@@ -109,11 +162,15 @@ func newObjectLockConfig(mode *RetentionMode, validity *uint, unit *ValidityUnit
 
 	if mode != nil && validity != nil && unit != nil {
 		if !mode.IsValid() {
-			return nil, fmt.Errorf("invalid retention mode `%v`", mode)
+			return nil, fmt.Errorf("invalid retention mode `%v`", *mode)
 		}
 
 		if !unit.isValid() {
-			return nil, fmt.Errorf("invalid validity unit `%v`", unit)
+			return nil, fmt.Errorf("invalid validity unit `%v`", *unit)
+		}
+
+		if *validity == 0 {
+			return nil, fmt.Errorf("invalid validity `%d`, must be greater than zero", *validity)
 		}
 
 		config.Rule = &struct {
@@ -138,13 +195,19 @@ func newObjectLockConfig(mode *RetentionMode, validity *uint, unit *ValidityUnit
 		return config, nil
 	}
 
+	if (validity == nil) != (unit == nil) {
+		return nil, fmt.Errorf("validity and validity unit must be passed together")
+	}
+
 	return nil, fmt.Errorf("all of retention mode, validity and validity unit must be passed")
 }
 
 // SetBucketObjectLockConfig sets object lock configuration in given bucket. mode, validity and unit are either all set or all nil.
+// The default retention is expressed in Days or Years depending on unit, and validity must be non-zero.
+// The bucket must have been created with object lock enabled, otherwise the server error is returned as is.
 func (c *Client) SetBucketObjectLockConfig(ctx context.Context, bucketName string, mode *RetentionMode, validity *uint, unit *ValidityUnit) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 
@@ -189,7 +252,7 @@ func (c *Client) SetBucketObjectLockConfig(ctx context.Context, bucketName strin
 // GetObjectLockConfig gets object lock configuration of given bucket.
 func (c *Client) GetObjectLockConfig(ctx context.Context, bucketName string) (objectLock string, mode *RetentionMode, validity *uint, unit *ValidityUnit, err error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return "", nil, nil, nil, err
 	}
 
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+)
+
+func TestNewObjectLockConfig(t *testing.T) {
+	governance := Governance
+	invalidMode := RetentionMode("INVALID")
+	days := Days
+	years := Years
+	invalidUnit := ValidityUnit("WEEKS")
+	zero := uint(0)
+	thirty := uint(30)
+
+	testCases := []struct {
+		mode     *RetentionMode
+		validity *uint
+		unit     *ValidityUnit
+		expected string
+		success  bool
+	}{
+		{nil, nil, nil, `<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`, true},
+		{&governance, &thirty, &days, `<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Days>30</Days></DefaultRetention></Rule></ObjectLockConfiguration>`, true},
+		{&governance, &thirty, &years, `<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Years>30</Years></DefaultRetention></Rule></ObjectLockConfiguration>`, true},
+		{&governance, &zero, &days, "", false},
+		{&invalidMode, &thirty, &days, "", false},
+		{&governance, &thirty, &invalidUnit, "", false},
+		{&governance, &thirty, nil, "", false},
+		{&governance, nil, &days, "", false},
+		{nil, &thirty, &days, "", false},
+	}
+
+	for i, testCase := range testCases {
+		config, err := newObjectLockConfig(testCase.mode, testCase.validity, testCase.unit)
+		if err != nil && testCase.success {
+			t.Fatalf("Test %d: expected success, got %v", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Fatalf("Test %d: expected failure, got success", i+1)
+		}
+		if !testCase.success {
+			continue
+		}
+		data, err := xml.Marshal(config)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if string(data) != testCase.expected {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.expected, string(data))
+		}
+	}
+}
+
+func TestRetentionModeJSON(t *testing.T) {
+	type config struct {
+		Mode RetentionMode `json:"mode"`
+	}
+
+	testCases := []struct {
+		json    string
+		mode    RetentionMode
+		success bool
+	}{
+		{`{"mode":"GOVERNANCE"}`, Governance, true},
+		{`{"mode":"COMPLIANCE"}`, Compliance, true},
+		{`{"mode":"compliance"}`, Compliance, true},
+		{`{"mode":""}`, "", true},
+		{`{"mode":null}`, "", true},
+		{`{"mode":"LEGAL"}`, "", false},
+		{`{"mode":1}`, "", false},
+	}
+
+	for i, testCase := range testCases {
+		var c config
+		err := json.Unmarshal([]byte(testCase.json), &c)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %t, got error %v", i+1, testCase.success, err)
+		}
+		if !testCase.success {
+			continue
+		}
+		if c.Mode != testCase.mode {
+			t.Fatalf("Test %d: expected mode %q, got %q", i+1, testCase.mode, c.Mode)
+		}
+		data, err := json.Marshal(c)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if expected := `{"mode":"` + string(testCase.mode) + `"}`; string(data) != expected {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, expected, data)
+		}
+	}
+
+	if _, err := json.Marshal(config{Mode: RetentionMode("LEGAL")}); err == nil {
+		t.Fatal("expected an invalid mode to be rejected")
+	}
+	if text, err := Governance.MarshalText(); err != nil || string(text) != "GOVERNANCE" {
+		t.Fatalf("unexpected text %q, %v", text, err)
+	}
+}
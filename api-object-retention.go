@@ -41,15 +41,27 @@ type objectRetention struct {
 	RetainUntilDate *time.Time    `type:"timestamp" timestampFormat:"iso8601" xml:"RetainUntilDate,omitempty"`
 }
 
+// newObjectRetention - returns the retention to be sent, only the fields
+// which are set are marshaled: a nil mode only sends the date and a nil or
+// zero date only sends the mode. Neither set clears the retention. Dates
+// beyond the year 9999, or in the past along with a mode, are rejected
+// with errors matching ErrInvalidRetentionDate.
 func newObjectRetention(mode *RetentionMode, date *time.Time) (*objectRetention, error) {
 	objectRetention := &objectRetention{}
 
 	if date != nil && !date.IsZero() {
-		objectRetention.RetainUntilDate = date
+		utc := date.UTC()
+		if utc.Year() > 9999 {
+			return nil, errInvalidArgumentMatching(ErrInvalidRetentionDate, fmt.Sprintf("Retain until date must be before the year 10000, got %s.", utc.Format(time.RFC3339)))
+		}
+		if now := time.Now().UTC(); mode != nil && !utc.After(now) {
+			return nil, errInvalidArgumentMatching(ErrInvalidRetentionDate, fmt.Sprintf("Retain until date must be in the future, got %s at %s.", utc.Format(time.RFC3339), now.Format(time.RFC3339)))
+		}
+		objectRetention.RetainUntilDate = &utc
 	}
 	if mode != nil {
 		if !mode.IsValid() {
-			return nil, fmt.Errorf("invalid retention mode `%v`", mode)
+			return nil, fmt.Errorf("invalid retention mode `%v`", *mode)
 		}
 		objectRetention.Mode = *mode
 	}
@@ -60,9 +72,13 @@ func newObjectRetention(mode *RetentionMode, date *time.Time) (*objectRetention,
 // PutObjectRetentionOptions represents options specified by user for PutObject call
 type PutObjectRetentionOptions struct {
 	GovernanceBypass bool
-	Mode             *RetentionMode
-	RetainUntilDate  *time.Time
-	VersionID        string
+	// Mode is only sent if set, such that RetainUntilDate can be updated
+	// alone. Some servers require both Mode and RetainUntilDate.
+	Mode *RetentionMode
+	// RetainUntilDate is only sent if set and not zero, such that Mode can
+	// be updated alone. It is sent in UTC.
+	RetainUntilDate *time.Time
+	VersionID       string
 }
 
 // PutObjectRetention sets the retention configuration for an object and specific version.
@@ -77,7 +93,7 @@ type PutObjectRetentionOptions struct {
 // Returns an error if the operation fails or if the retention settings are invalid.
 func (c *Client) PutObjectRetention(ctx context.Context, bucketName, objectName string, opts PutObjectRetentionOptions) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 
@@ -148,7 +164,7 @@ func (c *Client) PutObjectRetention(ctx context.Context, bucketName, objectName
 // Returns the retention mode (GOVERNANCE or COMPLIANCE), retain-until date, and any error.
 func (c *Client) GetObjectRetention(ctx context.Context, bucketName, objectName, versionID string) (mode *RetentionMode, retainUntilDate *time.Time, err error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return nil, nil, err
 	}
 
@@ -183,3 +199,48 @@ func (c *Client) GetObjectRetention(ctx context.Context, bucketName, objectName,
 
 	return &retention.Mode, retention.RetainUntilDate, nil
 }
+
+// ObjectRetentionInfo is the retention of an object version, see
+// GetObjectRetentionInfo.
+type ObjectRetentionInfo struct {
+	// Mode and RetainUntilDate are empty if the version has no retention.
+	Mode            RetentionMode
+	RetainUntilDate time.Time
+
+	// Remaining is how long the version remained retained when its
+	// retention was fetched, see RemainingUntil.
+	Remaining time.Duration
+}
+
+// RemainingUntil returns how long the version remains retained at now,
+// zero if it has no retention or its retention expired.
+func (r ObjectRetentionInfo) RemainingUntil(now time.Time) time.Duration {
+	if r.RetainUntilDate.IsZero() {
+		return 0
+	}
+	return max(r.RetainUntilDate.Sub(now), 0)
+}
+
+// GetObjectRetentionInfo retrieves the retention of an object version
+// like GetObjectRetention, along with the time it remains retained.
+// Versions without retention are returned with an empty
+// ObjectRetentionInfo rather than an error.
+func (c *Client) GetObjectRetentionInfo(ctx context.Context, bucketName, objectName, versionID string) (ObjectRetentionInfo, error) {
+	mode, retainUntilDate, err := c.GetObjectRetention(ctx, bucketName, objectName, versionID)
+	if err != nil {
+		if ToErrorResponse(err).Code == noSuchObjectLockConfiguration {
+			return ObjectRetentionInfo{}, nil
+		}
+		return ObjectRetentionInfo{}, err
+	}
+
+	var info ObjectRetentionInfo
+	if mode != nil {
+		info.Mode = *mode
+	}
+	if retainUntilDate != nil {
+		info.RetainUntilDate = *retainUntilDate
+	}
+	info.Remaining = info.RemainingUntil(time.Now())
+	return info, nil
+}
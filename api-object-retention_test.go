@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewObjectRetention(t *testing.T) {
+	governance := Governance
+	invalid := RetentionMode("INVALID")
+	date := time.Date(2099, time.January, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+	zero := time.Time{}
+
+	testCases := []struct {
+		mode     *RetentionMode
+		date     *time.Time
+		expected string
+		success  bool
+	}{
+		{&governance, &date, "<Retention><Mode>GOVERNANCE</Mode><RetainUntilDate>2099-01-02T02:04:05Z</RetainUntilDate></Retention>", true},
+		// Only the date is sent without a mode.
+		{nil, &date, "<Retention><RetainUntilDate>2099-01-02T02:04:05Z</RetainUntilDate></Retention>", true},
+		// Only the mode is sent without a date.
+		{&governance, nil, "<Retention><Mode>GOVERNANCE</Mode></Retention>", true},
+		{&governance, &zero, "<Retention><Mode>GOVERNANCE</Mode></Retention>", true},
+		// Neither clears the retention.
+		{nil, nil, "<Retention></Retention>", true},
+		{&invalid, &date, "", false},
+	}
+
+	for i, testCase := range testCases {
+		retention, err := newObjectRetention(testCase.mode, testCase.date)
+		if err != nil && testCase.success {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Fatalf("Test %d: expected an error", i+1)
+		}
+		if !testCase.success {
+			continue
+		}
+		data, err := xml.Marshal(retention)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if string(data) != testCase.expected {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.expected, data)
+		}
+	}
+
+	// Past dates are rejected along with a mode, when locking an object,
+	// and dates beyond the year 9999 always.
+	past := time.Now().Add(-time.Hour)
+	distant := time.Date(10000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for i, testCase := range []struct {
+		mode *RetentionMode
+		date *time.Time
+	}{
+		{&governance, &past},
+		{&governance, &distant},
+		{nil, &distant},
+	} {
+		_, err := newObjectRetention(testCase.mode, testCase.date)
+		if !errors.Is(err, ErrInvalidRetentionDate) || ToErrorResponse(err).Code != InvalidArgument {
+			t.Fatalf("Test %d: expected ErrInvalidRetentionDate, got %v", i+1, err)
+		}
+	}
+	if _, err := newObjectRetention(nil, &past); err != nil {
+		t.Fatalf("expected a past date without a mode to be sent, got %v", err)
+	}
+}
+
+func TestObjectRetentionRemainingUntil(t *testing.T) {
+	now := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		retention ObjectRetentionInfo
+		expected  time.Duration
+	}{
+		{ObjectRetentionInfo{Mode: Governance, RetainUntilDate: now.Add(36 * time.Hour)}, 36 * time.Hour},
+		// Expired retention is clamped to zero.
+		{ObjectRetentionInfo{Mode: Compliance, RetainUntilDate: now.Add(-time.Hour)}, 0},
+		{ObjectRetentionInfo{Mode: Compliance, RetainUntilDate: now}, 0},
+		// No retention.
+		{ObjectRetentionInfo{}, 0},
+	}
+	for i, testCase := range testCases {
+		if got := testCase.retention.RemainingUntil(now); got != testCase.expected {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestGetObjectRetentionInfo(t *testing.T) {
+	retainUntil := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("versionId") {
+		case "retained":
+			fmt.Fprintf(w, `<Retention><Mode>GOVERNANCE</Mode><RetainUntilDate>%s</RetainUntilDate></Retention>`, retainUntil.Format(time.RFC3339))
+		case "unretained":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<Error><Code>NoSuchObjectLockConfiguration</Code><Message>The specified object does not have a ObjectLock configuration</Message></Error>`)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`)
+		}
+	}))
+	ctx := context.Background()
+
+	info, err := clnt.GetObjectRetentionInfo(ctx, "bucket", "object", "retained")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode != Governance || !info.RetainUntilDate.Equal(retainUntil) {
+		t.Fatalf("unexpected retention %+v", info)
+	}
+	if info.Remaining <= 47*time.Hour || info.Remaining > 48*time.Hour {
+		t.Fatalf("expected about 48h of retention remaining, got %v", info.Remaining)
+	}
+
+	if info, err = clnt.GetObjectRetentionInfo(ctx, "bucket", "object", "unretained"); err != nil || info != (ObjectRetentionInfo{}) {
+		t.Fatalf("expected no retention, got %+v, %v", info, err)
+	}
+	if _, err = clnt.GetObjectRetentionInfo(ctx, "bucket", "object", "denied"); ToErrorResponse(err).Code != AccessDenied {
+		t.Fatalf("expected AccessDenied, got %v", err)
+	}
+}
+
+func TestGetObjectRetentionLenientMode(t *testing.T) {
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `<Retention><Mode>%s</Mode><RetainUntilDate>2030-01-01T00:00:00Z</RetainUntilDate></Retention>`, r.URL.Query().Get("versionId"))
+	}))
+
+	// Modes are reported as received, even those rejected by
+	// UnmarshalText and UnmarshalJSON.
+	for i, mode := range []string{"COMPLIANCE", "governance", "LEGAL"} {
+		got, _, err := clnt.GetObjectRetention(context.Background(), "bucket", "object", mode)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if string(*got) != mode {
+			t.Fatalf("Test %d: expected mode %q, got %q", i+1, mode, *got)
+		}
+	}
+}
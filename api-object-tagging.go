@@ -26,8 +26,8 @@ import (
 	"encoding/xml"
 	"net/http"
 	"net/url"
+	"sync"
 
-	"github.com/openstor/openstor-go/v7/pkg/s3utils"
 	"github.com/openstor/openstor-go/v7/pkg/tags"
 )
 
@@ -56,7 +56,7 @@ type AdvancedObjectTaggingOptions struct {
 // Returns an error if the operation fails.
 func (c *Client) PutObjectTagging(ctx context.Context, bucketName, objectName string, otags *tags.Tags, opts PutObjectTaggingOptions) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 
@@ -101,6 +101,91 @@ func (c *Client) PutObjectTagging(ctx context.Context, bucketName, objectName st
 	return nil
 }
 
+// defaultTaggingBatchConcurrency is the number of objects
+// PutObjectTaggingBatch tags concurrently by default.
+const defaultTaggingBatchConcurrency = 4
+
+// ObjectTagEntry is an object, or object version, to tag with
+// PutObjectTaggingBatch.
+type ObjectTagEntry struct {
+	ObjectName string
+	// VersionID of the object, the latest version when empty.
+	VersionID string
+	// Tags replace the tags of the object.
+	Tags *tags.Tags
+}
+
+// BatchTaggingOptions holds the options of a PutObjectTaggingBatch call.
+type BatchTaggingOptions struct {
+	// Concurrency is the number of objects tagged concurrently, 4 if
+	// not set.
+	Concurrency int
+}
+
+// TaggingResult is the result of PutObjectTaggingBatch for one entry.
+type TaggingResult struct {
+	ObjectName string
+	VersionID  string
+	Err        error
+}
+
+// PutObjectTaggingBatch replaces the tags of the objects of the entries,
+// tagging opts.Concurrency of them at a time. One result is sent for
+// every entry, in the order the requests complete, with the error of
+// the entry if any. Entries without Tags are rejected without any
+// request, tags are removed with RemoveObjectTagging.
+//
+// If the context is canceled the entries not tagged yet are skipped,
+// their results carry the context error. The caller must drain the
+// channel until it is closed, otherwise goroutines are leaked.
+func (c *Client) PutObjectTaggingBatch(ctx context.Context, bucketName string, entries []ObjectTagEntry, opts BatchTaggingOptions) <-chan TaggingResult {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultTaggingBatchConcurrency
+	}
+
+	resultCh := make(chan TaggingResult, concurrency)
+	go func() {
+		defer close(resultCh)
+
+		entryCh := make(chan ObjectTagEntry)
+		var wg sync.WaitGroup
+		for range min(concurrency, len(entries)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for entry := range entryCh {
+					if entry.Tags == nil {
+						resultCh <- TaggingResult{ObjectName: entry.ObjectName, VersionID: entry.VersionID, Err: errInvalidArgument("Tags must be set, use RemoveObjectTagging to remove them.")}
+						continue
+					}
+					err := c.PutObjectTagging(ctx, bucketName, entry.ObjectName, entry.Tags, PutObjectTaggingOptions{
+						VersionID: entry.VersionID,
+					})
+					resultCh <- TaggingResult{ObjectName: entry.ObjectName, VersionID: entry.VersionID, Err: err}
+				}
+			}()
+		}
+
+		fed := 0
+	feed:
+		for _, entry := range entries {
+			select {
+			case entryCh <- entry:
+				fed++
+			case <-ctx.Done():
+				break feed
+			}
+		}
+		close(entryCh)
+		for _, entry := range entries[fed:] {
+			resultCh <- TaggingResult{ObjectName: entry.ObjectName, VersionID: entry.VersionID, Err: ctx.Err()}
+		}
+		wg.Wait()
+	}()
+	return resultCh
+}
+
 // GetObjectTaggingOptions holds the object version ID
 // to fetch the tagging key/value pairs
 type GetObjectTaggingOptions struct {
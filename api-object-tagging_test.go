@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openstor/openstor-go/v7/pkg/tags"
+)
+
+func TestPutObjectTaggingBatch(t *testing.T) {
+	var (
+		mu                    sync.Mutex
+		tagged                = make(map[string]string)
+		inflight, maxInflight int32
+	)
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInflight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInflight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+		if strings.HasPrefix(key, "denied") {
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, `<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		tagged[key+"@"+r.URL.Query().Get("versionId")] = string(body)
+		mu.Unlock()
+	}))
+
+	otags, err := tags.NewTags(map[string]string{"class": "public"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries []ObjectTagEntry
+	for i := range 20 {
+		entries = append(entries, ObjectTagEntry{ObjectName: "object-" + strconv.Itoa(i), VersionID: "v" + strconv.Itoa(i), Tags: otags})
+	}
+	entries = append(entries, ObjectTagEntry{ObjectName: "denied-1", Tags: otags}, ObjectTagEntry{ObjectName: "untagged"})
+
+	results := make(map[string]TaggingResult)
+	for res := range clnt.PutObjectTaggingBatch(context.Background(), "bucket", entries, BatchTaggingOptions{Concurrency: 3}) {
+		results[res.ObjectName] = res
+	}
+	if len(results) != len(entries) {
+		t.Fatalf("expected %d results, got %d", len(entries), len(results))
+	}
+	for i, entry := range entries {
+		res := results[entry.ObjectName]
+		if res.VersionID != entry.VersionID {
+			t.Fatalf("Test %d: unexpected result %+v", i+1, res)
+		}
+		if strings.HasPrefix(entry.ObjectName, "denied") {
+			if ToErrorResponse(res.Err).Code != AccessDenied {
+				t.Fatalf("Test %d: expected %s to be denied, got %+v", i+1, entry.ObjectName, res)
+			}
+			continue
+		}
+		if entry.Tags == nil {
+			if _, ok := tagged[entry.ObjectName+"@"]; ok || ToErrorResponse(res.Err).Code != InvalidArgument {
+				t.Fatalf("Test %d: expected %s to be rejected without a request, got %+v", i+1, entry.ObjectName, res)
+			}
+			continue
+		}
+		if res.Err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, res.Err)
+		}
+		if body := tagged[entry.ObjectName+"@"+entry.VersionID]; !strings.Contains(body, "<Key>class</Key><Value>public</Value>") {
+			t.Fatalf("Test %d: unexpected tagging %q", i+1, body)
+		}
+	}
+	if maxInflight > 3 {
+		t.Fatalf("expected at most 3 concurrent requests, got %d", maxInflight)
+	}
+
+	// Entries are skipped once the context is canceled, still with
+	// one result each.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var n int
+	for res := range clnt.PutObjectTaggingBatch(ctx, "bucket", entries, BatchTaggingOptions{}) {
+		if res.ObjectName != "untagged" && !errors.Is(res.Err, context.Canceled) {
+			t.Fatalf("expected the context error, got %v", res.Err)
+		}
+		n++
+	}
+	if n != len(entries) {
+		t.Fatalf("expected %d results, got %d", len(entries), n)
+	}
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"time"
+)
+
+// Default delays used by WaitUntilObjectExists and WaitUntilObjectNotExists.
+const (
+	defaultWaitMinDelay = 100 * time.Millisecond
+	defaultWaitMaxDelay = 5 * time.Second
+)
+
+// WaitOptions represents options specified by user for
+// WaitUntilObjectExists and WaitUntilObjectNotExists calls.
+type WaitOptions struct {
+	// MinDelay is the delay before the first retry, it is doubled
+	// after every attempt up to MaxDelay. Defaults to 100ms.
+	MinDelay time.Duration
+	// MaxDelay caps the delay between two attempts. Defaults to 5s.
+	MaxDelay time.Duration
+
+	// StatOptions are passed to every StatObject attempt, for
+	// example to wait for a specific version of an object.
+	StatOptions StatObjectOptions
+}
+
+// isObjectNotFound returns true if the error indicates that the object
+// (or its version) does not exist yet.
+func isObjectNotFound(err error) bool {
+	switch ToErrorResponse(err).Code {
+	case NoSuchKey, NoSuchVersion:
+		return true
+	}
+	return false
+}
+
+// waitForObject polls StatObject until done returns true, the context is
+// canceled or deadlined. Delays between attempts grow exponentially.
+func (c *Client) waitForObject(ctx context.Context, bucketName, objectName string, opts WaitOptions, done func(ObjectInfo, error) (bool, error)) (ObjectInfo, error) {
+	delay := opts.MinDelay
+	if delay <= 0 {
+		delay = defaultWaitMinDelay
+	}
+	maxDelay := opts.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultWaitMaxDelay
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ObjectInfo{}, ctx.Err()
+		case <-timer.C:
+		}
+
+		objInfo, err := c.StatObject(ctx, bucketName, objectName, opts.StatOptions)
+		if err != nil && ctx.Err() != nil {
+			// Deadline hit while the request was in flight.
+			return ObjectInfo{}, ctx.Err()
+		}
+		ok, err := done(objInfo, err)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		if ok {
+			return objInfo, nil
+		}
+
+		timer.Reset(delay)
+		delay *= 2
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+}
+
+// WaitUntilObjectExists polls the object with StatObject, backing off
+// exponentially, until it exists or the context is canceled. Errors other
+// than a missing object are returned right away.
+func (c *Client) WaitUntilObjectExists(ctx context.Context, bucketName, objectName string, opts WaitOptions) (ObjectInfo, error) {
+	return c.waitForObject(ctx, bucketName, objectName, opts, func(_ ObjectInfo, err error) (bool, error) {
+		if err != nil {
+			if isObjectNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	})
+}
+
+// WaitUntilObjectNotExists polls the object with StatObject, backing off
+// exponentially, until it is no longer found or the context is canceled.
+// Errors other than a missing object are returned right away.
+func (c *Client) WaitUntilObjectNotExists(ctx context.Context, bucketName, objectName string, opts WaitOptions) error {
+	_, err := c.waitForObject(ctx, bucketName, objectName, opts, func(_ ObjectInfo, err error) (bool, error) {
+		if err != nil {
+			if isObjectNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		return false, nil
+	})
+	return err
+}
@@ -25,6 +25,7 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/openstor/openstor-go/v7/pkg/s3utils"
@@ -33,17 +34,24 @@ import (
 
 // presignURL - Returns a presigned URL for an input 'method'.
 // Expires maximum is 7days - ie. 604800 and minimum is 1.
-func (c *Client) presignURL(ctx context.Context, method, bucketName, objectName string, expires time.Duration, reqParams url.Values, extraHeaders http.Header) (u *url.URL, err error) {
+// If host is not empty the URL is signed for that host instead of
+// the endpoint host.
+func (c *Client) presignURL(ctx context.Context, method, bucketName, objectName string, expires time.Duration, reqParams url.Values, extraHeaders http.Header, host string) (u *url.URL, err error) {
 	// Input validation.
 	if method == "" {
 		return nil, errInvalidArgument("method cannot be empty.")
 	}
-	if err = s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err = c.checkBucketName(bucketName); err != nil {
 		return nil, err
 	}
 	if err = isValidExpiry(expires); err != nil {
 		return nil, err
 	}
+	if host != "" {
+		if err = isValidEndpointURL(url.URL{Host: host}); err != nil {
+			return nil, err
+		}
+	}
 
 	// Convert expires into seconds.
 	expireSeconds := int64(expires / time.Second)
@@ -54,6 +62,7 @@ func (c *Client) presignURL(ctx context.Context, method, bucketName, objectName
 		expires:            expireSeconds,
 		queryValues:        reqParams,
 		extraPresignHeader: extraHeaders,
+		presignHost:        host,
 	}
 
 	// Instantiate a new request.
@@ -73,7 +82,31 @@ func (c *Client) PresignedGetObject(ctx context.Context, bucketName, objectName
 	if err = s3utils.CheckValidObjectName(objectName); err != nil {
 		return nil, err
 	}
-	return c.presignURL(ctx, http.MethodGet, bucketName, objectName, expires, reqParams, nil)
+	return c.presignURL(ctx, http.MethodGet, bucketName, objectName, expires, reqParams, nil, "")
+}
+
+// PresignedGetObjectForHost - similar to PresignedGetObject() but signs
+// the URL for the given host instead of the endpoint host. This allows
+// generating URLs to be served via a CDN or a proxy on a different
+// hostname which forwards the request, including its Host header, to
+// the S3 endpoint.
+func (c *Client) PresignedGetObjectForHost(ctx context.Context, bucketName, objectName string, expires time.Duration, reqParams url.Values, host string) (u *url.URL, err error) {
+	if err = s3utils.CheckValidObjectName(objectName); err != nil {
+		return nil, err
+	}
+	if host == "" {
+		return nil, errInvalidArgument("host cannot be empty.")
+	}
+	return c.presignURL(ctx, http.MethodGet, bucketName, objectName, expires, reqParams, nil, host)
+}
+
+// PresignHeaderForHost - similar to PresignHeader() but signs the URL for
+// the given host instead of the endpoint host, see PresignedGetObjectForHost().
+func (c *Client) PresignHeaderForHost(ctx context.Context, method, bucketName, objectName string, expires time.Duration, reqParams url.Values, extraHeaders http.Header, host string) (u *url.URL, err error) {
+	if host == "" {
+		return nil, errInvalidArgument("host cannot be empty.")
+	}
+	return c.presignURL(ctx, method, bucketName, objectName, expires, reqParams, extraHeaders, host)
 }
 
 // PresignedHeadObject - Returns a presigned URL to access
@@ -84,7 +117,7 @@ func (c *Client) PresignedHeadObject(ctx context.Context, bucketName, objectName
 	if err = s3utils.CheckValidObjectName(objectName); err != nil {
 		return nil, err
 	}
-	return c.presignURL(ctx, http.MethodHead, bucketName, objectName, expires, reqParams, nil)
+	return c.presignURL(ctx, http.MethodHead, bucketName, objectName, expires, reqParams, nil, "")
 }
 
 // PresignedPutObject - Returns a presigned URL to upload an object
@@ -94,7 +127,51 @@ func (c *Client) PresignedPutObject(ctx context.Context, bucketName, objectName
 	if err = s3utils.CheckValidObjectName(objectName); err != nil {
 		return nil, err
 	}
-	return c.presignURL(ctx, http.MethodPut, bucketName, objectName, expires, nil, nil)
+	return c.presignURL(ctx, http.MethodPut, bucketName, objectName, expires, nil, nil, "")
+}
+
+// PresignPutOptions - options for PresignedPutObjectWithOptions, the headers
+// set are signed and must be sent with the upload.
+type PresignPutOptions struct {
+	// ContentType, if set, is the Content-Type uploads must be sent with.
+	ContentType string
+
+	// ContentLength, if positive, is the exact size uploads must have.
+	// Presigned PUT URLs cannot accept a range of sizes, use
+	// PresignedPostPolicy with SetContentLengthRange to bound the size.
+	ContentLength int64
+}
+
+// PresignedPutObjectWithOptions - similar to PresignedPutObject() but signs
+// the Content-Type and Content-Length of opts, such that the server rejects
+// uploads with a different content type or size. The headers returned must
+// be sent with the upload. Requires signature v4.
+func (c *Client) PresignedPutObjectWithOptions(ctx context.Context, bucketName, objectName string, expires time.Duration, opts PresignPutOptions) (u *url.URL, headers http.Header, err error) {
+	if err = s3utils.CheckValidObjectName(objectName); err != nil {
+		return nil, nil, err
+	}
+	if opts.ContentLength < 0 {
+		return nil, nil, errInvalidArgument("Content length cannot be negative.")
+	}
+	if opts.ContentLength > maxSinglePutObjectSize {
+		return nil, nil, errEntityTooLarge(opts.ContentLength, maxSinglePutObjectSize, bucketName, objectName)
+	}
+
+	headers = make(http.Header)
+	if opts.ContentType != "" {
+		headers.Set("Content-Type", opts.ContentType)
+	}
+	if opts.ContentLength > 0 {
+		headers.Set("Content-Length", strconv.FormatInt(opts.ContentLength, 10))
+	}
+	if len(headers) == 0 {
+		headers = nil
+	}
+	u, err = c.presignURL(ctx, http.MethodPut, bucketName, objectName, expires, nil, headers, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	return u, headers, nil
 }
 
 // PresignHeader - similar to Presign() but allows including HTTP headers that
@@ -105,14 +182,14 @@ func (c *Client) PresignedPutObject(ctx context.Context, bucketName, objectName
 // FIXME: The extra header parameter should be included in Presign() in the next
 // major version bump, and this function should then be deprecated.
 func (c *Client) PresignHeader(ctx context.Context, method, bucketName, objectName string, expires time.Duration, reqParams url.Values, extraHeaders http.Header) (u *url.URL, err error) {
-	return c.presignURL(ctx, method, bucketName, objectName, expires, reqParams, extraHeaders)
+	return c.presignURL(ctx, method, bucketName, objectName, expires, reqParams, extraHeaders, "")
 }
 
 // Presign - returns a presigned URL for any http method of your choice along
 // with custom request params and extra signed headers. URL can have a maximum
 // expiry of upto 7days or a minimum of 1sec.
 func (c *Client) Presign(ctx context.Context, method, bucketName, objectName string, expires time.Duration, reqParams url.Values) (u *url.URL, err error) {
-	return c.presignURL(ctx, method, bucketName, objectName, expires, reqParams, nil)
+	return c.presignURL(ctx, method, bucketName, objectName, expires, reqParams, nil, "")
 }
 
 // PresignedPostPolicy - Returns POST urlString, form data to upload an object.
@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/openstor/openstor-go/v7/pkg/credentials"
+)
+
+func TestPresignedGetObjectForHost(t *testing.T) {
+	clnt, err := New("localhost:9000", &Options{
+		Creds:  credentials.NewStaticV4("accessKey", "secretKey", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := clnt.PresignedGetObject(context.Background(), "bucket", "object", time.Hour, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cdnURL, err := clnt.PresignedGetObjectForHost(context.Background(), "bucket", "object", time.Hour, nil, "cdn.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cdnURL.Host != "cdn.example.com" {
+		t.Fatalf("expected host cdn.example.com, got %s", cdnURL.Host)
+	}
+	if cdnURL.Path != u.Path {
+		t.Fatalf("expected path %s, got %s", u.Path, cdnURL.Path)
+	}
+	if cdnURL.Query().Get("X-Amz-Signature") == u.Query().Get("X-Amz-Signature") {
+		t.Fatal("expected the signature to be computed for the custom host")
+	}
+
+	if _, err = clnt.PresignedGetObjectForHost(context.Background(), "bucket", "object", time.Hour, nil, ""); err == nil {
+		t.Fatal("expected empty host to fail")
+	}
+	if _, err = clnt.PresignedGetObjectForHost(context.Background(), "bucket", "object", time.Hour, nil, "cdn.-"); err == nil {
+		t.Fatal("expected invalid host to fail")
+	}
+}
+
+func TestPresignedPutObjectWithOptions(t *testing.T) {
+	clnt, err := New("localhost:9000", &Options{
+		Creds:  credentials.NewStaticV4("accessKey", "secretKey", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	testCases := []struct {
+		opts          PresignPutOptions
+		signedHeaders string
+		success       bool
+	}{
+		{PresignPutOptions{}, "host", true},
+		{PresignPutOptions{ContentType: "image/png"}, "content-type;host", true},
+		{PresignPutOptions{ContentLength: 1024}, "content-length;host", true},
+		{PresignPutOptions{ContentType: "image/png", ContentLength: 1024}, "content-length;content-type;host", true},
+		{PresignPutOptions{ContentLength: -1}, "", false},
+		{PresignPutOptions{ContentLength: maxSinglePutObjectSize + 1}, "", false},
+	}
+	for i, testCase := range testCases {
+		u, headers, err := clnt.PresignedPutObjectWithOptions(ctx, "bucket", "object", time.Hour, testCase.opts)
+		if err != nil && testCase.success {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Fatalf("Test %d: expected an error", i+1)
+		}
+		if !testCase.success {
+			continue
+		}
+		if signed := u.Query().Get("X-Amz-SignedHeaders"); signed != testCase.signedHeaders {
+			t.Fatalf("Test %d: expected signed headers %s, got %s", i+1, testCase.signedHeaders, signed)
+		}
+		if headers.Get("Content-Type") != testCase.opts.ContentType {
+			t.Fatalf("Test %d: expected Content-Type %q to be returned, got %q", i+1, testCase.opts.ContentType, headers.Get("Content-Type"))
+		}
+		if testCase.opts.ContentLength > 0 && headers.Get("Content-Length") != strconv.FormatInt(testCase.opts.ContentLength, 10) {
+			t.Fatalf("Test %d: expected Content-Length %d to be returned, got %q", i+1, testCase.opts.ContentLength, headers.Get("Content-Length"))
+		}
+	}
+
+	// The signature covers the header values.
+	png, _, err := clnt.PresignedPutObjectWithOptions(ctx, "bucket", "object", time.Hour, PresignPutOptions{ContentType: "image/png"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jpeg, _, err := clnt.PresignedPutObjectWithOptions(ctx, "bucket", "object", time.Hour, PresignPutOptions{ContentType: "image/jpeg"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if png.Query().Get("X-Amz-Signature") == jpeg.Query().Get("X-Amz-Signature") {
+		t.Fatal("expected the signature to cover the content type")
+	}
+
+	// Signature v2 cannot sign headers.
+	clnt, err = New("localhost:9000", &Options{
+		Creds:  credentials.NewStaticV2("accessKey", "secretKey", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = clnt.PresignedPutObjectWithOptions(ctx, "bucket", "object", time.Hour, PresignPutOptions{ContentType: "image/png"}); err == nil {
+		t.Fatal("expected signature v2 to fail")
+	}
+}
+
+func TestPresignedHeadObject(t *testing.T) {
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		if r.URL.Query().Get("X-Amz-Signature") == "" {
+			t.Errorf("expected a presigned request, got %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Length", "1024")
+	}))
+
+	ctx := context.Background()
+	reqParams := url.Values{"versionId": []string{"v1"}}
+	u, err := clnt.PresignedHeadObject(ctx, "bucket", "object", 24*time.Hour, reqParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := u.Query()
+	if query.Get("X-Amz-Expires") != "86400" || query.Get("versionId") != "v1" {
+		t.Fatalf("unexpected presigned query %s", u.RawQuery)
+	}
+	getURL, err := clnt.PresignedGetObject(ctx, "bucket", "object", 24*time.Hour, reqParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if getURL.Query().Get("X-Amz-Signature") == query.Get("X-Amz-Signature") {
+		t.Fatal("expected the signature to be computed for the HEAD method")
+	}
+
+	resp, err := http.Head(u.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.ContentLength != 1024 {
+		t.Fatalf("expected content length 1024, got %d", resp.ContentLength)
+	}
+
+	if _, err = clnt.PresignedHeadObject(ctx, "bucket", "", time.Hour, nil); err == nil {
+		t.Fatal("expected an empty object name to fail")
+	}
+	if _, err = clnt.PresignedHeadObject(ctx, "bucket", "object", 8*24*time.Hour, nil); err == nil {
+		t.Fatal("expected an expiry longer than 7 days to fail")
+	}
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+
+	"github.com/openstor/openstor-go/v7/pkg/s3utils"
+)
+
+// isValidCannedACL returns true for the canned ACLs applicable to objects.
+func isValidCannedACL(acl string) bool {
+	switch acl {
+	case "private", "public-read", "public-read-write", "authenticated-read",
+		"aws-exec-read", "bucket-owner-read", "bucket-owner-full-control":
+		return true
+	}
+	return false
+}
+
+// PutObjectACL - set the ACL of an object, either as the owner and grants
+// of acl or as the canned ACL set with SetCannedACL. Buckets enforcing
+// the bucket owner object ownership reject ACLs.
+func (c *Client) PutObjectACL(ctx context.Context, bucketName, objectName string, acl *AccessControlPolicy) error {
+	// Input validation.
+	if err := c.checkBucketName(bucketName); err != nil {
+		return err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return err
+	}
+	if acl == nil {
+		return errInvalidArgument("Access control policy cannot be nil.")
+	}
+
+	// Get resources properly escaped and lined up before
+	// using them in http request.
+	urlValues := make(url.Values)
+	urlValues.Set("acl", "")
+
+	reqMetadata := requestMetadata{
+		bucketName:  bucketName,
+		objectName:  objectName,
+		queryValues: urlValues,
+	}
+
+	if acl.cannedACL != "" {
+		if !isValidCannedACL(acl.cannedACL) {
+			return errInvalidArgument("Invalid canned ACL `" + acl.cannedACL + "`.")
+		}
+		reqMetadata.customHeader = make(http.Header)
+		reqMetadata.customHeader.Set("x-amz-acl", acl.cannedACL)
+		reqMetadata.contentSHA256Hex = emptySHA256Hex
+	} else {
+		buf, err := xml.Marshal(acl)
+		if err != nil {
+			return err
+		}
+		reqMetadata.contentBody = bytes.NewReader(buf)
+		reqMetadata.contentLength = int64(len(buf))
+		reqMetadata.contentMD5Base64 = sumMD5Base64(buf)
+		reqMetadata.contentSHA256Hex = sum256Hex(buf)
+	}
+
+	// Execute PUT to set the object ACL.
+	resp, err := c.executeMethod(ctx, http.MethodPut, reqMetadata)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp, bucketName, objectName)
+	}
+	return nil
+}
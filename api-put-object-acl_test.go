@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestObjectACL(t *testing.T) {
+	stored := `<AccessControlPolicy><Owner><ID>owner-id</ID><DisplayName>owner</DisplayName></Owner><AccessControlList>` +
+		`<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser"><ID>owner-id</ID><DisplayName>owner</DisplayName></Grantee><Permission>FULL_CONTROL</Permission></Grant>` +
+		`<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="Group"><URI>http://acs.amazonaws.com/groups/global/AllUsers</URI></Grantee><Permission>READ</Permission></Grant>` +
+		`</AccessControlList></AccessControlPolicy>`
+	var cannedACL string
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["acl"]; !ok {
+			t.Errorf("expected acl query, got %s", r.URL.RawQuery)
+		}
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			cannedACL = r.Header.Get("x-amz-acl")
+			if cannedACL == "" {
+				stored = string(body)
+			}
+		case http.MethodGet:
+			io.WriteString(w, stored)
+		}
+	}))
+
+	ctx := context.Background()
+	acl, err := clnt.GetObjectAccessControlPolicy(ctx, "bucket", "object")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acl.Owner.ID != "owner-id" || acl.Owner.DisplayName != "owner" {
+		t.Fatalf("unexpected owner %+v", acl.Owner)
+	}
+	grants := acl.AccessControlList.Grant
+	if len(grants) != 2 || grants[0].Grantee.Type != GranteeCanonicalUser || grants[1].Grantee.Type != GranteeGroup ||
+		grants[1].Grantee.URI != "http://acs.amazonaws.com/groups/global/AllUsers" || grants[1].Permission != "READ" {
+		t.Fatalf("unexpected grants %+v", grants)
+	}
+
+	// Grant a user by email read access, replacing the public grant.
+	acl.AccessControlList.Grant[1] = Grant{
+		Grantee:    Grantee{Type: GranteeEmail, EmailAddress: "user@example.com"},
+		Permission: "READ",
+	}
+	if err = clnt.PutObjectACL(ctx, "bucket", "object", acl); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<AccessControlPolicy><Owner><DisplayName>owner</DisplayName><ID>owner-id</ID></Owner><AccessControlList>` +
+		`<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser"><ID>owner-id</ID><DisplayName>owner</DisplayName></Grantee><Permission>FULL_CONTROL</Permission></Grant>` +
+		`<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="AmazonCustomerByEmail"><EmailAddress>user@example.com</EmailAddress></Grantee><Permission>READ</Permission></Grant>` +
+		`</AccessControlList></AccessControlPolicy>`
+	if stored != expected {
+		t.Fatalf("unexpected ACL sent\nexpected %s\ngot      %s", expected, stored)
+	}
+
+	acl = &AccessControlPolicy{}
+	acl.SetCannedACL("public-read")
+	if err = clnt.PutObjectACL(ctx, "bucket", "object", acl); err != nil {
+		t.Fatal(err)
+	}
+	if cannedACL != "public-read" {
+		t.Fatalf("expected canned ACL public-read, got %q", cannedACL)
+	}
+
+	acl.SetCannedACL("public")
+	if err = clnt.PutObjectACL(ctx, "bucket", "object", acl); ToErrorResponse(err).Code != InvalidArgument {
+		t.Fatalf("expected invalid canned ACL to be rejected, got %v", err)
+	}
+	if err = clnt.PutObjectACL(ctx, "bucket", "object", nil); ToErrorResponse(err).Code != InvalidArgument {
+		t.Fatalf("expected nil ACL to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/openstor/openstor-go/v7/pkg/s3utils"
+)
+
+// countingReader counts the bytes read from r, the transport may
+// still read the request body once the response is received.
+type countingReader struct {
+	r io.Reader
+	n atomic.Int64
+}
+
+func (r *countingReader) Read(p []byte) (n int, err error) {
+	n, err = r.r.Read(p)
+	r.n.Add(int64(n))
+	return n, err
+}
+
+// PutObjectStreaming - Create an object in a bucket, with contents read
+// from reader until EOF, without knowing its size upfront.
+//
+// Up to a part of the contents is read first: objects ending within it
+// are sent as a single request with their size known, larger objects
+// are uploaded through PutObject as a multipart upload, one part being
+// kept in memory at a time.
+//
+// With DisableMultipart larger objects are instead sent as a single
+// aws-chunked request of unknown length, signed chunk by chunk, with
+// their checksum sent in a trailer when Options.TrailingHeaders are
+// enabled or a Checksum is set. Only some gateways accept such requests,
+// AWS S3 and MinIO require the decoded length of aws-chunked uploads and
+// reject them.
+func (c *Client) PutObjectStreaming(ctx context.Context, bucketName, objectName string, reader io.Reader, opts PutObjectOptions) (UploadInfo, error) {
+	// Input validation.
+	if err := c.checkBucketName(bucketName); err != nil {
+		return UploadInfo{}, err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return UploadInfo{}, err
+	}
+	if err := opts.validate(c); err != nil {
+		return UploadInfo{}, err
+	}
+
+	partSize := opts.PartSize
+	if partSize == 0 {
+		partSize = minPartSize
+	}
+	first, err := io.ReadAll(io.LimitReader(reader, int64(partSize)+1))
+	if err != nil {
+		return UploadInfo{}, err
+	}
+	if int64(len(first)) <= int64(partSize) {
+		return c.PutObject(ctx, bucketName, objectName, bytes.NewReader(first), int64(len(first)), opts)
+	}
+	reader = io.MultiReader(bytes.NewReader(first), reader)
+
+	// Chunked signatures are only available with signature v4 and
+	// not supported by GCS.
+	if !opts.DisableMultipart || c.overrideSignerType.IsV2() || s3utils.IsGoogleEndpoint(*c.endpointURL) {
+		return c.PutObject(ctx, bucketName, objectName, reader, -1, opts)
+	}
+
+	progress := opts.Progress
+	if opts.ProgressFunc != nil {
+		progress = newProgressFuncHook(opts.Progress, opts.ProgressFunc, -1)
+	}
+	source := &countingReader{r: newHook(reader, progress)}
+
+	reqMetadata := requestMetadata{
+		bucketName:     bucketName,
+		objectName:     objectName,
+		bucketLocation: opts.Region,
+		customHeader:   opts.Header(),
+		userAgent:      opts.UserAgent,
+		contentBody:    source,
+		contentLength:  -1,
+		streamSha256:   !opts.DisableContentSha256,
+	}
+	if opts.Checksum.IsSet() {
+		reqMetadata.addCrc = &opts.Checksum
+	} else if c.trailingHeaderSupport {
+		checksum := opts.AutoChecksum
+		checksum.SetDefault(ChecksumFullObjectCRC32C)
+		reqMetadata.addCrc = &checksum
+	}
+
+	// Execute PUT an objectName.
+	resp, err := c.executeMethod(ctx, http.MethodPut, reqMetadata)
+	defer closeResponse(resp)
+	if err == nil && resp.StatusCode != http.StatusOK {
+		err = httpRespToErrorResponse(resp, bucketName, objectName)
+	}
+	if err != nil {
+		return UploadInfo{}, err
+	}
+
+	// extract lifecycle expiry date and rule ID
+	expTime, ruleID := amzExpirationToExpiryDateRuleID(resp.Header.Get(amzExpiration))
+	h := resp.Header
+	return UploadInfo{
+		Bucket:           bucketName,
+		Key:              objectName,
+		ETag:             trimEtag(h.Get("ETag")),
+		VersionID:        h.Get(amzVersionID),
+		Size:             source.n.Load(),
+		Expiration:       expTime,
+		ExpirationRuleID: ruleID,
+
+		// Checksum values
+		ChecksumCRC32:     h.Get(ChecksumCRC32.Key()),
+		ChecksumCRC32C:    h.Get(ChecksumCRC32C.Key()),
+		ChecksumSHA1:      h.Get(ChecksumSHA1.Key()),
+		ChecksumSHA256:    h.Get(ChecksumSHA256.Key()),
+		ChecksumCRC64NVME: h.Get(ChecksumCRC64NVME.Key()),
+		ChecksumMode:      h.Get(ChecksumFullObjectMode.Key()),
+
+		RequestCharged: h.Get(amzRequestCharged) == requestPayerRequester,
+	}, nil
+}
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/openstor/openstor-go/v7/pkg/credentials"
+)
+
+// decodeAWSChunked decodes an aws-chunked body, returning the
+// payload and the trailer.
+func decodeAWSChunked(r io.Reader) (payload []byte, trailer http.Header, err error) {
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, nil, err
+		}
+		// <hex-size>;chunk-signature=<signature>
+		sizeStr, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		size, err := strconv.ParseInt(sizeStr, 16, 64)
+		if err != nil {
+			return nil, nil, err
+		}
+		if size == 0 {
+			break
+		}
+		chunk := make([]byte, size+2)
+		if _, err = io.ReadFull(br, chunk); err != nil {
+			return nil, nil, err
+		}
+		payload = append(payload, chunk[:size]...)
+	}
+	trailer = make(http.Header)
+	for {
+		line, err := br.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if k, v, ok := strings.Cut(line, ":"); ok {
+			trailer.Set(k, v)
+		}
+		if err != nil {
+			break
+		}
+	}
+	return payload, trailer, nil
+}
+
+func TestPutObjectStreaming(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789abcdef"), absMinPartSize/16+3)
+	var stored []byte
+	var trailer http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != -1 || r.Header.Get("x-amz-decoded-content-length") != "" {
+			t.Errorf("expected an upload of unknown length, got %d", r.ContentLength)
+		}
+		if sha := r.Header.Get("x-amz-content-sha256"); sha != "STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER" {
+			t.Errorf("unexpected x-amz-content-sha256 %s", sha)
+		}
+		var err error
+		if stored, trailer, err = decodeAWSChunked(r.Body); err != nil {
+			t.Errorf("unable to decode the body: %v", err)
+		}
+		w.Header().Set("ETag", `"etag"`)
+	}))
+	defer srv.Close()
+
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:           credentials.NewStaticV4("accessKey", "secretKey", ""),
+		Region:          "us-east-1",
+		TrailingHeaders: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Objects larger than a part are sent with an unknown length
+	// when multipart uploads are disabled.
+	var progress int64
+	info, err := clnt.PutObjectStreaming(context.Background(), "bucket", "object", io.MultiReader(bytes.NewReader(data)), PutObjectOptions{
+		PartSize:         absMinPartSize,
+		DisableMultipart: true,
+		ProgressFunc:     func(bytesTransferred, _ int64) { progress = bytesTransferred },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ETag != "etag" || info.Size != int64(len(data)) || progress != int64(len(data)) {
+		t.Fatalf("unexpected upload info %+v, progress %d", info, progress)
+	}
+	if !bytes.Equal(stored, data) {
+		t.Fatalf("unexpected object of %d bytes", len(stored))
+	}
+	crc := crc32.Checksum(data, crc32.MakeTable(crc32.Castagnoli))
+	expected := base64.StdEncoding.EncodeToString(binary.BigEndian.AppendUint32(nil, crc))
+	if got := trailer.Get("x-amz-checksum-crc32c"); got != expected {
+		t.Fatalf("expected trailing checksum %s, got %s", expected, got)
+	}
+	if trailer.Get("x-amz-trailer-signature") == "" {
+		t.Fatal("expected a signed trailer")
+	}
+}
+
+func TestPutObjectStreamingDecodedLength(t *testing.T) {
+	testCases := []struct {
+		size      int
+		multipart bool
+	}{
+		{0, false},
+		{200 * 1024, false},
+		{absMinPartSize, false},
+		{absMinPartSize + 1, true},
+		{3*absMinPartSize + 7, true},
+	}
+
+	for i, testCase := range testCases {
+		data := bytes.Repeat([]byte("a"), testCase.size)
+		mpSrv := &multipartServer{
+			parts:    make(map[string]map[int][]byte),
+			partPuts: make(map[int]int),
+		}
+		var single []byte
+		var singlePuts, streamed int
+		clnt, srv := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// As AWS S3 and MinIO, require the decoded length of
+			// aws-chunked uploads.
+			if strings.HasPrefix(r.Header.Get("x-amz-content-sha256"), "STREAMING-") {
+				if r.Header.Get("x-amz-decoded-content-length") == "" {
+					w.WriteHeader(http.StatusLengthRequired)
+					io.WriteString(w, `<Error><Code>MissingContentLength</Code><Message>You must provide the Content-Length HTTP header.</Message></Error>`)
+					return
+				}
+				streamed++
+				payload, _, err := decodeAWSChunked(r.Body)
+				if err != nil {
+					t.Errorf("Test %d: unable to decode the body: %v", i+1, err)
+				}
+				r.Body = io.NopCloser(bytes.NewReader(payload))
+			}
+			if r.Method == http.MethodPut && !r.URL.Query().Has("uploadId") {
+				singlePuts++
+				single, _ = io.ReadAll(r.Body)
+				w.Header().Set("ETag", `"etag"`)
+				return
+			}
+			mpSrv.ServeHTTP(w, r)
+		}))
+
+		info, err := clnt.PutObjectStreaming(context.Background(), "bucket", "object", io.MultiReader(bytes.NewReader(data)), PutObjectOptions{
+			PartSize: absMinPartSize,
+		})
+		srv.Close()
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if info.Size != int64(len(data)) {
+			t.Fatalf("Test %d: expected %d bytes to be uploaded, got %d", i+1, len(data), info.Size)
+		}
+		if testCase.size > 0 && streamed == 0 {
+			t.Fatalf("Test %d: expected aws-chunked requests", i+1)
+		}
+		if testCase.multipart {
+			if singlePuts != 0 || mpSrv.uploads != 1 || !bytes.Equal(mpSrv.completed, data) {
+				t.Fatalf("Test %d: expected a multipart upload, got %d single PUTs, %d uploads, %d bytes", i+1, singlePuts, mpSrv.uploads, len(mpSrv.completed))
+			}
+		} else if singlePuts != 1 || mpSrv.uploads != 0 || !bytes.Equal(single, data) {
+			t.Fatalf("Test %d: expected a single PUT, got %d single PUTs, %d uploads, %d bytes", i+1, singlePuts, mpSrv.uploads, len(single))
+		}
+	}
+}
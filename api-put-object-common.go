@@ -22,9 +22,12 @@ package openstor
 
 import (
 	"context"
+	"crypto/md5"
+	"encoding/hex"
 	"io"
 	"math"
 	"os"
+	"strconv"
 
 	"github.com/openstor/openstor-go/v7/pkg/s3utils"
 )
@@ -65,6 +68,9 @@ func isReadAt(reader io.Reader) (ok bool) {
 // OptimalPartInfo - calculate the optimal part info for a given
 // object size.
 //
+// Without a configured part size, objects of known size are uploaded in
+// about 1000 parts, of at least minPartSize and at most 5GiB.
+//
 // NOTE: Assumption here is that for any object to be uploaded to any S3 compatible
 // object storage it will have the following parameters as constants.
 //
@@ -119,8 +125,13 @@ func OptimalPartInfo(objectSize int64, configuredPartSize uint64) (totalPartsCou
 		configuredPartSize = minPartSize
 		// Use floats for part size for all calculations to avoid
 		// overflows during float64 to int64 conversions.
-		partSizeFlt = float64(objectSize / maxPartsCount)
+		if unknownSize {
+			partSizeFlt = float64(objectSize / maxPartsCount)
+		} else {
+			partSizeFlt = float64(objectSize / targetPartsCount)
+		}
 		partSizeFlt = math.Ceil(partSizeFlt/float64(configuredPartSize)) * float64(configuredPartSize)
+		partSizeFlt = max(min(partSizeFlt, maxPartSize), minPartSize)
 	}
 
 	// Total parts count.
@@ -132,11 +143,74 @@ func OptimalPartInfo(objectSize int64, configuredPartSize uint64) (totalPartsCou
 	return totalPartsCount, partSize, lastPartSize, nil
 }
 
+// unknownSizePartSize - returns the size of part partNumber of an upload
+// of unknown size. The configured part size is used for every part if set,
+// otherwise parts start at minPartSize and double every
+// partSizeGrowthInterval parts up to maxPartSize, such that small streams
+// are not buffered in large parts while maxPartsCount parts still hold
+// up to 5TiB.
+func unknownSizePartSize(partNumber int, configuredPartSize uint64) int64 {
+	if configuredPartSize > 0 {
+		return int64(configuredPartSize)
+	}
+	return min(int64(minPartSize)<<((partNumber-1)/partSizeGrowthInterval), maxPartSize)
+}
+
+// ComputeMultipartETag - compute the ETag of a multipart object from the
+// MD5 sums of its parts, in part order. The ETag is the MD5 sum of the
+// concatenated part MD5 sums followed by the number of parts, as in
+// "<md5-of-part-md5s>-<parts>". Returns an empty string without parts.
+//
+// Objects encrypted with SSE-C or SSE-KMS do not have such an ETag.
+func ComputeMultipartETag(partMD5s [][]byte) string {
+	if len(partMD5s) == 0 {
+		return ""
+	}
+	hash := md5.New()
+	for _, sum := range partMD5s {
+		hash.Write(sum)
+	}
+	return hex.EncodeToString(hash.Sum(nil)) + "-" + strconv.Itoa(len(partMD5s))
+}
+
+// ComputeMultipartETagFromFile - compute the ETag of the multipart object
+// created by uploading the file at filePath in parts of partSize bytes,
+// see ComputeMultipartETag.
+func ComputeMultipartETagFromFile(filePath string, partSize int64) (string, error) {
+	if partSize <= 0 {
+		return "", errInvalidArgument("Part size must be greater than zero.")
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var partMD5s [][]byte
+	hash := md5.New()
+	for {
+		hash.Reset()
+		n, err := io.CopyN(hash, f, partSize)
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		// An empty file is uploaded as a single empty part.
+		if n > 0 || len(partMD5s) == 0 {
+			partMD5s = append(partMD5s, hash.Sum(nil))
+		}
+		if n < partSize {
+			break
+		}
+	}
+	return ComputeMultipartETag(partMD5s), nil
+}
+
 // getUploadID - fetch upload id if already present for an object name
 // or initiate a new request to fetch a new upload id.
 func (c *Client) newUploadID(ctx context.Context, bucketName, objectName string, opts PutObjectOptions) (uploadID string, err error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return "", err
 	}
 	if err := s3utils.CheckValidObjectName(objectName); err != nil {
@@ -122,6 +122,12 @@ func (c *Client) PutObjectFanOut(ctx context.Context, bucket string, fanOutData
 	mwriter := multipart.NewWriter(w)
 	req.Header.Add("Content-Type", mwriter.FormDataContentType())
 
+	// The request is authorized by the signed policy in its body.
+	if err = c.interceptRequest(req); err != nil {
+		w.Close()
+		return nil, err
+	}
+
 	go func() {
 		defer w.Close()
 		defer mwriter.Close()
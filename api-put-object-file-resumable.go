@@ -0,0 +1,349 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/openstor/openstor-go/v7/pkg/s3utils"
+)
+
+// ResumableOptions holds options for FPutObjectResumable.
+type ResumableOptions struct {
+	// Opts is options applied to the upload, the PartSize must not
+	// change between attempts for the upload to be resumed.
+	Opts PutObjectOptions
+
+	// StateFile is the path of the file recording the upload state,
+	// defaults to the source file path with a ".upload" suffix.
+	StateFile string
+}
+
+// resumableUploadState is the upload state persisted between
+// FPutObjectResumable attempts.
+type resumableUploadState struct {
+	Bucket   string       `json:"bucket"`
+	Object   string       `json:"object"`
+	UploadID string       `json:"uploadId"`
+	Size     int64        `json:"size"`
+	ModTime  time.Time    `json:"modTime"`
+	PartSize int64        `json:"partSize"`
+	Parts    []ObjectPart `json:"parts,omitempty"`
+}
+
+// matches returns true if the state was recorded for the same
+// object and the same, unmodified, source file.
+func (s resumableUploadState) matches(bucketName, objectName string, fi os.FileInfo, partSize int64) bool {
+	return s.UploadID != "" && s.Bucket == bucketName && s.Object == objectName &&
+		s.Size == fi.Size() && s.ModTime.Equal(fi.ModTime()) && s.PartSize == partSize
+}
+
+// readUploadState loads the upload state, a missing or unreadable
+// state file results in an empty state.
+func readUploadState(stateFile string) (state resumableUploadState) {
+	buf, err := os.ReadFile(stateFile)
+	if err != nil {
+		return state
+	}
+	if err = json.Unmarshal(buf, &state); err != nil {
+		return resumableUploadState{}
+	}
+	return state
+}
+
+// writeUploadState atomically replaces the upload state file.
+func writeUploadState(stateFile string, state resumableUploadState) error {
+	buf, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmpFile := stateFile + ".tmp"
+	if err = os.WriteFile(tmpFile, buf, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, stateFile)
+}
+
+// samePart returns true if the listed part is the recorded part, the
+// checksums are only compared when both sides have them.
+func samePart(recorded, listed ObjectPart) bool {
+	if recorded.ETag != listed.ETag || recorded.Size != listed.Size {
+		return false
+	}
+	checksums := [][2]string{
+		{recorded.ChecksumCRC32, listed.ChecksumCRC32},
+		{recorded.ChecksumCRC32C, listed.ChecksumCRC32C},
+		{recorded.ChecksumSHA1, listed.ChecksumSHA1},
+		{recorded.ChecksumSHA256, listed.ChecksumSHA256},
+		{recorded.ChecksumCRC64NVME, listed.ChecksumCRC64NVME},
+	}
+	for _, sums := range checksums {
+		if sums[0] != "" && sums[1] != "" && sums[0] != sums[1] {
+			return false
+		}
+	}
+	return true
+}
+
+// FPutObjectResumable - Create an object in a bucket, with contents from
+// file at filePath, through a multipart upload that can be resumed.
+//
+// The upload ID and the uploaded parts are recorded in a state file, a
+// subsequent call for the same object skips the parts already uploaded
+// once they are verified through ListObjectParts. The upload restarts
+// from scratch if the source file was modified in between. The state
+// file is removed once the upload completes, on failure the incomplete
+// upload is kept to be resumed later.
+//
+// Files smaller than a part are uploaded with FPutObject.
+func (c *Client) FPutObjectResumable(ctx context.Context, bucketName, objectName, filePath string, opts ResumableOptions) (info UploadInfo, err error) {
+	// Input validation.
+	if err = c.checkBucketName(bucketName); err != nil {
+		return UploadInfo{}, err
+	}
+	if err = s3utils.CheckValidObjectName(objectName); err != nil {
+		return UploadInfo{}, err
+	}
+
+	stateFile := opts.StateFile
+	if stateFile == "" {
+		stateFile = filePath + ".upload"
+	}
+
+	// Open the referenced file.
+	fileReader, err := os.Open(filePath)
+	if err != nil {
+		return UploadInfo{}, err
+	}
+	defer fileReader.Close()
+
+	// Save the file stat.
+	fileStat, err := fileReader.Stat()
+	if err != nil {
+		return UploadInfo{}, err
+	}
+	size := fileStat.Size()
+
+	putOpts := opts.Opts
+	if err = putOpts.validate(c); err != nil {
+		return UploadInfo{}, err
+	}
+
+	// Check for largest object size allowed.
+	if size > int64(maxMultipartPutObjectSize) {
+		return UploadInfo{}, errEntityTooLarge(size, maxMultipartPutObjectSize, bucketName, objectName)
+	}
+
+	// Calculate the optimal parts info for a given size.
+	totalPartsCount, partSize, lastPartSize, err := OptimalPartInfo(size, putOpts.PartSize)
+	if err != nil {
+		return UploadInfo{}, err
+	}
+	if totalPartsCount <= 1 {
+		info, err = c.FPutObject(ctx, bucketName, objectName, filePath, putOpts)
+		if err == nil {
+			os.Remove(stateFile)
+		}
+		return info, err
+	}
+
+	// Set contentType based on filepath extension if not given or default
+	// value of "application/octet-stream" if the extension has no associated type.
+	if putOpts.ContentType == "" {
+		if putOpts.ContentType = mime.TypeByExtension(filepath.Ext(filePath)); putOpts.ContentType == "" {
+			putOpts.ContentType = "application/octet-stream"
+		}
+	}
+
+	if putOpts.ProgressFunc != nil {
+		putOpts.Progress = newProgressFuncHook(putOpts.Progress, putOpts.ProgressFunc, size)
+	}
+
+	if putOpts.Checksum.IsSet() {
+		putOpts.AutoChecksum = putOpts.Checksum
+		putOpts.SendContentMd5 = false
+	}
+
+	withChecksum := c.trailingHeaderSupport
+	if withChecksum {
+		putOpts.AutoChecksum.SetDefault(ChecksumCRC32C)
+		addAutoChecksumHeaders(&putOpts)
+	}
+
+	// Parts already uploaded, by part number.
+	uploaded := make(map[int]ObjectPart)
+
+	state := readUploadState(stateFile)
+	if state.matches(bucketName, objectName, fileStat, partSize) {
+		result, lerr := c.ListObjectParts(ctx, bucketName, objectName, state.UploadID, ListPartsOptions{})
+		switch {
+		case lerr == nil:
+			listed := make(map[int]ObjectPart, len(result.Parts))
+			for _, part := range result.Parts {
+				listed[part.PartNumber] = part
+			}
+			for _, part := range state.Parts {
+				if l, ok := listed[part.PartNumber]; ok && samePart(part, l) {
+					uploaded[part.PartNumber] = part
+				}
+			}
+		case ToErrorResponse(lerr).Code == NoSuchUpload:
+			// The upload was aborted or expired, start over.
+			state = resumableUploadState{}
+		default:
+			return UploadInfo{}, lerr
+		}
+	} else if state.UploadID != "" {
+		// The source file or the upload options changed, the
+		// recorded upload cannot be resumed.
+		if state.Bucket == bucketName && state.Object == objectName {
+			c.abortIncompleteUpload(bucketName, objectName, state.UploadID, opts.Opts)
+		}
+		state = resumableUploadState{}
+	}
+
+	if state.UploadID == "" {
+		// Initiate a new multipart upload.
+		uploadID, err := c.newUploadID(ctx, bucketName, objectName, putOpts)
+		if err != nil {
+			return UploadInfo{}, err
+		}
+		state = resumableUploadState{
+			Bucket:   bucketName,
+			Object:   objectName,
+			UploadID: uploadID,
+			Size:     size,
+			ModTime:  fileStat.ModTime(),
+			PartSize: partSize,
+		}
+		if err = writeUploadState(stateFile, state); err != nil {
+			return UploadInfo{}, err
+		}
+	}
+
+	state.Parts = state.Parts[:0]
+	for partNumber := 1; partNumber <= totalPartsCount; partNumber++ {
+		readOffset := int64(partNumber-1) * partSize
+		length := partSize
+		if partNumber == totalPartsCount {
+			readOffset = size - lastPartSize
+			length = lastPartSize
+		}
+
+		if part, ok := uploaded[partNumber]; ok {
+			// Update the progress as if the part was uploaded.
+			if putOpts.Progress != nil {
+				if _, err = io.CopyN(io.Discard, putOpts.Progress, length); err != nil {
+					return UploadInfo{}, err
+				}
+			}
+			state.Parts = append(state.Parts, part)
+			continue
+		}
+
+		sectionReader := newHook(io.NewSectionReader(fileReader, readOffset, length), putOpts.Progress)
+		trailer := make(http.Header, 1)
+		if withChecksum {
+			crc := putOpts.AutoChecksum.Hasher()
+			trailer.Set(putOpts.AutoChecksum.Key(), base64.StdEncoding.EncodeToString(crc.Sum(nil)))
+			sectionReader = newHashReaderWrapper(sectionReader, crc, func(hash []byte) {
+				trailer.Set(putOpts.AutoChecksum.Key(), base64.StdEncoding.EncodeToString(hash))
+			})
+		}
+
+		// Proceed to upload the part.
+		objPart, err := c.uploadPart(ctx, uploadPartParams{
+			bucketName:   bucketName,
+			objectName:   objectName,
+			uploadID:     state.UploadID,
+			reader:       sectionReader,
+			partNumber:   partNumber,
+			size:         length,
+			sse:          putOpts.ServerSideEncryption,
+			streamSha256: !putOpts.DisableContentSha256,
+			trailer:      trailer,
+			region:       putOpts.Region,
+			userAgent:    putOpts.UserAgent,
+			requestPayer: putOpts.RequestPayer,
+		})
+		if err != nil {
+			return UploadInfo{}, err
+		}
+
+		// Record the part, such that it is not uploaded again.
+		state.Parts = append(state.Parts, objPart)
+		if err = writeUploadState(stateFile, state); err != nil {
+			return UploadInfo{}, err
+		}
+	}
+
+	var complMultipartUpload completeMultipartUpload
+	for _, part := range state.Parts {
+		complMultipartUpload.Parts = append(complMultipartUpload.Parts, CompletePart{
+			ETag:              part.ETag,
+			PartNumber:        part.PartNumber,
+			ChecksumCRC32:     part.ChecksumCRC32,
+			ChecksumCRC32C:    part.ChecksumCRC32C,
+			ChecksumSHA1:      part.ChecksumSHA1,
+			ChecksumSHA256:    part.ChecksumSHA256,
+			ChecksumCRC64NVME: part.ChecksumCRC64NVME,
+		})
+	}
+
+	// Sort all completed parts.
+	sort.Sort(completedParts(complMultipartUpload.Parts))
+
+	completeOpts := PutObjectOptions{
+		ServerSideEncryption: putOpts.ServerSideEncryption,
+		AutoChecksum:         putOpts.AutoChecksum,
+		Region:               putOpts.Region,
+		UserAgent:            putOpts.UserAgent,
+		RequestPayer:         putOpts.RequestPayer,
+	}
+	if withChecksum {
+		applyAutoChecksum(&completeOpts, state.Parts)
+	}
+
+	info, err = c.completeMultipartUpload(ctx, bucketName, objectName, state.UploadID, complMultipartUpload, completeOpts)
+	if err != nil {
+		return UploadInfo{}, err
+	}
+
+	// The upload is complete, the state is no longer needed.
+	if err = os.Remove(stateFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return UploadInfo{}, err
+	}
+
+	info.Size = size
+	info.PartSize = partSize
+	return info, nil
+}
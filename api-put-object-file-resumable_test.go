@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openstor/openstor-go/v7/pkg/credentials"
+)
+
+// multipartServer is a minimal in-memory multipart upload server.
+type multipartServer struct {
+	sync.Mutex
+	uploads   int
+	aborted   []string
+	parts     map[string]map[int][]byte
+	partPuts  map[int]int
+	failPart  int
+	completed []byte
+}
+
+func (s *multipartServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	query := r.URL.Query()
+	uploadID := query.Get("uploadId")
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		s.uploads++
+		uploadID = fmt.Sprintf("upload-%d", s.uploads)
+		s.parts[uploadID] = make(map[int][]byte)
+		fmt.Fprintf(w, `<InitiateMultipartUploadResult><Bucket>bucket</Bucket><Key>object</Key><UploadId>%s</UploadId></InitiateMultipartUploadResult>`, uploadID)
+	case r.Method == http.MethodPut:
+		partNumber, _ := strconv.Atoi(query.Get("partNumber"))
+		if partNumber == s.failPart {
+			s.failPart = 0
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		s.parts[uploadID][partNumber] = data
+		s.partPuts[partNumber]++
+		sum := md5.Sum(data)
+		w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+	case r.Method == http.MethodGet:
+		parts, ok := s.parts[uploadID]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<Error><Code>NoSuchUpload</Code></Error>`)
+			return
+		}
+		var body string
+		for i := 1; i <= len(parts); i++ {
+			data, ok := parts[i]
+			if !ok {
+				continue
+			}
+			sum := md5.Sum(data)
+			body += fmt.Sprintf(`<Part><PartNumber>%d</PartNumber><ETag>"%s"</ETag><Size>%d</Size></Part>`, i, hex.EncodeToString(sum[:]), len(data))
+		}
+		fmt.Fprintf(w, `<ListPartsResult><UploadId>%s</UploadId><IsTruncated>false</IsTruncated>%s</ListPartsResult>`, uploadID, body)
+	case r.Method == http.MethodPost:
+		parts := s.parts[uploadID]
+		var object []byte
+		for i := 1; i <= len(parts); i++ {
+			object = append(object, parts[i]...)
+		}
+		s.completed = object
+		delete(s.parts, uploadID)
+		fmt.Fprint(w, `<CompleteMultipartUploadResult><Bucket>bucket</Bucket><Key>object</Key><ETag>"etag"</ETag></CompleteMultipartUploadResult>`)
+	case r.Method == http.MethodDelete:
+		s.aborted = append(s.aborted, uploadID)
+		delete(s.parts, uploadID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestFPutObjectResumable(t *testing.T) {
+	mpSrv := &multipartServer{
+		parts:    make(map[string]map[int][]byte),
+		partPuts: make(map[int]int),
+		failPart: 3,
+	}
+	srv := httptest.NewServer(mpSrv)
+	defer srv.Close()
+
+	// Use anonymous credentials to receive the raw part data.
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:  credentials.NewStaticV4("", "", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "data.bin")
+	data := bytes.Repeat([]byte("0123456789abcdef"), (absMinPartSize*2+1024)/16)
+	if err = os.WriteFile(filePath, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	opts := ResumableOptions{Opts: PutObjectOptions{PartSize: absMinPartSize}}
+
+	// The first attempt fails on the last part.
+	if _, err = clnt.FPutObjectResumable(context.Background(), "bucket", "object", filePath, opts); err == nil {
+		t.Fatal("expected the first upload attempt to fail")
+	}
+	if _, err = os.Stat(filePath + ".upload"); err != nil {
+		t.Fatalf("expected the upload state to be kept, %v", err)
+	}
+
+	// The second attempt only uploads the missing part.
+	info, err := clnt.FPutObjectResumable(context.Background(), "bucket", "object", filePath, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size != int64(len(data)) || !bytes.Equal(mpSrv.completed, data) {
+		t.Fatalf("unexpected object of %d bytes", info.Size)
+	}
+	if mpSrv.uploads != 1 || mpSrv.partPuts[1] != 1 || mpSrv.partPuts[2] != 1 || mpSrv.partPuts[3] != 1 {
+		t.Fatalf("expected each part to be uploaded once, got %d uploads and %v", mpSrv.uploads, mpSrv.partPuts)
+	}
+	if _, err = os.Stat(filePath + ".upload"); !os.IsNotExist(err) {
+		t.Fatalf("expected the upload state to be removed, %v", err)
+	}
+
+	// A modified source file restarts the upload.
+	mpSrv.failPart = 2
+	if _, err = clnt.FPutObjectResumable(context.Background(), "bucket", "object", filePath, opts); err == nil {
+		t.Fatal("expected the upload attempt to fail")
+	}
+	modTime := time.Now().Add(time.Minute)
+	if err = os.Chtimes(filePath, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = clnt.FPutObjectResumable(context.Background(), "bucket", "object", filePath, opts); err != nil {
+		t.Fatal(err)
+	}
+	if mpSrv.uploads != 3 || len(mpSrv.aborted) != 1 || mpSrv.aborted[0] != "upload-2" {
+		t.Fatalf("expected the modified file to restart the upload, got %d uploads and aborted %v", mpSrv.uploads, mpSrv.aborted)
+	}
+	if !bytes.Equal(mpSrv.completed, data) {
+		t.Fatal("unexpected object data")
+	}
+}
@@ -61,7 +61,7 @@ func (c *Client) putObjectMultipart(ctx context.Context, bucketName, objectName
 
 func (c *Client) putObjectMultipartNoStream(ctx context.Context, bucketName, objectName string, reader io.Reader, opts PutObjectOptions) (info UploadInfo, err error) {
 	// Input validation.
-	if err = s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err = c.checkBucketName(bucketName); err != nil {
 		return UploadInfo{}, err
 	}
 	if err = s3utils.CheckValidObjectName(objectName); err != nil {
@@ -75,9 +75,8 @@ func (c *Client) putObjectMultipartNoStream(ctx context.Context, bucketName, obj
 	// Complete multipart upload.
 	var complMultipartUpload completeMultipartUpload
 
-	// Calculate the optimal parts info for a given size.
-	totalPartsCount, partSize, _, err := OptimalPartInfo(-1, opts.PartSize)
-	if err != nil {
+	// Validate the configured part size.
+	if _, _, _, err = OptimalPartInfo(-1, opts.PartSize); err != nil {
 		return UploadInfo{}, err
 	}
 
@@ -92,9 +91,10 @@ func (c *Client) putObjectMultipartNoStream(ctx context.Context, bucketName, obj
 		return UploadInfo{}, err
 	}
 
+	keepIncomplete := opts.KeepIncompleteOnError
 	defer func() {
-		if err != nil {
-			c.abortMultipartUpload(ctx, bucketName, objectName, uploadID)
+		if err != nil && !keepIncomplete {
+			c.abortIncompleteUpload(bucketName, objectName, uploadID, opts)
 		}
 	}()
 
@@ -104,14 +104,17 @@ func (c *Client) putObjectMultipartNoStream(ctx context.Context, bucketName, obj
 	// Initialize parts uploaded map.
 	partsInfo := make(map[int]ObjectPart)
 
-	// Create a buffer.
-	buf := make([]byte, partSize)
+	// Create a buffer, grown with the part size.
+	var buf []byte
 
 	// Create checksums
 	// CRC32C is ~50% faster on AMD64 @ 30GB/s
 	customHeader := make(http.Header)
 	crc := opts.AutoChecksum.Hasher()
-	for partNumber <= totalPartsCount {
+	for partNumber <= maxPartsCount {
+		if partSize := unknownSizePartSize(partNumber, opts.PartSize); int64(len(buf)) != partSize {
+			buf = make([]byte, partSize)
+		}
 		length, rErr := readFull(reader, buf)
 		if rErr == io.EOF && partNumber > 1 {
 			break
@@ -155,7 +158,7 @@ func (c *Client) putObjectMultipartNoStream(ctx context.Context, bucketName, obj
 			}
 		}
 
-		p := uploadPartParams{bucketName: bucketName, objectName: objectName, uploadID: uploadID, reader: rd, partNumber: partNumber, md5Base64: md5Base64, sha256Hex: sha256Hex, size: int64(length), sse: opts.ServerSideEncryption, streamSha256: !opts.DisableContentSha256, customHeader: customHeader}
+		p := uploadPartParams{bucketName: bucketName, objectName: objectName, uploadID: uploadID, reader: rd, partNumber: partNumber, md5Base64: md5Base64, sha256Hex: sha256Hex, size: int64(length), sse: opts.ServerSideEncryption, streamSha256: !opts.DisableContentSha256, customHeader: customHeader, region: opts.Region, userAgent: opts.UserAgent, requestPayer: opts.RequestPayer}
 		// Proceed to upload the part.
 		objPart, uerr := c.uploadPart(ctx, p)
 		if uerr != nil {
@@ -172,12 +175,15 @@ func (c *Client) putObjectMultipartNoStream(ctx context.Context, bucketName, obj
 		partNumber++
 
 		// For unknown size, Read EOF we break away.
-		// We do not have to upload till totalPartsCount.
+		// We do not have to upload till maxPartsCount.
 		if rErr == io.EOF {
 			break
 		}
 	}
 
+	// Part size in use when the upload completed.
+	partSize := unknownSizePartSize(partNumber-1, opts.PartSize)
+
 	// Loop over total uploaded parts to save them in
 	// Parts array before completing the multipart request.
 	allParts := make([]ObjectPart, 0, len(partsInfo))
@@ -203,6 +209,9 @@ func (c *Client) putObjectMultipartNoStream(ctx context.Context, bucketName, obj
 	opts = PutObjectOptions{
 		ServerSideEncryption: opts.ServerSideEncryption,
 		AutoChecksum:         opts.AutoChecksum,
+		Region:               opts.Region,
+		UserAgent:            opts.UserAgent,
+		RequestPayer:         opts.RequestPayer,
 	}
 	applyAutoChecksum(&opts, allParts)
 
@@ -212,13 +221,14 @@ func (c *Client) putObjectMultipartNoStream(ctx context.Context, bucketName, obj
 	}
 
 	uploadInfo.Size = totalUploadedSize
+	uploadInfo.PartSize = partSize
 	return uploadInfo, nil
 }
 
 // initiateMultipartUpload - Initiates a multipart upload and returns an upload ID.
 func (c *Client) initiateMultipartUpload(ctx context.Context, bucketName, objectName string, opts PutObjectOptions) (initiateMultipartUploadResult, error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return initiateMultipartUploadResult{}, err
 	}
 	if err := s3utils.CheckValidObjectName(objectName); err != nil {
@@ -242,10 +252,12 @@ func (c *Client) initiateMultipartUpload(ctx context.Context, bucketName, object
 	customHeader := opts.Header()
 
 	reqMetadata := requestMetadata{
-		bucketName:   bucketName,
-		objectName:   objectName,
-		queryValues:  urlValues,
-		customHeader: customHeader,
+		bucketName:     bucketName,
+		objectName:     objectName,
+		bucketLocation: opts.Region,
+		queryValues:    urlValues,
+		customHeader:   customHeader,
+		userAgent:      opts.UserAgent,
 	}
 
 	// Execute POST on an objectName to initiate multipart upload.
@@ -281,12 +293,15 @@ type uploadPartParams struct {
 	streamSha256 bool
 	customHeader http.Header
 	trailer      http.Header
+	region       string
+	userAgent    string
+	requestPayer bool
 }
 
 // uploadPart - Uploads a part in a multipart upload.
 func (c *Client) uploadPart(ctx context.Context, p uploadPartParams) (ObjectPart, error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(p.bucketName); err != nil {
+	if err := c.checkBucketName(p.bucketName); err != nil {
 		return ObjectPart{}, err
 	}
 	if err := s3utils.CheckValidObjectName(p.objectName); err != nil {
@@ -321,12 +336,16 @@ func (c *Client) uploadPart(ctx context.Context, p uploadPartParams) (ObjectPart
 	// Unless you are using a customer-provided encryption key, you don't need
 	// to specify the encryption parameters in each UploadPart request.
 	if p.sse != nil && p.sse.Type() == encrypt.SSEC {
-		p.sse.Marshal(p.customHeader)
+		encrypt.SSE(p.sse).Marshal(p.customHeader)
+	}
+	if p.requestPayer {
+		p.customHeader.Set(amzRequestPayer, requestPayerRequester)
 	}
 
 	reqMetadata := requestMetadata{
 		bucketName:       p.bucketName,
 		objectName:       p.objectName,
+		bucketLocation:   p.region,
 		queryValues:      urlValues,
 		customHeader:     p.customHeader,
 		contentBody:      p.reader,
@@ -335,6 +354,7 @@ func (c *Client) uploadPart(ctx context.Context, p uploadPartParams) (ObjectPart
 		contentSHA256Hex: p.sha256Hex,
 		streamSha256:     p.streamSha256,
 		trailer:          p.trailer,
+		userAgent:        p.userAgent,
 	}
 
 	// Execute PUT on each part.
@@ -369,7 +389,7 @@ func (c *Client) completeMultipartUpload(ctx context.Context, bucketName, object
 	complete completeMultipartUpload, opts PutObjectOptions,
 ) (UploadInfo, error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return UploadInfo{}, err
 	}
 	if err := s3utils.CheckValidObjectName(objectName); err != nil {
@@ -397,11 +417,13 @@ func (c *Client) completeMultipartUpload(ctx context.Context, bucketName, object
 	reqMetadata := requestMetadata{
 		bucketName:           bucketName,
 		objectName:           objectName,
+		bucketLocation:       opts.Region,
 		queryValues:          urlValues,
 		contentBody:          completeMultipartUploadBuffer,
 		contentLength:        int64(len(completeMultipartUploadBytes)),
 		contentSHA256Hex:     sum256Hex(completeMultipartUploadBytes),
 		customHeader:         headers,
+		userAgent:            opts.UserAgent,
 		expect200OKWithError: true,
 	}
 
@@ -462,5 +484,10 @@ func (c *Client) completeMultipartUpload(ctx context.Context, bucketName, object
 		ChecksumCRC32C:    completeMultipartUploadResult.ChecksumCRC32C,
 		ChecksumCRC64NVME: completeMultipartUploadResult.ChecksumCRC64NVME,
 		ChecksumMode:      completeMultipartUploadResult.ChecksumType,
+
+		ServerSideEncryption: resp.Header.Get(encrypt.SseGenericHeader),
+		SSEKMSKeyID:          resp.Header.Get(encrypt.SseKmsKeyID),
+
+		RequestCharged: resp.Header.Get(amzRequestCharged) == requestPayerRequester,
 	}, nil
 }
@@ -99,7 +99,7 @@ func (c *Client) putObjectMultipartStreamFromReadAt(ctx context.Context, bucketN
 	reader io.ReaderAt, size int64, opts PutObjectOptions,
 ) (info UploadInfo, err error) {
 	// Input validation.
-	if err = s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err = c.checkBucketName(bucketName); err != nil {
 		return UploadInfo{}, err
 	}
 	if err = s3utils.CheckValidObjectName(objectName); err != nil {
@@ -124,9 +124,10 @@ func (c *Client) putObjectMultipartStreamFromReadAt(ctx context.Context, bucketN
 	// function returns any error, since we do not resume
 	// we should purge the parts which have been uploaded
 	// to relinquish storage space.
+	keepIncomplete := opts.KeepIncompleteOnError
 	defer func() {
-		if err != nil {
-			c.abortMultipartUpload(ctx, bucketName, objectName, uploadID)
+		if err != nil && !keepIncomplete {
+			c.abortIncompleteUpload(bucketName, objectName, uploadID, opts)
 		}
 	}()
 
@@ -160,8 +161,8 @@ func (c *Client) putObjectMultipartStreamFromReadAt(ctx context.Context, bucketN
 		}
 	}()
 
-	// Receive each part number from the channel allowing three parallel uploads.
-	for w := 1; w <= opts.getNumThreads(); w++ {
+	// Receive each part number from the channel allowing parallel uploads.
+	for w := 1; w <= opts.getMaxConcurrentParts(); w++ {
 		go func(partSize int64) {
 			for {
 				var uploadReq uploadPartReq
@@ -210,6 +211,9 @@ func (c *Client) putObjectMultipartStreamFromReadAt(ctx context.Context, bucketN
 					streamSha256: !opts.DisableContentSha256,
 					sha256Hex:    "",
 					trailer:      trailer,
+					region:       opts.Region,
+					userAgent:    opts.UserAgent,
+					requestPayer: opts.RequestPayer,
 				}
 				objPart, err := c.uploadPart(ctx, p)
 				if err != nil {
@@ -277,6 +281,9 @@ func (c *Client) putObjectMultipartStreamFromReadAt(ctx context.Context, bucketN
 	opts = PutObjectOptions{
 		ServerSideEncryption: opts.ServerSideEncryption,
 		AutoChecksum:         opts.AutoChecksum,
+		Region:               opts.Region,
+		UserAgent:            opts.UserAgent,
+		RequestPayer:         opts.RequestPayer,
 	}
 	if withChecksum {
 		applyAutoChecksum(&opts, allParts)
@@ -288,6 +295,7 @@ func (c *Client) putObjectMultipartStreamFromReadAt(ctx context.Context, bucketN
 	}
 
 	uploadInfo.Size = totalUploadedSize
+	uploadInfo.PartSize = partSize
 	return uploadInfo, nil
 }
 
@@ -295,7 +303,7 @@ func (c *Client) putObjectMultipartStreamOptionalChecksum(ctx context.Context, b
 	reader io.Reader, size int64, opts PutObjectOptions,
 ) (info UploadInfo, err error) {
 	// Input validation.
-	if err = s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err = c.checkBucketName(bucketName); err != nil {
 		return UploadInfo{}, err
 	}
 	if err = s3utils.CheckValidObjectName(objectName); err != nil {
@@ -317,9 +325,10 @@ func (c *Client) putObjectMultipartStreamOptionalChecksum(ctx context.Context, b
 	// any error, since we do not resume we should purge
 	// the parts which have been uploaded to relinquish
 	// storage space.
+	keepIncomplete := opts.KeepIncompleteOnError
 	defer func() {
-		if err != nil {
-			c.abortMultipartUpload(ctx, bucketName, objectName, uploadID)
+		if err != nil && !keepIncomplete {
+			c.abortIncompleteUpload(bucketName, objectName, uploadID, opts)
 		}
 	}()
 
@@ -381,7 +390,7 @@ func (c *Client) putObjectMultipartStreamOptionalChecksum(ctx context.Context, b
 		// Update progress reader appropriately to the latest offset
 		// as we read from the source.
 		hooked := newHook(bytes.NewReader(buf[:length]), opts.Progress)
-		p := uploadPartParams{bucketName: bucketName, objectName: objectName, uploadID: uploadID, reader: hooked, partNumber: partNumber, md5Base64: md5Base64, size: partSize, sse: opts.ServerSideEncryption, streamSha256: !opts.DisableContentSha256, customHeader: customHeader}
+		p := uploadPartParams{bucketName: bucketName, objectName: objectName, uploadID: uploadID, reader: hooked, partNumber: partNumber, md5Base64: md5Base64, size: partSize, sse: opts.ServerSideEncryption, streamSha256: !opts.DisableContentSha256, customHeader: customHeader, region: opts.Region, userAgent: opts.UserAgent, requestPayer: opts.RequestPayer}
 		objPart, uerr := c.uploadPart(ctx, p)
 		if uerr != nil {
 			return UploadInfo{}, uerr
@@ -430,6 +439,9 @@ func (c *Client) putObjectMultipartStreamOptionalChecksum(ctx context.Context, b
 	opts = PutObjectOptions{
 		ServerSideEncryption: opts.ServerSideEncryption,
 		AutoChecksum:         opts.AutoChecksum,
+		Region:               opts.Region,
+		UserAgent:            opts.UserAgent,
+		RequestPayer:         opts.RequestPayer,
 	}
 	applyAutoChecksum(&opts, allParts)
 	uploadInfo, err := c.completeMultipartUpload(ctx, bucketName, objectName, uploadID, complMultipartUpload, opts)
@@ -438,16 +450,18 @@ func (c *Client) putObjectMultipartStreamOptionalChecksum(ctx context.Context, b
 	}
 
 	uploadInfo.Size = totalUploadedSize
+	uploadInfo.PartSize = int64(len(buf))
 	return uploadInfo, nil
 }
 
-// putObjectMultipartStreamParallel uploads opts.NumThreads parts in parallel.
-// This is expected to take opts.PartSize * opts.NumThreads * (GOGC / 100) bytes of buffer.
+// putObjectMultipartStreamParallel uploads opts.NumThreads parts in parallel,
+// or opts.MaxConcurrentParts when lower. This is expected to take
+// opts.PartSize * parallel parts * (GOGC / 100) bytes of buffer.
 func (c *Client) putObjectMultipartStreamParallel(ctx context.Context, bucketName, objectName string,
 	reader io.Reader, opts PutObjectOptions,
 ) (info UploadInfo, err error) {
 	// Input validation.
-	if err = s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err = c.checkBucketName(bucketName); err != nil {
 		return UploadInfo{}, err
 	}
 
@@ -459,9 +473,8 @@ func (c *Client) putObjectMultipartStreamParallel(ctx context.Context, bucketNam
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	// Calculate the optimal parts info for a given size.
-	totalPartsCount, partSize, _, err := OptimalPartInfo(-1, opts.PartSize)
-	if err != nil {
+	// Validate the configured part size.
+	if _, _, _, err = OptimalPartInfo(-1, opts.PartSize); err != nil {
 		return UploadInfo{}, err
 	}
 
@@ -475,24 +488,22 @@ func (c *Client) putObjectMultipartStreamParallel(ctx context.Context, bucketNam
 	// any error, since we do not resume we should purge
 	// the parts which have been uploaded to relinquish
 	// storage space.
+	keepIncomplete := opts.KeepIncompleteOnError
 	defer func() {
-		if err != nil {
-			c.abortMultipartUpload(ctx, bucketName, objectName, uploadID)
+		if err != nil && !keepIncomplete {
+			c.abortIncompleteUpload(bucketName, objectName, uploadID, opts)
 		}
 	}()
 
-	// Create checksums
-	// CRC32C is ~50% faster on AMD64 @ 30GB/s
-	crc := opts.AutoChecksum.Hasher()
-
 	// Total data read and written to server. should be equal to 'size' at the end of the call.
 	var totalUploadedSize int64
 
 	// Initialize parts uploaded map.
 	partsInfo := make(map[int]ObjectPart)
 
-	// Create a buffer.
-	nBuffers := int64(opts.NumThreads)
+	// Create a buffer, buffers are reallocated as the part size grows.
+	partSize := unknownSizePartSize(1, opts.PartSize)
+	nBuffers := int64(opts.getMaxConcurrentParts())
 	bufs := make(chan []byte, nBuffers)
 	all := make([]byte, nBuffers*partSize)
 	for i := int64(0); i < nBuffers; i++ {
@@ -501,13 +512,13 @@ func (c *Client) putObjectMultipartStreamParallel(ctx context.Context, bucketNam
 
 	var wg sync.WaitGroup
 	var mu sync.Mutex
-	errCh := make(chan error, opts.NumThreads)
+	errCh := make(chan error, nBuffers)
 
 	reader = newHook(reader, opts.Progress)
 
 	// Part number always starts with '1'.
 	var partNumber int
-	for partNumber = 1; partNumber <= totalPartsCount; partNumber++ {
+	for partNumber = 1; partNumber <= maxPartsCount; partNumber++ {
 		// Proceed to upload the part.
 		var buf []byte
 		select {
@@ -518,8 +529,8 @@ func (c *Client) putObjectMultipartStreamParallel(ctx context.Context, bucketNam
 			return UploadInfo{}, err
 		}
 
-		if int64(len(buf)) != partSize {
-			return UploadInfo{}, fmt.Errorf("read buffer < %d than expected partSize: %d", len(buf), partSize)
+		if partSize = unknownSizePartSize(partNumber, opts.PartSize); int64(len(buf)) != partSize {
+			buf = make([]byte, partSize)
 		}
 
 		length, rerr := readFull(reader, buf)
@@ -539,8 +550,9 @@ func (c *Client) putObjectMultipartStreamParallel(ctx context.Context, bucketNam
 			// Calculate md5sum.
 			customHeader := make(http.Header)
 			if opts.AutoChecksum.IsSet() {
-				// Add Checksum instead.
-				crc.Reset()
+				// Add Checksum instead, parts are hashed concurrently.
+				// CRC32C is ~50% faster on AMD64 @ 30GB/s
+				crc := opts.AutoChecksum.Hasher()
 				crc.Write(buf[:length])
 				cSum := crc.Sum(nil)
 				customHeader.Set(opts.AutoChecksum.Key(), base64.StdEncoding.EncodeToString(cSum))
@@ -572,6 +584,9 @@ func (c *Client) putObjectMultipartStreamParallel(ctx context.Context, bucketNam
 				sse:          opts.ServerSideEncryption,
 				streamSha256: !opts.DisableContentSha256,
 				customHeader: customHeader,
+				region:       opts.Region,
+				userAgent:    opts.UserAgent,
+				requestPayer: opts.RequestPayer,
 			}
 			objPart, uerr := c.uploadPart(ctx, p)
 			if uerr != nil {
@@ -603,6 +618,9 @@ func (c *Client) putObjectMultipartStreamParallel(ctx context.Context, bucketNam
 	// Complete multipart upload.
 	var complMultipartUpload completeMultipartUpload
 
+	// Part size in use when the upload completed.
+	partSize = unknownSizePartSize(partNumber-1, opts.PartSize)
+
 	// Loop over total uploaded parts to save them in
 	// Parts array before completing the multipart request.
 	allParts := make([]ObjectPart, 0, len(partsInfo))
@@ -629,6 +647,9 @@ func (c *Client) putObjectMultipartStreamParallel(ctx context.Context, bucketNam
 	opts = PutObjectOptions{
 		ServerSideEncryption: opts.ServerSideEncryption,
 		AutoChecksum:         opts.AutoChecksum,
+		Region:               opts.Region,
+		UserAgent:            opts.UserAgent,
+		RequestPayer:         opts.RequestPayer,
 	}
 	applyAutoChecksum(&opts, allParts)
 
@@ -638,6 +659,7 @@ func (c *Client) putObjectMultipartStreamParallel(ctx context.Context, bucketNam
 	}
 
 	uploadInfo.Size = totalUploadedSize
+	uploadInfo.PartSize = partSize
 	return uploadInfo, nil
 }
 
@@ -645,7 +667,7 @@ func (c *Client) putObjectMultipartStreamParallel(ctx context.Context, bucketNam
 // is used for Google Cloud Storage since Google's multipart API is not S3 compatible.
 func (c *Client) putObject(ctx context.Context, bucketName, objectName string, reader io.Reader, size int64, opts PutObjectOptions) (info UploadInfo, err error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return UploadInfo{}, err
 	}
 	if err := s3utils.CheckValidObjectName(objectName); err != nil {
@@ -721,7 +743,7 @@ func (c *Client) putObject(ctx context.Context, bucketName, objectName string, r
 // NOTE: You must have WRITE permissions on a bucket to add an object to it.
 func (c *Client) putObjectDo(ctx context.Context, bucketName, objectName string, reader io.Reader, md5Base64, sha256Hex string, size int64, opts PutObjectOptions) (UploadInfo, error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return UploadInfo{}, err
 	}
 	if err := s3utils.CheckValidObjectName(objectName); err != nil {
@@ -734,12 +756,14 @@ func (c *Client) putObjectDo(ctx context.Context, bucketName, objectName string,
 	reqMetadata := requestMetadata{
 		bucketName:       bucketName,
 		objectName:       objectName,
+		bucketLocation:   opts.Region,
 		customHeader:     customHeader,
 		contentBody:      reader,
 		contentLength:    size,
 		contentMD5Base64: md5Base64,
 		contentSHA256Hex: sha256Hex,
 		streamSha256:     !opts.DisableContentSha256,
+		userAgent:        opts.UserAgent,
 	}
 	// Add CRC when client supports it, MD5 is not set, not Google and we don't add SHA256 to chunks.
 	addCrc := c.trailingHeaderSupport && md5Base64 == "" && !s3utils.IsGoogleEndpoint(*c.endpointURL) && (opts.DisableContentSha256 || c.secure)
@@ -800,5 +824,7 @@ func (c *Client) putObjectDo(ctx context.Context, bucketName, objectName string,
 		ChecksumSHA256:    h.Get(ChecksumSHA256.Key()),
 		ChecksumCRC64NVME: h.Get(ChecksumCRC64NVME.Key()),
 		ChecksumMode:      h.Get(ChecksumFullObjectMode.Key()),
+
+		RequestCharged: h.Get(amzRequestCharged) == requestPayerRequester,
 	}, nil
 }
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/openstor/openstor-go/v7/pkg/encrypt"
+	"github.com/openstor/openstor-go/v7/pkg/s3utils"
+)
+
+// URLUploadInfo - upload info of PutObjectFromURL.
+type URLUploadInfo struct {
+	UploadInfo
+
+	// ServerSideFetch is set when the server fetched the source
+	// itself, otherwise the source was streamed through the client.
+	ServerSideFetch bool
+}
+
+// PutObjectFromURLOptions represents options for PutObjectFromURL.
+type PutObjectFromURLOptions struct {
+	PutObjectOptions
+
+	// ServerSideFetch asks the server to fetch the source URL itself,
+	// through a copy request with the URL as the copy source. Only
+	// gateways implementing such fetches accept it, S3 and MinIO only
+	// copy from "bucket/object" sources. Servers answering NotImplemented
+	// get the source streamed through the client instead.
+	ServerSideFetch bool
+}
+
+// PutObjectFromURL - Create an object in a bucket, with contents fetched
+// from sourceURL. The URL is downloaded and its body streamed through
+// PutObject, unless the server is asked to fetch it itself with
+// opts.ServerSideFetch. The source is fetched with the transport of the
+// client, but without Options.RootCAs and Options.CertificatePins which
+// only verify the endpoint.
+func (c *Client) PutObjectFromURL(ctx context.Context, bucketName, objectName, sourceURL string, opts PutObjectFromURLOptions) (URLUploadInfo, error) {
+	// Input validation.
+	if err := c.checkBucketName(bucketName); err != nil {
+		return URLUploadInfo{}, err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return URLUploadInfo{}, err
+	}
+	u, err := url.Parse(sourceURL)
+	if err != nil {
+		return URLUploadInfo{}, errInvalidArgument(fmt.Sprintf("Invalid source URL: %v", err))
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return URLUploadInfo{}, errInvalidArgument("Source URL must be an absolute http or https URL.")
+	}
+
+	if opts.ServerSideFetch {
+		info, err := c.putObjectServerSideFetch(ctx, bucketName, objectName, sourceURL, opts.PutObjectOptions)
+		if err == nil {
+			return URLUploadInfo{UploadInfo: info, ServerSideFetch: true}, nil
+		}
+		// Stream the source only if server-side fetch is not
+		// supported, other errors are those of the destination.
+		if errResp := ToErrorResponse(err); errResp.Code != NotImplemented && errResp.StatusCode != http.StatusNotImplemented {
+			return URLUploadInfo{}, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return URLUploadInfo{}, err
+	}
+	resp, err := c.sourceClient.Do(req)
+	defer closeResponse(resp)
+	if err != nil {
+		return URLUploadInfo{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return URLUploadInfo{}, errInvalidArgument(fmt.Sprintf("Fetching source URL failed: %s", resp.Status))
+	}
+
+	// Never read more than announced by the source, or more than
+	// the largest object size allowed when the size is unknown.
+	size := resp.ContentLength
+	limit := size
+	if limit < 0 {
+		limit = maxMultipartPutObjectSize
+	}
+	info, err := c.PutObject(ctx, bucketName, objectName, io.LimitReader(resp.Body, limit), size, opts.PutObjectOptions)
+	if err != nil {
+		return URLUploadInfo{}, err
+	}
+	return URLUploadInfo{UploadInfo: info}, nil
+}
+
+// putObjectServerSideFetch asks the server to copy the object from
+// sourceURL, through a copy request with the URL as the copy source.
+func (c *Client) putObjectServerSideFetch(ctx context.Context, bucketName, objectName, sourceURL string, opts PutObjectOptions) (UploadInfo, error) {
+	headers := opts.Header()
+	headers.Set("x-amz-copy-source", sourceURL)
+	headers.Set("x-amz-metadata-directive", "REPLACE")
+
+	resp, err := c.executeMethod(ctx, http.MethodPut, requestMetadata{
+		bucketName:     bucketName,
+		objectName:     objectName,
+		bucketLocation: opts.Region,
+		customHeader:   headers,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return UploadInfo{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return UploadInfo{}, httpRespToErrorResponse(resp, bucketName, objectName)
+	}
+
+	cpObjRes := copyObjectResult{}
+	if err = xmlDecoder(resp.Body, &cpObjRes); err != nil {
+		return UploadInfo{}, err
+	}
+
+	// extract lifecycle expiry date and rule ID
+	expTime, ruleID := amzExpirationToExpiryDateRuleID(resp.Header.Get(amzExpiration))
+
+	return UploadInfo{
+		Bucket:           bucketName,
+		Key:              objectName,
+		LastModified:     cpObjRes.LastModified,
+		ETag:             trimEtag(cpObjRes.ETag),
+		VersionID:        resp.Header.Get(amzVersionID),
+		Expiration:       expTime,
+		ExpirationRuleID: ruleID,
+
+		ServerSideEncryption: resp.Header.Get(encrypt.SseGenericHeader),
+		SSEKMSKeyID:          resp.Header.Get(encrypt.SseKmsKeyID),
+	}, nil
+}
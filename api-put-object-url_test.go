@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openstor/openstor-go/v7/pkg/credentials"
+)
+
+func TestPutObjectFromURL(t *testing.T) {
+	const content = "remote object content"
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		io.WriteString(w, content)
+	}))
+	defer source.Close()
+
+	testCases := []struct {
+		serverSideFetch bool
+		// code answered to the server-side fetch, empty on success.
+		code   string
+		status int
+		// fetched is set when the server fetched the source itself.
+		fetched  bool
+		streamed bool
+	}{
+		{true, "", http.StatusOK, true, false},
+		{true, NotImplemented, http.StatusNotImplemented, false, true},
+		{false, "", http.StatusOK, false, true},
+		{true, NoSuchBucket, http.StatusNotFound, false, false},
+		{true, InvalidArgument, http.StatusBadRequest, false, false},
+	}
+
+	for i, testCase := range testCases {
+		var uploaded string
+		var copied bool
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if copySource := r.Header.Get("x-amz-copy-source"); copySource != "" {
+				copied = true
+				if copySource != source.URL+"/object" {
+					t.Errorf("Test %d: unexpected copy source %q", i+1, copySource)
+				}
+				if testCase.code != "" {
+					w.WriteHeader(testCase.status)
+					fmt.Fprintf(w, `<Error><Code>%s</Code><Message>Copy failed</Message></Error>`, testCase.code)
+					return
+				}
+				fmt.Fprint(w, `<CopyObjectResult><ETag>"fetched"</ETag><LastModified>2025-01-01T00:00:00.000Z</LastModified></CopyObjectResult>`)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			uploaded = string(body)
+			w.Header().Set("ETag", `"streamed"`)
+		}))
+
+		// Use anonymous credentials to receive the raw object data.
+		clnt, err := New(srv.Listener.Addr().String(), &Options{
+			Creds:  credentials.NewStaticV4("", "", ""),
+			Region: "us-east-1",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		info, err := clnt.PutObjectFromURL(context.Background(), "bucket", "object", source.URL+"/object", PutObjectFromURLOptions{
+			ServerSideFetch: testCase.serverSideFetch,
+		})
+		srv.Close()
+		if copied != testCase.serverSideFetch {
+			t.Fatalf("Test %d: expected server-side fetch to be requested %t", i+1, testCase.serverSideFetch)
+		}
+		if !testCase.fetched && !testCase.streamed {
+			if ToErrorResponse(err).Code != testCase.code || uploaded != "" {
+				t.Fatalf("Test %d: expected the %s error to be returned, got %v", i+1, testCase.code, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if info.ServerSideFetch != testCase.fetched {
+			t.Fatalf("Test %d: expected server-side fetch %t, got %t", i+1, testCase.fetched, info.ServerSideFetch)
+		}
+		if testCase.fetched {
+			if info.ETag != "fetched" || uploaded != "" {
+				t.Fatalf("Test %d: unexpected upload %+v", i+1, info)
+			}
+			continue
+		}
+		if info.ETag != "streamed" || uploaded != content || info.Size != int64(len(content)) {
+			t.Fatalf("Test %d: unexpected upload %+v of %q", i+1, info, uploaded)
+		}
+	}
+}
+
+func TestPutObjectFromURLInvalidSource(t *testing.T) {
+	clnt, err := New("localhost:9000", &Options{
+		Creds: credentials.NewStaticV4("accessKey", "secretKey", ""),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, sourceURL := range []string{"", "ftp://host/object", "/relative/object", "http://"} {
+		if _, err = clnt.PutObjectFromURL(context.Background(), "bucket", "object", sourceURL, PutObjectFromURLOptions{}); err == nil {
+			t.Fatalf("Test %d: expected %q to be rejected", i+1, sourceURL)
+		}
+	}
+}
+
+func TestPutObjectFromURLCertificatePins(t *testing.T) {
+	const content = "remote object content"
+
+	// The source has its own certificate, neither pinned nor in
+	// Options.RootCAs.
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		io.WriteString(w, content)
+	}))
+	source.TLS = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+	source.StartTLS()
+	defer source.Close()
+
+	var uploaded string
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		uploaded = string(body)
+		w.Header().Set("ETag", `"streamed"`)
+	}))
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	defer srv.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(srv.Certificate())
+	pin := sha256.Sum256(srv.Certificate().RawSubjectPublicKeyInfo)
+	sourceCAs := x509.NewCertPool()
+	sourceCAs.AddCert(source.Certificate())
+
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:           credentials.NewStaticV4("accessKey", "secretKey", ""),
+		Region:          "us-east-1",
+		Secure:          true,
+		Transport:       &http.Transport{TLSClientConfig: &tls.Config{RootCAs: sourceCAs}},
+		RootCAs:         rootCAs,
+		CertificatePins: [][]byte{pin[:]},
+		MaxRetries:      1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clnt.Close()
+
+	// The endpoint is verified against the pins, the source against
+	// the transport only.
+	info, err := clnt.PutObjectFromURL(context.Background(), "bucket", "object", source.URL+"/object", PutObjectFromURLOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ETag != "streamed" || uploaded != content {
+		t.Fatalf("unexpected upload %+v of %q", info, uploaded)
+	}
+}
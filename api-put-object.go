@@ -112,7 +112,52 @@ type PutObjectOptions struct {
 	// fill them serially and upload them in parallel.
 	// This can be used for faster uploads on non-seekable or slow-to-seek input.
 	ConcurrentStreamParts bool
-	Internal              AdvancedPutOptions
+
+	// MaxConcurrentParts bounds the number of parts buffered or being
+	// uploaded at once by a multipart upload, reading from the source
+	// blocks until an uploaded part frees its slot. Memory used by
+	// ConcurrentStreamParts is then at most MaxConcurrentParts * PartSize
+	// bytes. Defaults to, and is capped by, NumThreads.
+	MaxConcurrentParts int
+
+	// ProgressFunc is invoked with the number of bytes uploaded so far
+	// and the object size as data is sent, including during each part of
+	// a multipart upload. Calls are never made concurrently. totalBytes
+	// is -1 when the object size is unknown.
+	ProgressFunc func(bytesTransferred, totalBytes int64)
+
+	// KeepIncompleteOnError disables aborting the multipart upload
+	// when the upload fails or its context is canceled, the uploaded
+	// parts are then kept on the server until explicitly removed.
+	KeepIncompleteOnError bool
+
+	// Region is the region the requests are signed for, bypassing the
+	// cached bucket location. Useful when the location guessed for a
+	// bucket on an S3 compatible backend is wrong.
+	Region string
+
+	// RequestPayer sends "x-amz-request-payer: requester" with the
+	// requests of the upload, to write to Requester Pays buckets.
+	RequestPayer bool
+
+	// UserAgent is a token appended to the User-Agent of the client
+	// for the requests of this upload, e.g. "myapp/1.0".
+	UserAgent string
+
+	// Compress compresses the content before uploading it, setting
+	// Content-Encoding accordingly and storing the uncompressed size,
+	// when known, in the UncompressedSizeMetaKey user-metadata. Content
+	// with a ContentEncoding or an already compressed ContentType, such
+	// as images or archives, is uploaded as is. Uploads larger than a
+	// part or of unknown size are compressed while being uploaded with
+	// a multipart upload. With DisableMultipart they are uploaded with
+	// PutObjectStreaming, as a single request of known size if their
+	// compressed content fits into a part, of unknown length, which only
+	// some gateways accept, otherwise. GetObjectOptions.Decompress
+	// decodes them.
+	Compress ContentCompression
+
+	Internal AdvancedPutOptions
 
 	customHeaders http.Header
 }
@@ -156,6 +201,16 @@ func (opts PutObjectOptions) getNumThreads() (numThreads int) {
 	return numThreads
 }
 
+// getMaxConcurrentParts - gets the number of parts buffered or uploaded
+// at once in the multipart put object operation
+func (opts PutObjectOptions) getMaxConcurrentParts() int {
+	numThreads := opts.getNumThreads()
+	if opts.MaxConcurrentParts > 0 {
+		return min(opts.MaxConcurrentParts, numThreads)
+	}
+	return numThreads
+}
+
 // Header - constructs the headers from metadata entered by user in
 // PutObjectOptions struct
 func (opts PutObjectOptions) Header() (header http.Header) {
@@ -197,7 +252,7 @@ func (opts PutObjectOptions) Header() (header http.Header) {
 	}
 
 	if opts.ServerSideEncryption != nil {
-		opts.ServerSideEncryption.Marshal(header)
+		encrypt.SSE(opts.ServerSideEncryption).Marshal(header)
 	}
 
 	if opts.StorageClass != "" {
@@ -208,6 +263,10 @@ func (opts PutObjectOptions) Header() (header http.Header) {
 		header.Set(amzWebsiteRedirectLocation, opts.WebsiteRedirectLocation)
 	}
 
+	if opts.RequestPayer {
+		header.Set(amzRequestPayer, requestPayerRequester)
+	}
+
 	if !opts.Internal.ReplicationStatus.Empty() {
 		header.Set(amzBucketReplicationStatus, string(opts.Internal.ReplicationStatus))
 	}
@@ -271,6 +330,12 @@ func (opts PutObjectOptions) validate(c *Client) (err error) {
 	if opts.LegalHold != "" && !opts.LegalHold.IsValid() {
 		return errInvalidArgument(opts.LegalHold.String() + " unsupported legal-hold status")
 	}
+	if opts.MaxConcurrentParts < 0 {
+		return errInvalidArgument("MaxConcurrentParts cannot be negative")
+	}
+	if !opts.Compress.IsValid() {
+		return errInvalidArgument(string(opts.Compress) + " unsupported compression")
+	}
 
 	checkCrc := false
 	for k := range opts.UserMetadata {
@@ -332,6 +397,25 @@ func (c *Client) PutObject(ctx context.Context, bucketName, objectName string, r
 		return UploadInfo{}, err
 	}
 
+	if opts.compress() {
+		var compressed io.ReadCloser
+		compressed, size, opts, err = compressUpload(reader, size, opts)
+		if err != nil {
+			return UploadInfo{}, err
+		}
+		defer compressed.Close()
+		reader = compressed
+		// The compressed size of large uploads is only known once
+		// compressed, PutObjectStreaming reads a part of it first.
+		if size < 0 && opts.DisableMultipart {
+			return c.PutObjectStreaming(ctx, bucketName, objectName, reader, opts)
+		}
+	}
+
+	if opts.ProgressFunc != nil {
+		opts.Progress = newProgressFuncHook(opts.Progress, opts.ProgressFunc, size)
+	}
+
 	// Check for largest object size allowed.
 	if size > int64(maxMultipartPutObjectSize) {
 		return UploadInfo{}, errEntityTooLarge(size, maxMultipartPutObjectSize, bucketName, objectName)
@@ -383,7 +467,7 @@ func (c *Client) PutObject(ctx context.Context, bucketName, objectName string, r
 
 func (c *Client) putObjectMultipartStreamNoLength(ctx context.Context, bucketName, objectName string, reader io.Reader, opts PutObjectOptions) (info UploadInfo, err error) {
 	// Input validation.
-	if err = s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err = c.checkBucketName(bucketName); err != nil {
 		return UploadInfo{}, err
 	}
 	if err = s3utils.CheckValidObjectName(objectName); err != nil {
@@ -397,9 +481,8 @@ func (c *Client) putObjectMultipartStreamNoLength(ctx context.Context, bucketNam
 	// Complete multipart upload.
 	var complMultipartUpload completeMultipartUpload
 
-	// Calculate the optimal parts info for a given size.
-	totalPartsCount, partSize, _, err := OptimalPartInfo(-1, opts.PartSize)
-	if err != nil {
+	// Validate the configured part size.
+	if _, _, _, err = OptimalPartInfo(-1, opts.PartSize); err != nil {
 		return UploadInfo{}, err
 	}
 
@@ -409,9 +492,10 @@ func (c *Client) putObjectMultipartStreamNoLength(ctx context.Context, bucketNam
 		return UploadInfo{}, err
 	}
 
+	keepIncomplete := opts.KeepIncompleteOnError
 	defer func() {
-		if err != nil {
-			c.abortMultipartUpload(ctx, bucketName, objectName, uploadID)
+		if err != nil && !keepIncomplete {
+			c.abortIncompleteUpload(bucketName, objectName, uploadID, opts)
 		}
 	}()
 
@@ -421,15 +505,18 @@ func (c *Client) putObjectMultipartStreamNoLength(ctx context.Context, bucketNam
 	// Initialize parts uploaded map.
 	partsInfo := make(map[int]ObjectPart)
 
-	// Create a buffer.
-	buf := make([]byte, partSize)
+	// Create a buffer, grown with the part size.
+	var buf []byte
 
 	// Create checksums
 	// CRC32C is ~50% faster on AMD64 @ 30GB/s
 	customHeader := make(http.Header)
 	crc := opts.AutoChecksum.Hasher()
 
-	for partNumber <= totalPartsCount {
+	for partNumber <= maxPartsCount {
+		if partSize := unknownSizePartSize(partNumber, opts.PartSize); int64(len(buf)) != partSize {
+			buf = make([]byte, partSize)
+		}
 		length, rerr := readFull(reader, buf)
 		if rerr == io.EOF && partNumber > 1 {
 			break
@@ -464,7 +551,7 @@ func (c *Client) putObjectMultipartStreamNoLength(ctx context.Context, bucketNam
 		rd := newHook(bytes.NewReader(buf[:length]), opts.Progress)
 
 		// Proceed to upload the part.
-		p := uploadPartParams{bucketName: bucketName, objectName: objectName, uploadID: uploadID, reader: rd, partNumber: partNumber, md5Base64: md5Base64, size: int64(length), sse: opts.ServerSideEncryption, streamSha256: !opts.DisableContentSha256, customHeader: customHeader}
+		p := uploadPartParams{bucketName: bucketName, objectName: objectName, uploadID: uploadID, reader: rd, partNumber: partNumber, md5Base64: md5Base64, size: int64(length), sse: opts.ServerSideEncryption, streamSha256: !opts.DisableContentSha256, customHeader: customHeader, region: opts.Region, userAgent: opts.UserAgent, requestPayer: opts.RequestPayer}
 		objPart, uerr := c.uploadPart(ctx, p)
 		if uerr != nil {
 			return UploadInfo{}, uerr
@@ -480,12 +567,15 @@ func (c *Client) putObjectMultipartStreamNoLength(ctx context.Context, bucketNam
 		partNumber++
 
 		// For unknown size, Read EOF we break away.
-		// We do not have to upload till totalPartsCount.
+		// We do not have to upload till maxPartsCount.
 		if rerr == io.EOF {
 			break
 		}
 	}
 
+	// Part size in use when the upload completed.
+	partSize := unknownSizePartSize(partNumber-1, opts.PartSize)
+
 	// Loop over total uploaded parts to save them in
 	// Parts array before completing the multipart request.
 	allParts := make([]ObjectPart, 0, len(partsInfo))
@@ -512,6 +602,9 @@ func (c *Client) putObjectMultipartStreamNoLength(ctx context.Context, bucketNam
 	opts = PutObjectOptions{
 		ServerSideEncryption: opts.ServerSideEncryption,
 		AutoChecksum:         opts.AutoChecksum,
+		Region:               opts.Region,
+		UserAgent:            opts.UserAgent,
+		RequestPayer:         opts.RequestPayer,
 	}
 	applyAutoChecksum(&opts, allParts)
 
@@ -521,5 +614,6 @@ func (c *Client) putObjectMultipartStreamNoLength(ctx context.Context, bucketNam
 	}
 
 	uploadInfo.Size = totalUploadedSize
+	uploadInfo.PartSize = partSize
 	return uploadInfo, nil
 }
@@ -20,12 +20,21 @@
 package openstor
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/openstor/openstor-go/v7/pkg/credentials"
 	"github.com/openstor/openstor-go/v7/pkg/encrypt"
 )
 
@@ -169,3 +178,301 @@ func Test_SSEHeaders(t *testing.T) {
 		})
 	}
 }
+
+func TestPutObjectMultipartAbortOnError(t *testing.T) {
+	for _, keepIncomplete := range []bool{false, true} {
+		var aborted int32
+		clnt, srv := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodPost && r.URL.Query().Has("uploads"):
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`<InitiateMultipartUploadResult><Bucket>bucket</Bucket><Key>object</Key><UploadId>upload-id</UploadId></InitiateMultipartUploadResult>`))
+			case r.Method == http.MethodDelete && r.URL.Query().Get("uploadId") == "upload-id":
+				atomic.AddInt32(&aborted, 1)
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`<Error><Code>InvalidArgument</Code><Message>rejected part</Message></Error>`))
+			}
+		}))
+
+		_, err := clnt.putObjectMultipartNoStream(context.Background(), "bucket", "object", bytes.NewReader([]byte("data")), PutObjectOptions{
+			KeepIncompleteOnError: keepIncomplete,
+		})
+		srv.Close()
+		if err == nil {
+			t.Fatal("expected upload to fail")
+		}
+
+		expected := int32(1)
+		if keepIncomplete {
+			expected = 0
+		}
+		if n := atomic.LoadInt32(&aborted); n != expected {
+			t.Fatalf("KeepIncompleteOnError=%t: expected %d aborts, got %d", keepIncomplete, expected, n)
+		}
+	}
+}
+
+func TestPutObjectProgressFunc(t *testing.T) {
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Header().Set("ETag", `"etag"`)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	data := bytes.Repeat([]byte("a"), 1024)
+	var calls, transferred, total int64
+	_, err := clnt.PutObject(context.Background(), "bucket", "object", bytes.NewReader(data), int64(len(data)), PutObjectOptions{
+		ProgressFunc: func(bytesTransferred, totalBytes int64) {
+			calls++
+			transferred, total = bytesTransferred, totalBytes
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls == 0 || transferred != int64(len(data)) || total != int64(len(data)) {
+		t.Fatalf("expected %d/%d bytes reported, got %d/%d after %d calls", len(data), len(data), transferred, total, calls)
+	}
+}
+
+func TestPutObjectSSEWithFallback(t *testing.T) {
+	var applied []string
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		sse := r.Header.Get(encrypt.SseGenericHeader)
+		applied = append(applied, sse)
+		if sse == "aws:kms" {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `<Error><Code>KMS.NotFoundException</Code><Message>Invalid keyId</Message></Error>`)
+			return
+		}
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set(encrypt.SseGenericHeader, sse)
+	}))
+
+	kms, err := encrypt.NewSSEKMS("missing-key", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sse := encrypt.NewSSEWithFallback(kms, encrypt.NewSSE())
+	if sse.Type() != encrypt.KMS || encrypt.Fallback(kms) != nil {
+		t.Fatalf("unexpected server-side-encryption %v", sse.Type())
+	}
+
+	data := []byte("object data")
+	_, err = clnt.PutObject(context.Background(), "bucket", "object", bytes.NewReader(data), int64(len(data)), PutObjectOptions{ServerSideEncryption: sse})
+	if !IsKMSUnavailable(err) {
+		t.Fatalf("expected a KMS error, got %v", err)
+	}
+
+	info, err := clnt.PutObject(context.Background(), "bucket", "object", bytes.NewReader(data), int64(len(data)), PutObjectOptions{ServerSideEncryption: encrypt.Fallback(sse)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ETag != "etag" || len(applied) != 2 || applied[1] != "AES256" {
+		t.Fatalf("expected the fallback to be applied, got %v", applied)
+	}
+
+	if IsKMSUnavailable(ErrorResponse{Code: NotImplemented, Message: "A header you provided implies functionality that is not implemented"}) {
+		t.Fatal("expected a non KMS error not to be reported as KMS unavailable")
+	}
+	if !IsKMSUnavailable(ErrorResponse{Code: NotImplemented, Message: "Server side encryption specified but KMS is not configured"}) {
+		t.Fatal("expected a missing KMS to be reported as KMS unavailable")
+	}
+}
+
+func TestPutObjectPartSize(t *testing.T) {
+	testCases := []struct {
+		size     int
+		length   int64
+		opts     PutObjectOptions
+		expected int64
+	}{
+		// Unknown size, the part size grows from minPartSize.
+		{1024 * 1024, -1, PutObjectOptions{}, minPartSize},
+		{1024 * 1024, -1, PutObjectOptions{ConcurrentStreamParts: true, NumThreads: 2}, minPartSize},
+		{1024 * 1024, -1, PutObjectOptions{DisableContentSha256: true}, minPartSize},
+		// Unknown size with a configured part size.
+		{absMinPartSize + 1024, -1, PutObjectOptions{PartSize: absMinPartSize}, absMinPartSize},
+		// Known size.
+		{absMinPartSize + 1024, absMinPartSize + 1024, PutObjectOptions{PartSize: absMinPartSize}, absMinPartSize},
+		{minPartSize + 1024, minPartSize + 1024, PutObjectOptions{}, minPartSize},
+	}
+
+	for i, testCase := range testCases {
+		mpSrv := &multipartServer{
+			parts:    make(map[string]map[int][]byte),
+			partPuts: make(map[int]int),
+		}
+		srv := httptest.NewServer(mpSrv)
+
+		// Use anonymous credentials to receive the raw part data.
+		clnt, err := New(srv.Listener.Addr().String(), &Options{
+			Creds:  credentials.NewStaticV4("", "", ""),
+			Region: "us-east-1",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data := bytes.Repeat([]byte("a"), testCase.size)
+		// Hide io.ReaderAt to upload the stream part by part.
+		info, err := clnt.PutObject(context.Background(), "bucket", "object", io.MultiReader(bytes.NewReader(data)), testCase.length, testCase.opts)
+		srv.Close()
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if info.PartSize != testCase.expected {
+			t.Fatalf("Test %d: expected part size %d, got %d", i+1, testCase.expected, info.PartSize)
+		}
+		if info.Size != int64(len(data)) || !bytes.Equal(mpSrv.completed, data) {
+			t.Fatalf("Test %d: expected %d bytes to be uploaded, got %d", i+1, len(data), len(mpSrv.completed))
+		}
+	}
+}
+
+// readAheadReader fails reads going further than limit bytes ahead of
+// the parts uploaded to the server.
+type readAheadReader struct {
+	r        io.Reader
+	read     int64
+	limit    int64
+	partSize int64
+	uploaded *atomic.Int64
+}
+
+func (r *readAheadReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.read += int64(n)
+	if ahead := r.read - r.uploaded.Load()*r.partSize; ahead > r.limit {
+		return n, fmt.Errorf("read %d bytes ahead of the uploaded parts", ahead)
+	}
+	return n, err
+}
+
+func TestPutObjectMaxConcurrentParts(t *testing.T) {
+	const maxConcurrentParts = 2
+	mpSrv := &multipartServer{
+		parts:    make(map[string]map[int][]byte),
+		partPuts: make(map[int]int),
+	}
+	var inFlight, maxInFlight, uploaded atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			mpSrv.ServeHTTP(w, r)
+			return
+		}
+		n := inFlight.Add(1)
+		for m := maxInFlight.Load(); n > m && !maxInFlight.CompareAndSwap(m, n); m = maxInFlight.Load() {
+		}
+		// Slow down part uploads so the source is read faster.
+		time.Sleep(10 * time.Millisecond)
+		mpSrv.ServeHTTP(w, r)
+		inFlight.Add(-1)
+		uploaded.Add(1)
+	}))
+	defer srv.Close()
+
+	// Use anonymous credentials to receive the raw part data.
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:           credentials.NewStaticV4("", "", ""),
+		Region:          "us-east-1",
+		TrailingHeaders: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("a"), 8*absMinPartSize+1024)
+	reader := &readAheadReader{
+		r:        bytes.NewReader(data),
+		limit:    maxConcurrentParts * absMinPartSize,
+		partSize: absMinPartSize,
+		uploaded: &uploaded,
+	}
+	info, err := clnt.PutObject(context.Background(), "bucket", "object", reader, -1, PutObjectOptions{
+		PartSize:              absMinPartSize,
+		NumThreads:            8,
+		ConcurrentStreamParts: true,
+		MaxConcurrentParts:    maxConcurrentParts,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size != int64(len(data)) || !bytes.Equal(mpSrv.completed, data) {
+		t.Fatalf("expected %d bytes to be uploaded, got %d", len(data), len(mpSrv.completed))
+	}
+	if got := maxInFlight.Load(); got > maxConcurrentParts {
+		t.Fatalf("expected at most %d parts in flight, got %d", maxConcurrentParts, got)
+	}
+
+	_, err = clnt.PutObject(context.Background(), "bucket", "object", bytes.NewReader(data), -1, PutObjectOptions{MaxConcurrentParts: -1})
+	if ToErrorResponse(err).Code != InvalidArgument {
+		t.Fatalf("expected a negative MaxConcurrentParts to be rejected, got %v", err)
+	}
+}
+
+func TestPutObjectChecksumTrailer(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789abcdef"), 4096)
+	sum := sha256.Sum256(data)
+	checksum := base64.StdEncoding.EncodeToString(sum[:])
+
+	var header http.Header
+	var payload []byte
+	var trailer http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		var err error
+		if payload, trailer, err = decodeAWSChunked(r.Body); err != nil {
+			t.Errorf("unable to decode the body: %v", err)
+		}
+		w.Header().Set("ETag", `"etag"`)
+	}))
+	defer srv.Close()
+
+	testCases := []struct {
+		accessKey       string
+		opts            PutObjectOptions
+		contentSHA256   string
+		contentEncoding string
+	}{
+		{"accessKey", PutObjectOptions{Checksum: ChecksumSHA256}, "STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER", "aws-chunked"},
+		{"accessKey", PutObjectOptions{Checksum: ChecksumSHA256, ContentEncoding: "gzip"}, "STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER", "aws-chunked,gzip"},
+		{"accessKey", PutObjectOptions{Checksum: ChecksumSHA256, DisableContentSha256: true}, "STREAMING-UNSIGNED-PAYLOAD-TRAILER", "aws-chunked"},
+		{"accessKey", PutObjectOptions{Checksum: ChecksumSHA256, DisableContentSha256: true, ContentEncoding: "gzip"}, "STREAMING-UNSIGNED-PAYLOAD-TRAILER", "aws-chunked,gzip"},
+		{"", PutObjectOptions{Checksum: ChecksumSHA256, ContentEncoding: "gzip"}, "STREAMING-UNSIGNED-PAYLOAD-TRAILER", "aws-chunked,gzip"},
+	}
+	for i, testCase := range testCases {
+		clnt, err := New(srv.Listener.Addr().String(), &Options{
+			Creds:           credentials.NewStaticV4(testCase.accessKey, testCase.accessKey, ""),
+			Region:          "us-east-1",
+			TrailingHeaders: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = clnt.PutObject(context.Background(), "bucket", "object", bytes.NewReader(data), int64(len(data)), testCase.opts); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if got := header.Get("X-Amz-Content-Sha256"); got != testCase.contentSHA256 {
+			t.Fatalf("Test %d: expected x-amz-content-sha256 %s, got %s", i+1, testCase.contentSHA256, got)
+		}
+		if got := header.Get("Content-Encoding"); got != testCase.contentEncoding {
+			t.Fatalf("Test %d: expected Content-Encoding %q, got %q", i+1, testCase.contentEncoding, got)
+		}
+		if got := header.Get("X-Amz-Trailer"); got != "x-amz-checksum-sha256" {
+			t.Fatalf("Test %d: unexpected x-amz-trailer %q", i+1, got)
+		}
+		if got := header.Get("X-Amz-Decoded-Content-Length"); got != strconv.Itoa(len(data)) {
+			t.Fatalf("Test %d: unexpected x-amz-decoded-content-length %q", i+1, got)
+		}
+		if !bytes.Equal(payload, data) {
+			t.Fatalf("Test %d: unexpected payload of %d bytes", i+1, len(payload))
+		}
+		if got := trailer.Get("x-amz-checksum-sha256"); got != checksum {
+			t.Fatalf("Test %d: expected trailing checksum %s, got %s", i+1, checksum, got)
+		}
+	}
+}
@@ -56,6 +56,11 @@ type SnowballOptions struct {
 	// SkipErrs if enabled will skip any errors while reading the
 	// object content while creating the snowball archive
 	SkipErrs bool
+
+	// MaxBatchSize if set uploads the archive as soon as the objects
+	// added to it reach this size (before compression), remaining
+	// objects are sent in further archives.
+	MaxBatchSize int64
 }
 
 // SnowballObject contains information about a single object to be added to the snowball.
@@ -104,37 +109,44 @@ type readSeekCloser interface {
 	io.Seeker
 }
 
-// PutObjectsSnowball will put multiple objects with a single put call.
-// A (compressed) TAR file will be created which will contain multiple objects.
-// The key for each object will be used for the destination in the specified bucket.
-// Total size should be < 5TB.
-// This function blocks until 'objs' is closed and the content has been uploaded.
-func (c *Client) PutObjectsSnowball(ctx context.Context, bucketName string, opts SnowballOptions, objs <-chan SnowballObject) (err error) {
-	err = opts.Opts.validate(c)
-	if err != nil {
-		return err
-	}
+// snowballBatch is a (compressed) TAR archive being assembled
+// for a single snowball upload.
+type snowballBatch struct {
+	tar          *tar.Writer
+	flush        func() error
+	getTmpReader func() (rc readSeekCloser, sz int64, err error)
+	cleanup      func()
+
+	// Uncompressed size of the objects added so far.
+	size int64
+}
+
+// newSnowballBatch creates an empty batch, buffered in memory or
+// in a temporary file.
+func newSnowballBatch(opts SnowballOptions) (*snowballBatch, error) {
+	batch := &snowballBatch{cleanup: func() {}}
 	var tmpWriter io.Writer
-	var getTmpReader func() (rc readSeekCloser, sz int64, err error)
 	if opts.InMemory {
 		b := bytes.NewBuffer(nil)
 		tmpWriter = b
-		getTmpReader = func() (readSeekCloser, int64, error) {
+		batch.getTmpReader = func() (readSeekCloser, int64, error) {
 			return nopReadSeekCloser{bytes.NewReader(b.Bytes())}, int64(b.Len()), nil
 		}
 	} else {
 		f, err := os.CreateTemp("", "s3-putsnowballobjects-*")
 		if err != nil {
-			return err
+			return nil, err
 		}
 		name := f.Name()
 		tmpWriter = f
 		var once sync.Once
-		defer once.Do(func() {
-			f.Close()
-		})
-		defer os.Remove(name)
-		getTmpReader = func() (readSeekCloser, int64, error) {
+		batch.cleanup = func() {
+			once.Do(func() {
+				f.Close()
+			})
+			os.Remove(name)
+		}
+		batch.getTmpReader = func() (readSeekCloser, int64, error) {
 			once.Do(func() {
 				f.Close()
 			})
@@ -149,21 +161,83 @@ func (c *Client) PutObjectsSnowball(ctx context.Context, bucketName string, opts
 			return f, st.Size(), nil
 		}
 	}
-	flush := func() error { return nil }
+	batch.flush = func() error { return nil }
 	if !opts.Compress {
 		if !opts.InMemory {
 			// Insert buffer for writes.
 			buf := bufio.NewWriterSize(tmpWriter, 1<<20)
-			flush = buf.Flush
+			batch.flush = buf.Flush
 			tmpWriter = buf
 		}
 	} else {
 		s2c := s2.NewWriter(tmpWriter, s2.WriterBetterCompression())
-		flush = s2c.Close
-		defer s2c.Close()
+		batch.flush = s2c.Close
+		cleanup := batch.cleanup
+		batch.cleanup = func() {
+			s2c.Close()
+			cleanup()
+		}
 		tmpWriter = s2c
 	}
-	t := tar.NewWriter(tmpWriter)
+	batch.tar = tar.NewWriter(tmpWriter)
+	return batch, nil
+}
+
+// uploadSnowballBatch uploads batch as a single object, which is
+// extracted by the server.
+func (c *Client) uploadSnowballBatch(ctx context.Context, bucketName string, opts PutObjectOptions, batch *snowballBatch) error {
+	// Flush tar
+	err := batch.tar.Flush()
+	if err != nil {
+		return err
+	}
+	// Flush compression
+	err = batch.flush()
+	if err != nil {
+		return err
+	}
+	rc, sz, err := batch.getTmpReader()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	rand := c.random.Uint64()
+	_, err = c.PutObject(ctx, bucketName, fmt.Sprintf("snowball-upload-%x.tar", rand), rc, sz, opts)
+	return err
+}
+
+// PutObjectsSnowball will put multiple objects with a single put call.
+// A (compressed) TAR file will be created which will contain multiple objects.
+// The key for each object will be used for the destination in the specified bucket.
+// Total size should be < 5TB, unless MaxBatchSize is set in which case
+// the objects are split in several uploads.
+// This function blocks until 'objs' is closed and the content has been uploaded.
+func (c *Client) PutObjectsSnowball(ctx context.Context, bucketName string, opts SnowballOptions, objs <-chan SnowballObject) (err error) {
+	err = opts.Opts.validate(c)
+	if err != nil {
+		return err
+	}
+	if opts.MaxBatchSize < 0 {
+		return errInvalidArgument("MaxBatchSize cannot be negative")
+	}
+
+	putOpts := opts.Opts
+	putOpts.UserMetadata = make(map[string]string, len(opts.Opts.UserMetadata)+1)
+	for k, v := range opts.Opts.UserMetadata {
+		putOpts.UserMetadata[k] = v
+	}
+	putOpts.UserMetadata["X-Amz-Meta-Snowball-Auto-Extract"] = "true"
+	putOpts.DisableMultipart = true
+
+	batch, err := newSnowballBatch(opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if batch != nil {
+			batch.cleanup()
+		}
+	}()
 
 objectLoop:
 	for {
@@ -180,6 +254,15 @@ objectLoop:
 				closeObj = obj.Close
 			}
 
+			if batch == nil {
+				// Previous batch was uploaded, start a new one.
+				batch, err = newSnowballBatch(opts)
+				if err != nil {
+					closeObj()
+					return err
+				}
+			}
+
 			// Trim accidental slash prefix.
 			obj.Key = strings.TrimPrefix(obj.Key, "/")
 			header := tar.Header{
@@ -201,11 +284,11 @@ objectLoop:
 				header.PAXRecords["minio.metadata."+k] = strings.Join(vals, ",")
 			}
 
-			if err := t.WriteHeader(&header); err != nil {
+			if err := batch.tar.WriteHeader(&header); err != nil {
 				closeObj()
 				return err
 			}
-			n, err := io.Copy(t, obj.Content)
+			n, err := io.Copy(batch.tar, obj.Content)
 			if err != nil {
 				closeObj()
 				if opts.SkipErrs {
@@ -221,29 +304,21 @@ objectLoop:
 				return io.ErrUnexpectedEOF
 			}
 			closeObj()
+
+			batch.size += n
+			if opts.MaxBatchSize > 0 && batch.size >= opts.MaxBatchSize {
+				err = c.uploadSnowballBatch(ctx, bucketName, putOpts, batch)
+				batch.cleanup()
+				batch = nil
+				if err != nil {
+					return err
+				}
+			}
 		}
 	}
-	// Flush tar
-	err = t.Flush()
-	if err != nil {
-		return err
-	}
-	// Flush compression
-	err = flush()
-	if err != nil {
-		return err
-	}
-	if opts.Opts.UserMetadata == nil {
-		opts.Opts.UserMetadata = map[string]string{}
+	if batch == nil {
+		// Everything was uploaded with the last batch.
+		return nil
 	}
-	opts.Opts.UserMetadata["X-Amz-Meta-Snowball-Auto-Extract"] = "true"
-	opts.Opts.DisableMultipart = true
-	rc, sz, err := getTmpReader()
-	if err != nil {
-		return err
-	}
-	defer rc.Close()
-	rand := c.random.Uint64()
-	_, err = c.PutObject(ctx, bucketName, fmt.Sprintf("snowball-upload-%x.tar", rand), rc, sz, opts.Opts)
-	return err
+	return c.uploadSnowballBatch(ctx, bucketName, putOpts, batch)
 }
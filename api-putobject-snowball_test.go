@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/openstor/openstor-go/v7/pkg/credentials"
+)
+
+func TestPutObjectsSnowballBatches(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Meta-Snowball-Auto-Extract") != "true" {
+			t.Errorf("expected snowball auto extract metadata")
+		}
+		var keys []string
+		tr := tar.NewReader(r.Body)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Errorf("unable to read archive: %v", err)
+				break
+			}
+			keys = append(keys, hdr.Name)
+		}
+		mu.Lock()
+		batches = append(batches, keys)
+		mu.Unlock()
+		w.Header().Set("ETag", `"etag"`)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	// Anonymous requests are not stream signed, the archive is sent as is.
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:  credentials.NewStaticV4("", "", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objs := make(chan SnowballObject, 3)
+	for _, key := range []string{"a", "b", "c"} {
+		objs <- SnowballObject{Key: key, Size: 6, Content: strings.NewReader("object")}
+	}
+	close(objs)
+
+	err = clnt.PutObjectsSnowball(context.Background(), "bucket", SnowballOptions{InMemory: true, MaxBatchSize: 10}, objs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batches) != 2 {
+		t.Fatalf("expected 2 batches, got %d", len(batches))
+	}
+	if strings.Join(batches[0], ",") != "a,b" || strings.Join(batches[1], ",") != "c" {
+		t.Fatalf("unexpected batches %v", batches)
+	}
+}
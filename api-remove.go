@@ -24,10 +24,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/xml"
+	"fmt"
 	"io"
 	"iter"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/openstor/openstor-go/v7/pkg/s3utils"
@@ -53,7 +55,7 @@ type RemoveBucketOptions struct {
 // ForceDelete to 'true'.
 func (c *Client) RemoveBucketWithOptions(ctx context.Context, bucketName string, opts RemoveBucketOptions) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 
@@ -90,7 +92,7 @@ func (c *Client) RemoveBucketWithOptions(ctx context.Context, bucketName string,
 //	in the bucket must be deleted before successfully attempting this request.
 func (c *Client) RemoveBucket(ctx context.Context, bucketName string) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 	// Execute DELETE on bucket.
@@ -125,16 +127,47 @@ type AdvancedRemoveOptions struct {
 
 // RemoveObjectOptions represents options specified by user for RemoveObject call
 type RemoveObjectOptions struct {
-	ForceDelete      bool
+	// ForceDelete removes the object with all of its versions on MinIO.
+	// RemoveObject retries a force delete rejected because of object
+	// lock retention once with GovernanceBypass set, returning a
+	// *GovernanceBypassDeniedError if the bypass is denied as well.
+	ForceDelete bool
+
+	// GovernanceBypass allows removing an object version protected by
+	// governance mode retention, which requires the
+	// s3:BypassGovernanceRetention permission. It has no effect on
+	// compliance mode retention and legal holds.
 	GovernanceBypass bool
-	VersionID        string
-	Internal         AdvancedRemoveOptions
+
+	// VersionID removes this version of the object, instead of adding
+	// a delete marker to a versioned bucket.
+	VersionID string
+
+	// MatchETag only removes the object if its ETag matches, "*"
+	// matching any existing object, by sending If-Match. The removal
+	// fails with an error matching ErrPreconditionFailed otherwise,
+	// e.g. when the object was overwritten in the meantime.
+	MatchETag string
+
+	Internal AdvancedRemoveOptions
+
+	// RequestPayer sends "x-amz-request-payer: requester" to remove
+	// objects of Requester Pays buckets.
+	RequestPayer bool
+
+	// Region is the region the request is signed for, bypassing the
+	// cached bucket location.
+	Region string
+
+	// UserAgent is a token appended to the User-Agent of the client
+	// for this request, e.g. "myapp/1.0".
+	UserAgent string
 }
 
 // RemoveObject removes an object from a bucket.
 func (c *Client) RemoveObject(ctx context.Context, bucketName, objectName string, opts RemoveObjectOptions) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 	if err := s3utils.CheckValidObjectName(objectName); err != nil {
@@ -142,9 +175,52 @@ func (c *Client) RemoveObject(ctx context.Context, bucketName, objectName string
 	}
 
 	res := c.removeObject(ctx, bucketName, objectName, opts)
+	if res.Err != nil && opts.ForceDelete && !opts.GovernanceBypass && c.isObjectLockedError(ctx, bucketName, objectName, opts, res.Err) {
+		// Retry bypassing the governance retention of the object.
+		opts.GovernanceBypass = true
+		res = c.removeObject(ctx, bucketName, objectName, opts)
+		if res.Err != nil && isObjectLockDenial(res.Err) {
+			return &GovernanceBypassDeniedError{
+				BucketName: bucketName,
+				ObjectName: objectName,
+				VersionID:  opts.VersionID,
+				Err:        res.Err,
+			}
+		}
+	}
 	return res.Err
 }
 
+// isObjectLockDenial returns true if the error has the code used by
+// servers to reject the removal of an object under object lock.
+func isObjectLockDenial(err error) bool {
+	switch ToErrorResponse(err).Code {
+	case AccessDenied, "InvalidRequest":
+		return true
+	}
+	return false
+}
+
+// isObjectLockedError returns true if the removal was rejected with an
+// object lock denial and the object reports, through its object lock
+// headers, to be protected by a retention or a legal hold.
+func (c *Client) isObjectLockedError(ctx context.Context, bucketName, objectName string, opts RemoveObjectOptions, err error) bool {
+	if !isObjectLockDenial(err) {
+		return false
+	}
+	objInfo, err := c.StatObject(ctx, bucketName, objectName, StatObjectOptions{
+		VersionID:    opts.VersionID,
+		Region:       opts.Region,
+		UserAgent:    opts.UserAgent,
+		RequestPayer: opts.RequestPayer,
+	})
+	if err != nil {
+		return false
+	}
+	return objInfo.Metadata.Get(amzLockMode) != "" ||
+		LegalHoldStatus(objInfo.Metadata.Get(amzLegalHoldHeader)) == LegalHoldEnabled
+}
+
 func (c *Client) removeObject(ctx context.Context, bucketName, objectName string, opts RemoveObjectOptions) RemoveObjectResult {
 	// Get resources properly escaped and lined up before
 	// using them in http request.
@@ -179,13 +255,23 @@ func (c *Client) removeObject(ctx context.Context, bucketName, objectName string
 	if opts.ForceDelete {
 		headers.Set(minIOForceDelete, "true")
 	}
+	if opts.RequestPayer {
+		headers.Set(amzRequestPayer, requestPayerRequester)
+	}
+	if opts.MatchETag == "*" {
+		headers.Set("If-Match", "*")
+	} else if opts.MatchETag != "" {
+		headers.Set("If-Match", "\""+strings.Trim(opts.MatchETag, "\"")+"\"")
+	}
 	// Execute DELETE on objectName.
 	resp, err := c.executeMethod(ctx, http.MethodDelete, requestMetadata{
 		bucketName:       bucketName,
 		objectName:       objectName,
+		bucketLocation:   opts.Region,
 		contentSHA256Hex: emptySHA256Hex,
 		queryValues:      urlValues,
 		customHeader:     headers,
+		userAgent:        opts.UserAgent,
 	})
 	defer closeResponse(resp)
 	if err != nil {
@@ -225,6 +311,27 @@ func (err *RemoveObjectError) Error() string {
 	return err.Err.Error()
 }
 
+// GovernanceBypassDeniedError is returned by RemoveObject when a force
+// delete of an object protected by object lock retention could not be
+// completed bypassing its governance retention, either because the
+// s3:BypassGovernanceRetention permission is missing or because the
+// object is under compliance mode retention or a legal hold.
+type GovernanceBypassDeniedError struct {
+	BucketName string
+	ObjectName string
+	VersionID  string
+	Err        error
+}
+
+func (err *GovernanceBypassDeniedError) Error() string {
+	return "governance retention bypass denied for " + err.BucketName + "/" + err.ObjectName + ": " + err.Err.Error()
+}
+
+// Unwrap returns the error of the retried delete.
+func (err *GovernanceBypassDeniedError) Unwrap() error {
+	return err.Err
+}
+
 // RemoveObjectResult - container of Multi Delete S3 API result
 type RemoveObjectResult struct {
 	ObjectName      string
@@ -289,9 +396,52 @@ func processRemoveMultiObjectsResponse(body io.Reader, resultCh chan<- RemoveObj
 	}
 }
 
+// dryRunRemoveObjectResult - result reported for an object removal
+// which was only previewed and never sent to the server.
+func dryRunRemoveObjectResult(object ObjectInfo) RemoveObjectResult {
+	return RemoveObjectResult{
+		ObjectName:      object.Key,
+		ObjectVersionID: object.VersionID,
+	}
+}
+
 // RemoveObjectsOptions represents options specified by user for RemoveObjects call
 type RemoveObjectsOptions struct {
 	GovernanceBypass bool
+
+	// DryRun when set does not remove anything, instead every object
+	// that would have been removed is sent back on the result channel
+	// (or iterator) with its version ID, as received from the caller.
+	// RemoveObjects only reports errors, use RemoveObjectsWithResult
+	// or RemoveObjectsWithIter to preview the deletions.
+	DryRun bool
+
+	// ChecksumAlgorithm, when set, sends the x-amz-checksum-* header
+	// of this algorithm over every delete request payload, for servers
+	// requiring it. The Content-Md5 header is sent regardless.
+	ChecksumAlgorithm ChecksumType
+}
+
+// validate checks the options are valid.
+func (opts RemoveObjectsOptions) validate() error {
+	if opts.ChecksumAlgorithm != ChecksumNone && !opts.ChecksumAlgorithm.IsSet() {
+		return errInvalidArgument(fmt.Sprintf("Invalid checksum algorithm %s", opts.ChecksumAlgorithm))
+	}
+	return nil
+}
+
+// header returns the headers of a delete request of the payload.
+func (opts RemoveObjectsOptions) header(payload []byte) http.Header {
+	headers := make(http.Header)
+	if opts.GovernanceBypass {
+		// Set the bypass goverenance retention header
+		headers.Set(amzBypassGovernance, "true")
+	}
+	if opts.ChecksumAlgorithm.IsSet() {
+		headers.Set(opts.ChecksumAlgorithm.Key(), opts.ChecksumAlgorithm.EncodeToString(payload))
+		headers.Set(amzChecksumAlgo, opts.ChecksumAlgorithm.String())
+	}
+	return headers
 }
 
 // RemoveObjects removes multiple objects from a bucket while
@@ -301,7 +451,7 @@ func (c *Client) RemoveObjects(ctx context.Context, bucketName string, objectsCh
 	errorCh := make(chan RemoveObjectError, 1)
 
 	// Validate if bucket name is valid.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		defer close(errorCh)
 		errorCh <- RemoveObjectError{
 			Err: err,
@@ -316,6 +466,13 @@ func (c *Client) RemoveObjects(ctx context.Context, bucketName string, objectsCh
 		}
 		return errorCh
 	}
+	if err := opts.validate(); err != nil {
+		defer close(errorCh)
+		errorCh <- RemoveObjectError{
+			Err: err,
+		}
+		return errorCh
+	}
 
 	resultCh := make(chan RemoveObjectResult, 1)
 	go c.removeObjects(ctx, bucketName, objectsCh, resultCh, opts)
@@ -345,13 +502,16 @@ func (c *Client) RemoveObjects(ctx context.Context, bucketName string, objectsCh
 // longer accept more objects.
 func (c *Client) RemoveObjectsWithIter(ctx context.Context, bucketName string, objectsIter iter.Seq[ObjectInfo], opts RemoveObjectsOptions) (iter.Seq[RemoveObjectResult], error) {
 	// Validate if bucket name is valid.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return nil, err
 	}
 	// Validate objects channel to be properly allocated.
 	if objectsIter == nil {
 		return nil, errInvalidArgument("Objects iter can never by nil")
 	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
 
 	return func(yield func(RemoveObjectResult) bool) {
 		select {
@@ -372,7 +532,7 @@ func (c *Client) RemoveObjectsWithResult(ctx context.Context, bucketName string,
 	resultCh := make(chan RemoveObjectResult, 1)
 
 	// Validate if bucket name is valid.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		defer close(resultCh)
 		resultCh <- RemoveObjectResult{
 			Err: err,
@@ -387,6 +547,13 @@ func (c *Client) RemoveObjectsWithResult(ctx context.Context, bucketName string,
 		}
 		return resultCh
 	}
+	if err := opts.validate(); err != nil {
+		defer close(resultCh)
+		resultCh <- RemoveObjectResult{
+			Err: err,
+		}
+		return resultCh
+	}
 
 	go c.removeObjects(ctx, bucketName, objectsCh, resultCh, opts)
 	return resultCh
@@ -414,17 +581,19 @@ func hasInvalidXMLChar(str string) bool {
 
 // Generate and call MultiDelete S3 requests based on entries received from the iterator.
 func (c *Client) removeObjectsIter(ctx context.Context, bucketName string, objectsIter iter.Seq[ObjectInfo], yield func(RemoveObjectResult) bool, opts RemoveObjectsOptions) {
+	if opts.DryRun {
+		for object := range objectsIter {
+			if !yield(dryRunRemoveObjectResult(object)) {
+				return
+			}
+		}
+		return
+	}
+
 	maxEntries := 1000
 	urlValues := make(url.Values)
 	urlValues.Set("delete", "")
 
-	// Build headers.
-	headers := make(http.Header)
-	if opts.GovernanceBypass {
-		// Set the bypass goverenance retention header
-		headers.Set(amzBypassGovernance, "true")
-	}
-
 	processRemoveMultiObjectsResponseIter := func(batch []ObjectInfo, yield func(RemoveObjectResult) bool) bool {
 		if len(batch) == 0 {
 			return false
@@ -440,7 +609,8 @@ func (c *Client) removeObjectsIter(ctx context.Context, bucketName string, objec
 			contentLength:    int64(len(removeBytes)),
 			contentMD5Base64: sumMD5Base64(removeBytes),
 			contentSHA256Hex: sum256Hex(removeBytes),
-			customHeader:     headers,
+			requireMD5:       true,
+			customHeader:     opts.header(removeBytes),
 		})
 		if resp != nil {
 			defer closeResponse(resp)
@@ -560,6 +730,13 @@ func (c *Client) removeObjects(ctx context.Context, bucketName string, objectsCh
 	// Close result channel when Multi delete finishes.
 	defer close(resultCh)
 
+	if opts.DryRun {
+		for object := range objectsCh {
+			resultCh <- dryRunRemoveObjectResult(object)
+		}
+		return
+	}
+
 	// Loop over entries by 1000 and call MultiDelete requests
 	for !finish {
 		count := 0
@@ -600,13 +777,6 @@ func (c *Client) removeObjects(ctx context.Context, bucketName string, objectsCh
 			finish = true
 		}
 
-		// Build headers.
-		headers := make(http.Header)
-		if opts.GovernanceBypass {
-			// Set the bypass goverenance retention header
-			headers.Set(amzBypassGovernance, "true")
-		}
-
 		// Generate remove multi objects XML request
 		removeBytes := generateRemoveMultiObjectsRequest(batch)
 		// Execute POST on bucket to remove objects.
@@ -617,7 +787,8 @@ func (c *Client) removeObjects(ctx context.Context, bucketName string, objectsCh
 			contentLength:        int64(len(removeBytes)),
 			contentMD5Base64:     sumMD5Base64(removeBytes),
 			contentSHA256Hex:     sum256Hex(removeBytes),
-			customHeader:         headers,
+			requireMD5:           true,
+			customHeader:         opts.header(removeBytes),
 			expect200OKWithError: true,
 		})
 
@@ -646,7 +817,7 @@ func (c *Client) removeObjects(ctx context.Context, bucketName string, objectsCh
 // RemoveIncompleteUpload aborts an partially uploaded object.
 func (c *Client) RemoveIncompleteUpload(ctx context.Context, bucketName, objectName string) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 	if err := s3utils.CheckValidObjectName(objectName); err != nil {
@@ -660,7 +831,7 @@ func (c *Client) RemoveIncompleteUpload(ctx context.Context, bucketName, objectN
 
 	for _, uploadID := range uploadIDs {
 		// abort incomplete multipart upload, based on the upload id passed.
-		err := c.abortMultipartUpload(ctx, bucketName, objectName, uploadID)
+		err := c.abortMultipartUpload(ctx, bucketName, objectName, uploadID, PutObjectOptions{})
 		if err != nil {
 			return err
 		}
@@ -669,11 +840,55 @@ func (c *Client) RemoveIncompleteUpload(ctx context.Context, bucketName, objectN
 	return nil
 }
 
+// AbortIncompleteUploadsOlderThan aborts all the incomplete uploads
+// matching objectPrefix which were initiated more than age ago. It
+// returns the number of aborted uploads.
+func (c *Client) AbortIncompleteUploadsOlderThan(ctx context.Context, bucketName, objectPrefix string, age time.Duration) (int, error) {
+	if age < 0 {
+		return 0, errInvalidArgument("Age cannot be negative.")
+	}
+
+	var aborted int
+	olderThan := time.Now().Add(-age)
+	for upload, err := range c.listIncompleteUploadsIter(ctx, bucketName, objectPrefix, true, false) {
+		if err != nil {
+			return aborted, err
+		}
+		if !upload.Initiated.Before(olderThan) {
+			continue
+		}
+		if err = c.abortMultipartUpload(ctx, bucketName, upload.Key, upload.UploadID, PutObjectOptions{}); err != nil {
+			// Upload may have been completed or aborted meanwhile.
+			if ToErrorResponse(err).Code == NoSuchUpload {
+				continue
+			}
+			return aborted, err
+		}
+		aborted++
+	}
+	return aborted, nil
+}
+
+// abortIncompleteUploadTimeout - time allowed to abort an upload
+// that failed or whose context was canceled.
+const abortIncompleteUploadTimeout = 30 * time.Second
+
+// abortIncompleteUpload aborts a failed multipart upload, sent with the
+// Region and RequestPayer of its opts. A fresh context is used so that
+// the abort still happens when the upload failed because its context
+// was canceled or deadlined.
+func (c *Client) abortIncompleteUpload(bucketName, objectName, uploadID string, opts PutObjectOptions) {
+	ctx, cancel := context.WithTimeout(context.Background(), abortIncompleteUploadTimeout)
+	defer cancel()
+	c.abortMultipartUpload(ctx, bucketName, objectName, uploadID, opts)
+}
+
 // abortMultipartUpload aborts a multipart upload for the given
-// uploadID, all previously uploaded parts are deleted.
-func (c *Client) abortMultipartUpload(ctx context.Context, bucketName, objectName, uploadID string) error {
+// uploadID, all previously uploaded parts are deleted. Only the Region
+// and RequestPayer of opts apply.
+func (c *Client) abortMultipartUpload(ctx context.Context, bucketName, objectName, uploadID string, opts PutObjectOptions) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 	if err := s3utils.CheckValidObjectName(objectName); err != nil {
@@ -684,11 +899,18 @@ func (c *Client) abortMultipartUpload(ctx context.Context, bucketName, objectNam
 	urlValues := make(url.Values)
 	urlValues.Set("uploadId", uploadID)
 
+	headers := make(http.Header)
+	if opts.RequestPayer {
+		headers.Set(amzRequestPayer, requestPayerRequester)
+	}
+
 	// Execute DELETE on multipart upload.
 	resp, err := c.executeMethod(ctx, http.MethodDelete, requestMetadata{
 		bucketName:       bucketName,
 		objectName:       objectName,
+		bucketLocation:   opts.Region,
 		queryValues:      urlValues,
+		customHeader:     headers,
 		contentSHA256Hex: emptySHA256Hex,
 	})
 	defer closeResponse(resp)
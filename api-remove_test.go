@@ -0,0 +1,250 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestRemoveObjectsDryRun(t *testing.T) {
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s during dry run", r.Method, r.URL)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	objects := []ObjectInfo{
+		{Key: "a/object1", VersionID: "v1"},
+		{Key: "a/object1", VersionID: "v2"},
+		{Key: "b/object2"},
+	}
+
+	objectsCh := make(chan ObjectInfo, len(objects))
+	for _, object := range objects {
+		objectsCh <- object
+	}
+	close(objectsCh)
+
+	var results []RemoveObjectResult
+	for res := range clnt.RemoveObjectsWithResult(context.Background(), "bucket", objectsCh, RemoveObjectsOptions{DryRun: true}) {
+		results = append(results, res)
+	}
+	if len(results) != len(objects) {
+		t.Fatalf("expected %d results, got %d", len(objects), len(results))
+	}
+	for i, res := range results {
+		if res.Err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, res.Err)
+		}
+		if res.ObjectName != objects[i].Key || res.ObjectVersionID != objects[i].VersionID {
+			t.Fatalf("Test %d: expected %s (%s), got %s (%s)", i+1, objects[i].Key, objects[i].VersionID, res.ObjectName, res.ObjectVersionID)
+		}
+	}
+
+	resultsIter, err := clnt.RemoveObjectsWithIter(context.Background(), "bucket", func(yield func(ObjectInfo) bool) {
+		for _, object := range objects {
+			if !yield(object) {
+				return
+			}
+		}
+	}, RemoveObjectsOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	for res := range resultsIter {
+		if res.ObjectName != objects[count].Key || res.ObjectVersionID != objects[count].VersionID {
+			t.Fatalf("Test %d: expected %s (%s), got %s (%s)", count+1, objects[count].Key, objects[count].VersionID, res.ObjectName, res.ObjectVersionID)
+		}
+		count++
+	}
+	if count != len(objects) {
+		t.Fatalf("expected %d results, got %d", len(objects), count)
+	}
+}
+
+func TestRemoveObjectForceDeleteGovernanceBypass(t *testing.T) {
+	testCases := []struct {
+		allowBypass bool
+		locked      bool
+		forbidden   bool
+		attempts    int
+		denied      bool
+	}{
+		{allowBypass: true, locked: false, attempts: 1},
+		{allowBypass: true, locked: true, attempts: 2},
+		{allowBypass: false, locked: true, attempts: 2, denied: true},
+		// Denied without the object being locked, not retried.
+		{allowBypass: true, forbidden: true, attempts: 1},
+	}
+
+	for i, testCase := range testCases {
+		var attempts int
+		clnt, srv := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodHead {
+				if r.URL.Query().Get("versionId") != "v1" {
+					t.Errorf("Test %d: unexpected request %s %s", i+1, r.Method, r.URL)
+				}
+				w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+				if testCase.locked {
+					w.Header().Set(amzLockMode, "GOVERNANCE")
+				}
+				return
+			}
+			attempts++
+			if r.URL.Query().Get("versionId") != "v1" || r.Header.Get(minIOForceDelete) != "true" {
+				t.Errorf("Test %d: unexpected request %s %v", i+1, r.URL, r.Header)
+			}
+			bypass := r.Header.Get(amzBypassGovernance) == "true"
+			switch {
+			case testCase.forbidden:
+				w.WriteHeader(http.StatusForbidden)
+				io.WriteString(w, `<Error><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>`)
+			case bypass && !testCase.allowBypass:
+				w.WriteHeader(http.StatusForbidden)
+				io.WriteString(w, `<Error><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>`)
+			case testCase.locked && !bypass:
+				w.WriteHeader(http.StatusForbidden)
+				io.WriteString(w, `<Error><Code>AccessDenied</Code><Message>Object is WORM protected and cannot be overwritten</Message></Error>`)
+			default:
+				w.WriteHeader(http.StatusNoContent)
+			}
+		}))
+
+		err := clnt.RemoveObject(context.Background(), "bucket", "object", RemoveObjectOptions{ForceDelete: true, VersionID: "v1"})
+		srv.Close()
+		if attempts != testCase.attempts {
+			t.Fatalf("Test %d: expected %d attempts, got %d", i+1, testCase.attempts, attempts)
+		}
+		var deniedErr *GovernanceBypassDeniedError
+		if testCase.denied {
+			if !errors.As(err, &deniedErr) || deniedErr.ObjectName != "object" || deniedErr.VersionID != "v1" {
+				t.Fatalf("Test %d: expected GovernanceBypassDeniedError, got %v", i+1, err)
+			}
+			if ToErrorResponse(errors.Unwrap(err)).Code != AccessDenied {
+				t.Fatalf("Test %d: expected the AccessDenied error to be wrapped, got %v", i+1, errors.Unwrap(err))
+			}
+		} else if testCase.forbidden {
+			if errors.As(err, &deniedErr) || ToErrorResponse(err).Code != AccessDenied {
+				t.Fatalf("Test %d: expected AccessDenied, got %v", i+1, err)
+			}
+		} else if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+	}
+}
+
+func TestRemoveObjectMatchETag(t *testing.T) {
+	etag := `"current"`
+	removed := false
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			return
+		}
+		if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != "*" && ifMatch != etag {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			io.WriteString(w, `<Error><Code>PreconditionFailed</Code><Message>At least one of the pre-conditions you specified did not hold</Message></Error>`)
+			return
+		}
+		removed = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	testCases := []struct {
+		matchETag string
+		removed   bool
+	}{
+		{"", true},
+		{"current", true},
+		{`"current"`, true},
+		{"*", true},
+		{"overwritten", false},
+	}
+	for i, testCase := range testCases {
+		removed = false
+		err := clnt.RemoveObject(context.Background(), "bucket", "object", RemoveObjectOptions{MatchETag: testCase.matchETag})
+		if removed != testCase.removed {
+			t.Fatalf("Test %d: expected removed to be %t, got %t", i+1, testCase.removed, removed)
+		}
+		if testCase.removed && err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if !testCase.removed && !errors.Is(err, ErrPreconditionFailed) {
+			t.Fatalf("Test %d: expected ErrPreconditionFailed, got %v", i+1, err)
+		}
+	}
+}
+
+func TestRemoveObjectsChecksum(t *testing.T) {
+	var requests int
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPost || !r.URL.Query().Has("delete") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		if got := r.Header.Get("Content-Md5"); got != sumMD5Base64(body) {
+			t.Errorf("expected Content-Md5 %s, got %s", sumMD5Base64(body), got)
+		}
+		if got := r.Header.Get("X-Amz-Checksum-Crc32c"); got != ChecksumCRC32C.EncodeToString(body) {
+			t.Errorf("expected x-amz-checksum-crc32c %s, got %s", ChecksumCRC32C.EncodeToString(body), got)
+		}
+		if got := r.Header.Get(amzChecksumAlgo); got != "CRC32C" {
+			t.Errorf("expected the CRC32C checksum algorithm, got %q", got)
+		}
+		io.WriteString(w, `<DeleteResult><Deleted><Key>object</Key></Deleted></DeleteResult>`)
+	}))
+
+	ctx := context.Background()
+	opts := RemoveObjectsOptions{ChecksumAlgorithm: ChecksumCRC32C}
+	objectsCh := make(chan ObjectInfo, 1)
+	objectsCh <- ObjectInfo{Key: "object"}
+	close(objectsCh)
+	for res := range clnt.RemoveObjectsWithResult(ctx, "bucket", objectsCh, opts) {
+		if res.Err != nil || res.ObjectName != "object" {
+			t.Fatalf("unexpected result %+v", res)
+		}
+	}
+
+	results, err := clnt.RemoveObjectsWithIter(ctx, "bucket", func(yield func(ObjectInfo) bool) {
+		yield(ObjectInfo{Key: "object"})
+	}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for res := range results {
+		if res.Err != nil || res.ObjectName != "object" {
+			t.Fatalf("unexpected result %+v", res)
+		}
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 delete requests, got %d", requests)
+	}
+
+	opts.ChecksumAlgorithm = ChecksumCRC32 | ChecksumSHA256
+	if _, err = clnt.RemoveObjectsWithIter(ctx, "bucket", func(yield func(ObjectInfo) bool) {}, opts); ToErrorResponse(err).Code != InvalidArgument {
+		t.Fatalf("expected the checksum algorithm to be rejected, got %v", err)
+	}
+}
@@ -143,10 +143,32 @@ func (r *RestoreRequest) SetOutputLocation(v OutputLocation) {
 	r.OutputLocation = &v
 }
 
+// RestoreOptions holds the options of a plain restore of an archived
+// object, use RestoreRequest directly for SELECT restore requests.
+type RestoreOptions struct {
+	// Days is the number of days the restored copy is kept.
+	Days int
+	// Tier is the retrieval tier, the server default is used if empty.
+	Tier TierType
+}
+
+// Request returns the restore request for the options, with the
+// retrieval tier set in the Glacier job parameters.
+func (o RestoreOptions) Request() RestoreRequest {
+	var req RestoreRequest
+	if o.Days > 0 {
+		req.SetDays(o.Days)
+	}
+	if o.Tier != "" {
+		req.SetGlacierJobParameters(GlacierJobParameters{Tier: o.Tier})
+	}
+	return req
+}
+
 // RestoreObject is a implementation of https://docs.aws.amazon.com/AmazonS3/latest/API/API_RestoreObject.html AWS S3 API
 func (c *Client) RestoreObject(ctx context.Context, bucketName, objectName, versionID string, req RestoreRequest) error {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return err
 	}
 	if err := s3utils.CheckValidObjectName(objectName); err != nil {
@@ -179,7 +201,7 @@ func (c *Client) RestoreObject(ctx context.Context, bucketName, objectName, vers
 		return err
 	}
 	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
-		return httpRespToErrorResponse(resp, bucketName, "")
+		return httpRespToErrorResponse(resp, bucketName, objectName)
 	}
 	return nil
 }
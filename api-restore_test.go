@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openstor/openstor-go/v7/pkg/credentials"
+)
+
+func TestRestoreObject(t *testing.T) {
+	var restoreBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			if _, ok := r.URL.Query()["restore"]; !ok || r.URL.Query().Get("versionId") != "v1" {
+				t.Errorf("unexpected restore query %q", r.URL.RawQuery)
+			}
+			body, _ := io.ReadAll(r.Body)
+			restoreBody = string(body)
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodHead:
+			w.Header().Set("Last-Modified", "Wed, 01 Jan 2025 00:00:00 GMT")
+			w.Header().Set("ETag", `"etag"`)
+			w.Header().Set("Content-Length", "0")
+			w.Header().Set("x-amz-restore", `ongoing-request="false", expiry-date="Fri, 21 Dec 2012 00:00:00 GMT"`)
+		}
+	}))
+	defer srv.Close()
+
+	// Use anonymous credentials to receive the raw request body.
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:  credentials.NewStaticV4("", "", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := RestoreOptions{Days: 2, Tier: TierBulk}
+	if err = clnt.RestoreObject(context.Background(), "bucket", "object", "v1", opts.Request()); err != nil {
+		t.Fatal(err)
+	}
+	expected := `<RestoreRequest xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Days>2</Days><GlacierJobParameters><Tier>Bulk</Tier></GlacierJobParameters></RestoreRequest>`
+	if restoreBody != expected {
+		t.Fatalf("expected restore request %s, got %s", expected, restoreBody)
+	}
+
+	info, err := clnt.StatObject(context.Background(), "bucket", "object", StatObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiry := time.Date(2012, time.December, 21, 0, 0, 0, 0, time.UTC)
+	if info.Restore == nil || info.Restore.OngoingRestore || !info.Restore.ExpiryTime.Equal(expiry) {
+		t.Fatalf("unexpected restore info %+v", info.Restore)
+	}
+}
+
+func TestAmzRestoreToStruct(t *testing.T) {
+	testCases := []struct {
+		header  string
+		ongoing bool
+		expiry  time.Time
+		success bool
+	}{
+		{`ongoing-request="true"`, true, time.Time{}, true},
+		{`ongoing-request="false", expiry-date="Fri, 21 Dec 2012 00:00:00 GMT"`, false, time.Date(2012, time.December, 21, 0, 0, 0, 0, time.UTC), true},
+		{`expiry-date="Fri, 21 Dec 2012 00:00:00 GMT"`, false, time.Time{}, false},
+		{`ongoing-request="maybe"`, false, time.Time{}, false},
+	}
+
+	for i, testCase := range testCases {
+		ongoing, expiry, err := amzRestoreToStruct(testCase.header)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %t, got error %v", i+1, testCase.success, err)
+		}
+		if ongoing != testCase.ongoing || !expiry.Equal(testCase.expiry) {
+			t.Fatalf("Test %d: unexpected restore state %t, %v", i+1, ongoing, expiry)
+		}
+	}
+}
@@ -35,7 +35,8 @@ type listAllMyBucketsResult struct {
 	Buckets struct {
 		Bucket []BucketInfo
 	}
-	Owner owner
+	Owner             owner
+	ContinuationToken string
 }
 
 // listAllMyDirectoryBucketsResult container for listDirectoryBuckets response.
@@ -30,9 +30,11 @@ import (
 	"hash"
 	"hash/crc32"
 	"io"
+	"iter"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 
 	"github.com/openstor/openstor-go/v7/pkg/encrypt"
 	"github.com/openstor/openstor-go/v7/pkg/s3utils"
@@ -395,11 +397,27 @@ type preludeInfo struct {
 // SelectResults is used for the streaming responses from the server.
 type SelectResults struct {
 	pipeReader *io.PipeReader
+	pipeWriter *io.PipeWriter
 	resp       *http.Response
 	stats      *StatsMessage
 	progress   *ProgressMessage
+
+	mu         sync.Mutex
+	readMode   selectReadMode
+	progressFn func(ProgressMessage)
+	statsFn    func(StatsMessage)
 }
 
+// selectReadMode is how the select results are consumed, either
+// through Read or through Records, but never both.
+type selectReadMode int
+
+const (
+	selectReadNone selectReadMode = iota
+	selectReadStream
+	selectReadRecords
+)
+
 // ProgressMessage is a struct for progress xml message.
 type ProgressMessage struct {
 	XMLName xml.Name `xml:"Progress" json:"-"`
@@ -443,7 +461,7 @@ const (
 // SelectObjectContent is a implementation of http://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectSELECTContent.html AWS S3 API.
 func (c *Client) SelectObjectContent(ctx context.Context, bucketName, objectName string, opts SelectObjectOptions) (*SelectResults, error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return nil, err
 	}
 	if err := s3utils.CheckValidObjectName(objectName); err != nil {
@@ -461,14 +479,15 @@ func (c *Client) SelectObjectContent(ctx context.Context, bucketName, objectName
 
 	// Execute POST on bucket/object.
 	resp, err := c.executeMethod(ctx, http.MethodPost, requestMetadata{
-		bucketName:       bucketName,
-		objectName:       objectName,
-		queryValues:      urlValues,
-		customHeader:     opts.Header(),
-		contentMD5Base64: sumMD5Base64(selectReqBytes),
-		contentSHA256Hex: sum256Hex(selectReqBytes),
-		contentBody:      bytes.NewReader(selectReqBytes),
-		contentLength:    int64(len(selectReqBytes)),
+		bucketName:        bucketName,
+		objectName:        objectName,
+		queryValues:       urlValues,
+		customHeader:      opts.Header(),
+		contentMD5Base64:  sumMD5Base64(selectReqBytes),
+		contentSHA256Hex:  sum256Hex(selectReqBytes),
+		contentBody:       bytes.NewReader(selectReqBytes),
+		contentLength:     int64(len(selectReqBytes)),
+		streamingResponse: true,
 	})
 	if err != nil {
 		return nil, err
@@ -490,8 +509,8 @@ func NewSelectResults(resp *http.Response, bucketName string) (*SelectResults, e
 		stats:      &StatsMessage{},
 		progress:   &ProgressMessage{},
 		pipeReader: pipeReader,
+		pipeWriter: pipeWriter,
 	}
-	streamer.start(pipeWriter)
 	return streamer, nil
 }
 
@@ -501,11 +520,77 @@ func (s *SelectResults) Close() error {
 	return s.pipeReader.Close()
 }
 
+// setReadMode sets how the results are consumed, it returns false
+// if the results are already consumed in another mode, or already
+// iterated through Records.
+func (s *SelectResults) setReadMode(mode selectReadMode) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.readMode == selectReadNone {
+		s.readMode = mode
+		if mode == selectReadStream {
+			s.start(s.pipeWriter)
+		}
+		return true
+	}
+	return s.readMode == mode && mode == selectReadStream
+}
+
 // Read - is a reader compatible implementation for SelectObjectContent records.
 func (s *SelectResults) Read(b []byte) (n int, err error) {
+	if !s.setReadMode(selectReadStream) {
+		return 0, errors.New("select results are already read through Records")
+	}
 	return s.pipeReader.Read(b)
 }
 
+// Records returns an iterator over the payloads of the Records events,
+// decoded from the message stream as they arrive. A payload holds one
+// or more records, a record may be split over consecutive payloads.
+// The yielded slice is only valid until the next iteration. Results
+// can either be iterated once or read through Read, not both.
+func (s *SelectResults) Records() iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		if !s.setReadMode(selectReadRecords) {
+			yield(nil, errors.New("select results are already read"))
+			return
+		}
+		defer closeResponse(s.resp)
+
+		var buf bytes.Buffer
+		for {
+			buf.Reset()
+			end, err := s.nextMessage(&buf)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if end {
+				return
+			}
+			if buf.Len() > 0 && !yield(buf.Bytes(), nil) {
+				return
+			}
+		}
+	}
+}
+
+// OnProgress sets a function called with each Progress event received,
+// from the goroutine decoding the stream when reading through Read.
+func (s *SelectResults) OnProgress(fn func(ProgressMessage)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.progressFn = fn
+}
+
+// OnStats sets a function called with the Stats event received once
+// processing is complete.
+func (s *SelectResults) OnStats(fn func(StatsMessage)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statsFn = fn
+}
+
 // Stats - information about a request's stats when processing is complete.
 func (s *SelectResults) Stats() *StatsMessage {
 	return s.stats
@@ -521,99 +606,105 @@ func (s *SelectResults) Progress() *ProgressMessage {
 func (s *SelectResults) start(pipeWriter *io.PipeWriter) {
 	go func() {
 		for {
-			var prelude preludeInfo
-			headers := make(http.Header)
-			var err error
-
-			// Create CRC code
-			crc := crc32.New(crc32.IEEETable)
-			crcReader := io.TeeReader(s.resp.Body, crc)
-
-			// Extract the prelude(12 bytes) into a struct to extract relevant information.
-			prelude, err = processPrelude(crcReader, crc)
+			end, err := s.nextMessage(pipeWriter)
 			if err != nil {
 				pipeWriter.CloseWithError(err)
 				closeResponse(s.resp)
 				return
 			}
-
-			// Extract the headers(variable bytes) into a struct to extract relevant information
-			if prelude.headerLen > 0 {
-				if err = extractHeader(io.LimitReader(crcReader, int64(prelude.headerLen)), headers); err != nil {
-					pipeWriter.CloseWithError(err)
-					closeResponse(s.resp)
-					return
-				}
+			if end {
+				pipeWriter.Close()
+				closeResponse(s.resp)
+				return
 			}
+		}
+	}()
+}
 
-			// Get the actual payload length so that the appropriate amount of
-			// bytes can be read or parsed.
-			payloadLen := prelude.PayloadLen()
+// nextMessage decodes the next message of the event stream, the
+// payload of Records events is copied to records. Returns true once
+// the End event is received.
+func (s *SelectResults) nextMessage(records io.Writer) (end bool, err error) {
+	var prelude preludeInfo
+	headers := make(http.Header)
 
-			m := messageType(headers.Get("message-type"))
+	// Create CRC code
+	crc := crc32.New(crc32.IEEETable)
+	crcReader := io.TeeReader(s.resp.Body, crc)
 
-			switch m {
-			case errorMsg:
-				pipeWriter.CloseWithError(errors.New(headers.Get("error-code") + ":\"" + headers.Get("error-message") + "\""))
-				closeResponse(s.resp)
-				return
-			case commonMsg:
-				// Get content-type of the payload.
-				c := contentType(headers.Get("content-type"))
-
-				// Get event type of the payload.
-				e := eventType(headers.Get("event-type"))
-
-				// Handle all supported events.
-				switch e {
-				case endEvent:
-					pipeWriter.Close()
-					closeResponse(s.resp)
-					return
-				case recordsEvent:
-					if _, err = io.Copy(pipeWriter, io.LimitReader(crcReader, payloadLen)); err != nil {
-						pipeWriter.CloseWithError(err)
-						closeResponse(s.resp)
-						return
-					}
-				case progressEvent:
-					switch c {
-					case xmlContent:
-						if err = xmlDecoder(io.LimitReader(crcReader, payloadLen), s.progress); err != nil {
-							pipeWriter.CloseWithError(err)
-							closeResponse(s.resp)
-							return
-						}
-					default:
-						pipeWriter.CloseWithError(fmt.Errorf("Unexpected content-type %s sent for event-type %s", c, progressEvent))
-						closeResponse(s.resp)
-						return
-					}
-				case statsEvent:
-					switch c {
-					case xmlContent:
-						if err = xmlDecoder(io.LimitReader(crcReader, payloadLen), s.stats); err != nil {
-							pipeWriter.CloseWithError(err)
-							closeResponse(s.resp)
-							return
-						}
-					default:
-						pipeWriter.CloseWithError(fmt.Errorf("Unexpected content-type %s sent for event-type %s", c, statsEvent))
-						closeResponse(s.resp)
-						return
-					}
+	// Extract the prelude(12 bytes) into a struct to extract relevant information.
+	prelude, err = processPrelude(crcReader, crc)
+	if err != nil {
+		return false, err
+	}
+
+	// Extract the headers(variable bytes) into a struct to extract relevant information
+	if prelude.headerLen > 0 {
+		if err = extractHeader(io.LimitReader(crcReader, int64(prelude.headerLen)), headers); err != nil {
+			return false, err
+		}
+	}
+
+	// Get the actual payload length so that the appropriate amount of
+	// bytes can be read or parsed.
+	payloadLen := prelude.PayloadLen()
+
+	m := messageType(headers.Get("message-type"))
+
+	switch m {
+	case errorMsg:
+		return false, errors.New(headers.Get("error-code") + ":\"" + headers.Get("error-message") + "\"")
+	case commonMsg:
+		// Get content-type of the payload.
+		c := contentType(headers.Get("content-type"))
+
+		// Get event type of the payload.
+		e := eventType(headers.Get("event-type"))
+
+		// Handle all supported events.
+		switch e {
+		case endEvent:
+			return true, nil
+		case recordsEvent:
+			if _, err = io.Copy(records, io.LimitReader(crcReader, payloadLen)); err != nil {
+				return false, err
+			}
+		case progressEvent:
+			switch c {
+			case xmlContent:
+				if err = xmlDecoder(io.LimitReader(crcReader, payloadLen), s.progress); err != nil {
+					return false, err
+				}
+				s.mu.Lock()
+				fn := s.progressFn
+				s.mu.Unlock()
+				if fn != nil {
+					fn(*s.progress)
 				}
+			default:
+				return false, fmt.Errorf("Unexpected content-type %s sent for event-type %s", c, progressEvent)
 			}
-
-			// Ensures that the full message's CRC is correct and
-			// that the message is not corrupted
-			if err := checkCRC(s.resp.Body, crc.Sum32()); err != nil {
-				pipeWriter.CloseWithError(err)
-				closeResponse(s.resp)
-				return
+		case statsEvent:
+			switch c {
+			case xmlContent:
+				if err = xmlDecoder(io.LimitReader(crcReader, payloadLen), s.stats); err != nil {
+					return false, err
+				}
+				s.mu.Lock()
+				fn := s.statsFn
+				s.mu.Unlock()
+				if fn != nil {
+					fn(*s.stats)
+				}
+			default:
+				return false, fmt.Errorf("Unexpected content-type %s sent for event-type %s", c, statsEvent)
 			}
 		}
-	}()
+	}
+
+	// Ensures that the full message's CRC is correct and
+	// that the message is not corrupted
+	return false, checkCRC(s.resp.Body, crc.Sum32())
 }
 
 // PayloadLen is a function that calculates the length of the payload.
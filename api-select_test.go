@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// selectMessage encodes an event stream message with string headers.
+func selectMessage(headers [][2]string, payload string) []byte {
+	var hdr bytes.Buffer
+	for _, h := range headers {
+		hdr.WriteByte(byte(len(h[0])))
+		hdr.WriteString(h[0])
+		hdr.WriteByte(7)
+		binary.Write(&hdr, binary.BigEndian, uint16(len(h[1])))
+		hdr.WriteString(h[1])
+	}
+
+	var msg bytes.Buffer
+	binary.Write(&msg, binary.BigEndian, uint32(16+hdr.Len()+len(payload)))
+	binary.Write(&msg, binary.BigEndian, uint32(hdr.Len()))
+	binary.Write(&msg, binary.BigEndian, crc32.ChecksumIEEE(msg.Bytes()))
+	msg.Write(hdr.Bytes())
+	msg.WriteString(payload)
+	binary.Write(&msg, binary.BigEndian, crc32.ChecksumIEEE(msg.Bytes()))
+	return msg.Bytes()
+}
+
+func selectEvent(event, contentType, payload string) []byte {
+	headers := [][2]string{{":message-type", "event"}, {":event-type", event}}
+	if contentType != "" {
+		headers = append(headers, [2]string{":content-type", contentType})
+	}
+	return selectMessage(headers, payload)
+}
+
+func newTestSelectResults(t *testing.T, messages ...[]byte) *SelectResults {
+	t.Helper()
+	res, err := NewSelectResults(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(bytes.Join(messages, nil))),
+	}, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestSelectResultsRecords(t *testing.T) {
+	stream := [][]byte{
+		selectEvent("Records", "application/octet-stream", `{"a":1}`+"\n"),
+		selectEvent("Progress", "text/xml", `<Progress><BytesScanned>10</BytesScanned><BytesProcessed>10</BytesProcessed><BytesReturned>8</BytesReturned></Progress>`),
+		selectEvent("Records", "application/octet-stream", `{"a":2}`+"\n"),
+		selectEvent("Stats", "text/xml", `<Stats><BytesScanned>20</BytesScanned><BytesProcessed>20</BytesProcessed><BytesReturned>16</BytesReturned></Stats>`),
+		selectEvent("End", "", ""),
+	}
+
+	res := newTestSelectResults(t, stream...)
+	var progress []ProgressMessage
+	var stats []StatsMessage
+	res.OnProgress(func(p ProgressMessage) { progress = append(progress, p) })
+	res.OnStats(func(s StatsMessage) { stats = append(stats, s) })
+
+	var records []string
+	for payload, err := range res.Records() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, string(payload))
+	}
+	if len(records) != 2 || records[0] != `{"a":1}`+"\n" || records[1] != `{"a":2}`+"\n" {
+		t.Fatalf("unexpected records %q", records)
+	}
+	if len(progress) != 1 || progress[0].BytesReturned != 8 {
+		t.Fatalf("unexpected progress %+v", progress)
+	}
+	if len(stats) != 1 || stats[0].BytesScanned != 20 || res.Stats().BytesReturned != 16 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if _, err := res.Read(make([]byte, 1)); err == nil {
+		t.Fatal("expected reading iterated results to fail")
+	}
+	for _, err := range res.Records() {
+		if err == nil {
+			t.Fatal("expected iterating the results twice to fail")
+		}
+	}
+
+	// The same stream read through Read.
+	res = newTestSelectResults(t, stream...)
+	data, err := io.ReadAll(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"a":1}`+"\n"+`{"a":2}`+"\n" {
+		t.Fatalf("unexpected data %q", data)
+	}
+	for _, err := range res.Records() {
+		if err == nil {
+			t.Fatal("expected iterating read results to fail")
+		}
+	}
+}
+
+func TestSelectResultsRecordsError(t *testing.T) {
+	res := newTestSelectResults(t,
+		selectEvent("Records", "application/octet-stream", "a,b\n"),
+		selectMessage([][2]string{{":message-type", "error"}, {":error-code", "InternalError"}, {":error-message", "failed"}}, ""),
+	)
+
+	var records int
+	var lastErr error
+	for _, err := range res.Records() {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		records++
+	}
+	if records != 1 || lastErr == nil || lastErr.Error() != `InternalError:"failed"` {
+		t.Fatalf("unexpected %d records and error %v", records, lastErr)
+	}
+}
@@ -22,7 +22,9 @@ package openstor
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/openstor/openstor-go/v7/pkg/s3utils"
 )
@@ -31,7 +33,7 @@ import (
 // control cancellations and timeouts.
 func (c *Client) BucketExists(ctx context.Context, bucketName string) (bool, error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return false, err
 	}
 
@@ -59,11 +61,27 @@ func (c *Client) BucketExists(ctx context.Context, bucketName string) (bool, err
 	return true, nil
 }
 
+// BucketAccess verifies if bucket exists and you have permission to access it.
+// Unlike BucketExists a bucket that exists but that you are not allowed to
+// access, i.e. it is owned by someone else, is reported as exists=true and
+// accessible=false without an error. Other errors, including 403 errors
+// such as InvalidAccessKeyId, are returned.
+func (c *Client) BucketAccess(ctx context.Context, bucketName string) (exists, accessible bool, err error) {
+	found, err := c.BucketExists(ctx, bucketName)
+	if err != nil {
+		if ToErrorResponse(err).Code == AccessDenied {
+			return true, false, nil
+		}
+		return false, false, err
+	}
+	return found, found, nil
+}
+
 // StatObject verifies if object exists, you have permission to access it
 // and returns information about the object.
 func (c *Client) StatObject(ctx context.Context, bucketName, objectName string, opts StatObjectOptions) (ObjectInfo, error) {
 	// Input validation.
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return ObjectInfo{}, ErrorResponse{
 			StatusCode: http.StatusBadRequest,
 			Code:       InvalidBucketName,
@@ -77,6 +95,9 @@ func (c *Client) StatObject(ctx context.Context, bucketName, objectName string,
 			Message:    err.Error(),
 		}
 	}
+	if opts.PartNumber < 0 || opts.PartNumber > maxPartsCount {
+		return ObjectInfo{}, errInvalidArgument(fmt.Sprintf("Part number must be between 1 and %d.", maxPartsCount))
+	}
 	headers := opts.Header()
 	if opts.Internal.ReplicationDeleteMarker {
 		headers.Set(minIOBucketReplicationDeleteMarker, "true")
@@ -89,9 +110,11 @@ func (c *Client) StatObject(ctx context.Context, bucketName, objectName string,
 	resp, err := c.executeMethod(ctx, http.MethodHead, requestMetadata{
 		bucketName:       bucketName,
 		objectName:       objectName,
+		bucketLocation:   opts.Region,
 		queryValues:      opts.toQueryValues(),
 		contentSHA256Hex: emptySHA256Hex,
 		customHeader:     headers,
+		userAgent:        opts.UserAgent,
 	})
 	defer closeResponse(resp)
 	if err != nil {
@@ -125,3 +148,75 @@ func (c *Client) StatObject(ctx context.Context, bucketName, objectName string,
 
 	return ToObjectInfo(bucketName, objectName, resp.Header)
 }
+
+// defaultStatObjectsConcurrency is the number of objects StatObjects
+// sends HEAD requests for concurrently by default.
+const defaultStatObjectsConcurrency = 4
+
+// BatchStatOptions holds the options of a StatObjects call.
+type BatchStatOptions struct {
+	// Concurrency is the number of HEAD requests sent concurrently,
+	// 4 if not set.
+	Concurrency int
+	// Options are used for every object.
+	Options StatObjectOptions
+}
+
+// StatResult is the result of StatObjects for one object.
+type StatResult struct {
+	Key  string
+	Info ObjectInfo
+	Err  error
+	// NotFound is set along with Err if the object does not exist.
+	NotFound bool
+}
+
+// StatObjects returns information about the objects with the given keys,
+// sending opts.Concurrency HEAD requests at a time. One result is sent
+// for every key, in the order the requests complete. Objects that do not
+// exist are returned with NotFound set.
+//
+// If the context is canceled the keys not requested yet are skipped.
+// The caller must drain the channel until it is closed, otherwise
+// goroutines are leaked.
+func (c *Client) StatObjects(ctx context.Context, bucketName string, keys []string, opts BatchStatOptions) <-chan StatResult {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultStatObjectsConcurrency
+	}
+
+	resultCh := make(chan StatResult, concurrency)
+	go func() {
+		defer close(resultCh)
+
+		keyCh := make(chan string)
+		var wg sync.WaitGroup
+		for range min(concurrency, len(keys)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for key := range keyCh {
+					info, err := c.StatObject(ctx, bucketName, key, opts.Options)
+					res := StatResult{Key: key, Info: info, Err: err}
+					if err != nil {
+						code := ToErrorResponse(err).Code
+						res.NotFound = code == NoSuchKey || code == NoSuchVersion
+					}
+					resultCh <- res
+				}
+			}()
+		}
+
+	feed:
+		for _, key := range keys {
+			select {
+			case keyCh <- key:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+		close(keyCh)
+		wg.Wait()
+	}()
+	return resultCh
+}
@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBucketAccess(t *testing.T) {
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.Trim(r.URL.Path, "/") {
+		case "owned":
+			w.WriteHeader(http.StatusOK)
+		case "foreign":
+			w.WriteHeader(http.StatusForbidden)
+		case "unknown-key":
+			w.Header().Set("x-minio-error-code", "InvalidAccessKeyId")
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	testCases := []struct {
+		bucketName string
+		exists     bool
+		accessible bool
+		errCode    string
+	}{
+		{"owned", true, true, ""},
+		{"foreign", true, false, ""},
+		{"missing", false, false, ""},
+		{"unknown-key", false, false, "InvalidAccessKeyId"},
+	}
+
+	for i, testCase := range testCases {
+		exists, accessible, err := clnt.BucketAccess(context.Background(), testCase.bucketName)
+		if ToErrorResponse(err).Code != testCase.errCode {
+			t.Fatalf("Test %d: expected error code %q, got %v", i+1, testCase.errCode, err)
+		}
+		if exists != testCase.exists || accessible != testCase.accessible {
+			t.Fatalf("Test %d: expected (%t, %t), got (%t, %t)", i+1, testCase.exists, testCase.accessible, exists, accessible)
+		}
+	}
+}
+
+func TestStatObjectPartNumber(t *testing.T) {
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		if r.URL.Query().Get("partNumber") != "2" {
+			w.Header().Set("ETag", `"etag-3"`)
+			w.Header().Set("Content-Length", "12582912")
+			return
+		}
+		if r.Header.Get("x-amz-checksum-mode") != "ENABLED" {
+			t.Errorf("expected checksum mode, got %q", r.Header.Get("x-amz-checksum-mode"))
+		}
+		w.Header().Set("ETag", `"part-2"`)
+		w.Header().Set("Content-Length", "5242880")
+		w.Header().Set("x-amz-checksum-crc32c", "yZRlqg==")
+		w.Header().Set("x-amz-mp-parts-count", "3")
+		w.WriteHeader(http.StatusPartialContent)
+	}))
+
+	ctx := context.Background()
+	info, err := clnt.StatObject(ctx, "bucket", "object", StatObjectOptions{PartNumber: 2, Checksum: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ETag != "part-2" || info.Size != 5242880 || info.ChecksumCRC32C != "yZRlqg==" || info.PartsCount != 3 {
+		t.Fatalf("unexpected part info %+v", info)
+	}
+
+	info, err = clnt.StatObject(ctx, "bucket", "object", StatObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ETag != "etag-3" || info.Size != 12582912 || info.PartsCount != 0 {
+		t.Fatalf("unexpected object info %+v", info)
+	}
+
+	for _, partNumber := range []int{-1, maxPartsCount + 1} {
+		if _, err = clnt.StatObject(ctx, "bucket", "object", StatObjectOptions{PartNumber: partNumber}); ToErrorResponse(err).Code != InvalidArgument {
+			t.Fatalf("expected part number %d to be rejected, got %v", partNumber, err)
+		}
+	}
+}
+
+func TestStatObjects(t *testing.T) {
+	var inflight, maxInflight int32
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInflight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInflight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+		switch {
+		case strings.HasPrefix(key, "missing"):
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasPrefix(key, "denied"):
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.Header().Set("Content-Length", strconv.Itoa(len(key)))
+			w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+			w.Header().Set("ETag", `"`+key+`"`)
+		}
+	}))
+
+	var keys []string
+	for i := range 20 {
+		keys = append(keys, "object-"+strconv.Itoa(i))
+	}
+	keys = append(keys, "missing-1", "denied-1")
+
+	results := make(map[string]StatResult)
+	for res := range clnt.StatObjects(context.Background(), "bucket", keys, BatchStatOptions{Concurrency: 3}) {
+		results[res.Key] = res
+	}
+	if len(results) != len(keys) {
+		t.Fatalf("expected %d results, got %d", len(keys), len(results))
+	}
+	for i, key := range keys {
+		res := results[key]
+		switch {
+		case strings.HasPrefix(key, "missing"):
+			if !res.NotFound || ToErrorResponse(res.Err).Code != NoSuchKey {
+				t.Fatalf("Test %d: expected %s not to be found, got %+v", i+1, key, res)
+			}
+		case strings.HasPrefix(key, "denied"):
+			if res.NotFound || ToErrorResponse(res.Err).Code != AccessDenied {
+				t.Fatalf("Test %d: expected %s to be denied, got %+v", i+1, key, res)
+			}
+		default:
+			if res.Err != nil || res.Info.ETag != key || res.Info.Size != int64(len(key)) {
+				t.Fatalf("Test %d: unexpected result %+v", i+1, res)
+			}
+		}
+	}
+	if maxInflight > 3 {
+		t.Fatalf("expected at most 3 concurrent requests, got %d", maxInflight)
+	}
+
+	// Keys are skipped once the context is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var n int
+	for res := range clnt.StatObjects(ctx, "bucket", keys, BatchStatOptions{}) {
+		if !errors.Is(res.Err, context.Canceled) {
+			t.Fatalf("expected the context error, got %v", res.Err)
+		}
+		n++
+	}
+	if n == len(keys) {
+		t.Fatal("expected keys to be skipped after the context is canceled")
+	}
+}
@@ -7,7 +7,11 @@ package openstor
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -20,10 +24,12 @@ import (
 	"net/url"
 	"os"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
+	"weak"
 
 	"github.com/dustin/go-humanize"
 	md5simd "github.com/openstor/md5-simd"
@@ -59,6 +65,7 @@ type Client struct {
 
 	// Needs allocation.
 	httpClient         *http.Client
+	sourceClient       *http.Client
 	httpTrace          *httptrace.ClientTrace
 	bucketLocCache     *kvcache.Cache[string, string]
 	bucketSessionCache *kvcache.Cache[string, credentials.Value]
@@ -68,9 +75,23 @@ type Client struct {
 	isTraceEnabled  bool
 	traceErrorsOnly bool
 	traceOutput     io.Writer
+	requestTrace    func(*http.Request)
+	responseTrace   func(*http.Response)
+
+	// Run on every request and response.
+	requestInterceptors  []func(*http.Request) error
+	responseInterceptors []func(*http.Response) error
+
+	// Bounds requests whose context has no deadline.
+	defaultOperationTimeout time.Duration
+
+	// Receives the metrics of every request.
+	metricsCollector MetricsCollector
 
 	// S3 specific accelerated endpoint.
 	s3AccelerateEndpoint string
+	// S3 Express One Zone support for directory buckets.
+	s3Express bool
 	// S3 dual-stack endpoints are enabled by default.
 	s3DualstackEnabled bool
 
@@ -95,6 +116,24 @@ type Client struct {
 
 	trailingHeaderSupport bool
 	maxRetries            int
+
+	// Account ID sent as x-amz-expected-bucket-owner on bucket requests.
+	expectedBucketOwner string
+
+	// Omit Content-Md5 unless the request requires it.
+	disableContentMD5 bool
+
+	// Do not retry requests redirected to the bucket region.
+	disableRegionRedirect bool
+
+	// Only check bucket names are non-empty and without slashes.
+	laxBucketNameValidation bool
+
+	// Stops the running health check, called by Close. Set, along
+	// with the context of the running health check, under healthCheckMu.
+	healthCheckMu     sync.Mutex
+	healthCheckCtx    context.Context
+	healthCheckCancel context.CancelFunc
 }
 
 // Options for New method
@@ -106,6 +145,24 @@ type Options struct {
 	Region       string
 	BucketLookup BucketLookupType
 
+	// CustomDialer establishes the connections to the endpoint instead
+	// of the dialer of the transport, e.g. to connect to a specific IP
+	// address without changing the endpoint host. It is set as the
+	// DialContext of DefaultTransport, or of a clone of Transport when
+	// it is a *http.Transport, other transports cannot be combined with
+	// it.
+	CustomDialer func(ctx context.Context, network, addr string) (net.Conn, error)
+
+	// RootCAs verifies the certificate of the endpoint instead of the
+	// system certificate pool. CertificatePins additionally requires the
+	// SHA256 sum of the subject public key info of the endpoint leaf
+	// certificate to be one of the pins, it requires Secure. Like
+	// CustomDialer, they are set on DefaultTransport or on a clone of
+	// Transport when it is a *http.Transport. Source URLs of
+	// PutObjectFromURL are verified without them.
+	RootCAs         *x509.CertPool
+	CertificatePins [][]byte
+
 	// Allows setting a custom region lookup based on URL pattern
 	// not all URL patterns are covered by this library so if you
 	// have a custom endpoints with many regions you can use this
@@ -142,6 +199,85 @@ type Options struct {
 	// Number of times a request is retried. Defaults to 10 retries if this option is not configured.
 	// Set to 1 to disable retries.
 	MaxRetries int
+
+	// DisableContentMD5 omits the Content-Md5 header for gateways not
+	// supporting it, the payload is protected by its SHA256 instead.
+	// Requests requiring it, like DeleteObjects, still send it.
+	DisableContentMD5 bool
+
+	// DisableRegionRedirect disables retrying, against the bucket region,
+	// requests rejected with a PermanentRedirect or an
+	// AuthorizationHeaderMalformed error carrying the bucket region.
+	// Such requests are retried once and only when the request body
+	// can be replayed.
+	DisableRegionRedirect bool
+
+	// LaxBucketNameValidation only checks bucket names are made of
+	// letters, digits, '.', '-' and '_' and are not "." or "..", to
+	// access legacy buckets whose names predate the S3 naming rules,
+	// e.g. names with uppercase letters, consecutive dots or longer
+	// than 63 characters.
+	// Bucket names are always checked strictly by MakeBucket.
+	LaxBucketNameValidation bool
+
+	// S3Express enables S3 Express One Zone support for directory
+	// buckets, named <name>--<zone-id>--x-s3, on any endpoint: requests
+	// to them authenticate with the credentials of a cached CreateSession
+	// and are signed for the s3express service. It is always enabled
+	// for Amazon S3 endpoints.
+	S3Express bool
+
+	// S3TransferAcceleration routes bucket requests through the AWS S3
+	// transfer acceleration endpoint, operations not supported by the
+	// accelerated endpoint fall back to the regular endpoint. Only
+	// applies to AWS S3 and requires DNS-compliant bucket names.
+	S3TransferAcceleration bool
+
+	// ExpectedBucketOwner is the account ID expected to own the buckets
+	// accessed by this client, it is sent as x-amz-expected-bucket-owner
+	// with every bucket and object request. A per-call value set through
+	// the request headers (for example GetObjectOptions.Set) takes precedence.
+	ExpectedBucketOwner string
+
+	// HealthCheckInterval starts a background health check probing the
+	// endpoint at this interval, its result is reported by IsOnline.
+	// The interval must be at least one second. The health check stops
+	// when the client is garbage collected.
+	HealthCheckInterval time.Duration
+
+	// RequestTrace is called with every request sent, and ResponseTrace
+	// with every response received, for selective logging. They are
+	// passed copies without the body, modifying them has no effect on
+	// the request or the response.
+	RequestTrace  func(*http.Request)
+	ResponseTrace func(*http.Response)
+
+	// RequestInterceptors are run in order on every request before it is
+	// signed, such that the headers they set are signed, e.g. to add
+	// request IDs. They must not change the method, URL, host or content
+	// length of the request, nor set the headers computed for its
+	// signature. An error returned by an interceptor fails the request.
+	RequestInterceptors []func(*http.Request) error
+
+	// ResponseInterceptors are run in order on every response received,
+	// before it is processed, e.g. to collect metrics. An error returned
+	// by an interceptor fails the request.
+	ResponseInterceptors []func(*http.Response) error
+
+	// DefaultOperationTimeout bounds every request whose context has no
+	// deadline, including its retries and the reading of its response
+	// body, which is canceled when the timeout expires. Requests of an
+	// operation sending several of them, e.g. the parts of a multipart
+	// upload, are bounded individually. GetObject and
+	// SelectObjectContent are exempt, their response body is read for
+	// as long as the caller streams it: pass a context with a deadline
+	// to bound them.
+	DefaultOperationTimeout time.Duration
+
+	// MetricsCollector observes the latency, status and bytes
+	// transferred of every request, e.g. to export them to a metrics
+	// library.
+	MetricsCollector MetricsCollector
 }
 
 // Global constants.
@@ -184,6 +320,19 @@ func New(endpoint string, opts *Options) (*Client, error) {
 		// Amazon S3 endpoints are resolved into dual-stack endpoints by default
 		// for backwards compatibility.
 		clnt.s3DualstackEnabled = true
+		if opts.S3TransferAcceleration {
+			clnt.s3AccelerateEndpoint = s3AccelerateEndpoint
+		}
+	}
+
+	if opts.HealthCheckInterval > 0 {
+		cancel, err := clnt.HealthCheck(opts.HealthCheckInterval)
+		if err != nil {
+			return nil, err
+		}
+		// The health check only holds a weak reference to the client,
+		// stop it once the client is collected.
+		runtime.AddCleanup(clnt, func(cancel context.CancelFunc) { cancel() }, cancel)
 	}
 
 	return clnt, nil
@@ -252,6 +401,43 @@ func privateNew(endpoint string, opts *Options) (*Client, error) {
 			return nil, err
 		}
 	}
+	if len(opts.CertificatePins) > 0 && !opts.Secure {
+		return nil, errInvalidArgument("CertificatePins require Secure to be set.")
+	}
+	for _, pin := range opts.CertificatePins {
+		if len(pin) != sha256.Size {
+			return nil, errInvalidArgument("CertificatePins must be SHA256 sums of 32 bytes.")
+		}
+	}
+	// Sources of PutObjectFromURL are not the endpoint, they are not
+	// verified by RootCAs and CertificatePins.
+	sourceTransport := transport
+	if opts.CustomDialer != nil || opts.RootCAs != nil || len(opts.CertificatePins) > 0 {
+		tr, ok := transport.(*http.Transport)
+		if !ok {
+			return nil, errInvalidArgument("CustomDialer, RootCAs and CertificatePins require Transport to be a *http.Transport.")
+		}
+		if opts.Transport != nil {
+			tr = tr.Clone()
+		}
+		if opts.CustomDialer != nil {
+			tr.DialContext = opts.CustomDialer
+		}
+		sourceTransport = tr
+		if opts.RootCAs != nil || len(opts.CertificatePins) > 0 {
+			sourceTransport = tr.Clone()
+			if tr.TLSClientConfig == nil {
+				tr.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+			}
+			if opts.RootCAs != nil {
+				tr.TLSClientConfig.RootCAs = opts.RootCAs
+			}
+			if len(opts.CertificatePins) > 0 {
+				tr.TLSClientConfig.VerifyConnection = verifyCertificatePins(opts.CertificatePins, tr.TLSClientConfig.VerifyConnection)
+			}
+		}
+		transport = tr
+	}
 
 	clnt.httpTrace = opts.Trace
 
@@ -263,6 +449,13 @@ func privateNew(endpoint string, opts *Options) (*Client, error) {
 			return http.ErrUseLastResponse
 		},
 	}
+	clnt.sourceClient = clnt.httpClient
+	if sourceTransport != transport {
+		clnt.sourceClient = &http.Client{
+			Transport:     sourceTransport,
+			CheckRedirect: clnt.httpClient.CheckRedirect,
+		}
+	}
 
 	// Sets custom region, if region is empty bucket location cache is used automatically.
 	if opts.Region == "" {
@@ -308,6 +501,19 @@ func privateNew(endpoint string, opts *Options) (*Client, error) {
 		clnt.maxRetries = opts.MaxRetries
 	}
 
+	clnt.expectedBucketOwner = opts.ExpectedBucketOwner
+	clnt.disableContentMD5 = opts.DisableContentMD5
+	clnt.disableRegionRedirect = opts.DisableRegionRedirect
+	clnt.laxBucketNameValidation = opts.LaxBucketNameValidation
+	clnt.s3Express = opts.S3Express
+
+	clnt.requestTrace = opts.RequestTrace
+	clnt.responseTrace = opts.ResponseTrace
+	clnt.requestInterceptors = slices.Clone(opts.RequestInterceptors)
+	clnt.responseInterceptors = slices.Clone(opts.ResponseInterceptors)
+	clnt.defaultOperationTimeout = opts.DefaultOperationTimeout
+	clnt.metricsCollector = opts.MetricsCollector
+
 	// Return.
 	return clnt, nil
 }
@@ -368,6 +574,9 @@ func (c *Client) TraceOff() {
 	c.traceErrorsOnly = false
 }
 
+// s3AccelerateEndpoint is the AWS S3 transfer acceleration endpoint.
+const s3AccelerateEndpoint = "s3-accelerate.amazonaws.com"
+
 // SetS3TransferAccelerate - turns s3 accelerated endpoint on or off for all your
 // requests. This feature is only specific to S3 for all other endpoints this
 // function does nothing. To read further details on s3 transfer acceleration
@@ -444,25 +653,44 @@ func (c *Client) IsOffline() bool {
 // Returns a context cancellation function, to stop the health check,
 // and an error if health check is already started.
 func (c *Client) HealthCheck(hcDuration time.Duration) (context.CancelFunc, error) {
-	if atomic.LoadInt32(&c.healthStatus) != unknown {
-		return nil, fmt.Errorf("health check is running")
-	}
 	if hcDuration < 1*time.Second {
 		return nil, fmt.Errorf("health check duration should be at least 1 second")
 	}
+	c.healthCheckMu.Lock()
+	if c.healthCheckCancel != nil {
+		c.healthCheckMu.Unlock()
+		return nil, fmt.Errorf("health check is running")
+	}
 	probeBucketName := randString(60, rand.NewSource(time.Now().UnixNano()), "probe-health-")
-	ctx, cancelFn := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	// Only keep a weak reference, such that a client that is no
+	// longer used can be collected while the health check runs.
+	wc := weak.Make(c)
+	cancelFn := func() {
+		cancel()
+		c := wc.Value()
+		if c == nil {
+			return
+		}
+		c.healthCheckMu.Lock()
+		defer c.healthCheckMu.Unlock()
+		// Only reset the status if no other health check was started
+		// since, the probes of this one being aborted by cancel.
+		if c.healthCheckCtx == ctx {
+			c.healthCheckCtx, c.healthCheckCancel = nil, nil
+			atomic.StoreInt32(&c.healthStatus, unknown)
+		}
+	}
+	c.healthCheckCtx, c.healthCheckCancel = ctx, cancelFn
 	atomic.StoreInt32(&c.healthStatus, offline)
+	c.healthCheckMu.Unlock()
 	{
 		// Change to online, if we can connect.
 		gctx, gcancel := context.WithTimeout(ctx, 3*time.Second)
-		_, err := c.getBucketLocation(gctx, probeBucketName)
+		err := c.probeHealth(gctx, probeBucketName)
 		gcancel()
-		if !IsNetworkOrHostDown(err, false) {
-			switch ToErrorResponse(err).Code {
-			case NoSuchBucket, AccessDenied, "":
-				atomic.CompareAndSwapInt32(&c.healthStatus, offline, online)
-			}
+		if err == nil {
+			atomic.CompareAndSwapInt32(&c.healthStatus, offline, online)
 		}
 	}
 
@@ -472,22 +700,21 @@ func (c *Client) HealthCheck(hcDuration time.Duration) (context.CancelFunc, erro
 		for {
 			select {
 			case <-ctx.Done():
-				atomic.StoreInt32(&c.healthStatus, unknown)
 				return
 			case <-timer.C:
+				c := wc.Value()
+				if c == nil {
+					return
+				}
 				// Do health check the first time and ONLY if the connection is marked offline
 				if c.IsOffline() {
-					gctx, gcancel := context.WithTimeout(context.Background(), 3*time.Second)
-					_, err := c.getBucketLocation(gctx, probeBucketName)
+					gctx, gcancel := context.WithTimeout(ctx, 3*time.Second)
+					err := c.probeHealth(gctx, probeBucketName)
 					gcancel()
-					if !IsNetworkOrHostDown(err, false) {
-						switch ToErrorResponse(err).Code {
-						case NoSuchBucket, AccessDenied, "":
-							atomic.CompareAndSwapInt32(&c.healthStatus, offline, online)
-						}
+					if err == nil {
+						atomic.CompareAndSwapInt32(&c.healthStatus, offline, online)
 					}
 				}
-
 				timer.Reset(duration)
 			}
 		}
@@ -495,10 +722,65 @@ func (c *Client) HealthCheck(hcDuration time.Duration) (context.CancelFunc, erro
 	return cancelFn, nil
 }
 
+// Close releases the idle connections of the underlying transport and
+// stops the running health check, if any. The client must not be used
+// after Close.
+func (c *Client) Close() error {
+	c.healthCheckMu.Lock()
+	cancel := c.healthCheckCancel
+	c.healthCheckMu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+	c.httpClient.CloseIdleConnections()
+	if c.sourceClient != c.httpClient {
+		c.sourceClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+// CheckHealth synchronously probes the endpoint, an error is returned
+// if it cannot be reached. If a health check is running, a successful
+// probe marks the client online.
+func (c *Client) CheckHealth(ctx context.Context) error {
+	probeBucketName := randString(60, rand.NewSource(time.Now().UnixNano()), "probe-health-")
+	if err := c.probeHealth(ctx, probeBucketName); err != nil {
+		return err
+	}
+	atomic.CompareAndSwapInt32(&c.healthStatus, offline, online)
+	return nil
+}
+
+// probeHealth sends a bucket location request for a non-existent
+// bucket, any S3 response to it means that the endpoint is up.
+func (c *Client) probeHealth(ctx context.Context, probeBucketName string) error {
+	req, err := c.getBucketLocationRequest(ctx, probeBucketName)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+	_, err = processBucketLocationResponse(resp, probeBucketName)
+	switch ToErrorResponse(err).Code {
+	case NoSuchBucket, AccessDenied, "":
+		return nil
+	}
+	return err
+}
+
 // requestMetadata - is container for all the values to make a request.
 type requestMetadata struct {
 	// If set newRequest presigns the URL.
 	presignURL bool
+	// If set the presigned URL is signed for this host.
+	presignHost string
+
+	// If set the request is sent to this path of the MinIO admin API,
+	// e.g. "/set-bucket-quota", instead of a bucket or an object.
+	adminPath string
 
 	// User supplied.
 	bucketName         string
@@ -514,11 +796,35 @@ type requestMetadata struct {
 	contentLength    int64
 	contentMD5Base64 string // carries base64 encoded md5sum
 	contentSHA256Hex string // carries hex encoded sha256sum
+	requireMD5       bool   // send contentMD5Base64 even if disabled
 	streamSha256     bool
 	addCrc           *ChecksumType
 	trailer          http.Header // (http.Request).Trailer. Requires v4 signature.
 
 	expect200OKWithError bool
+	// Appended to the User-Agent of the client for this request.
+	userAgent string
+	// If set the ExpectedBucketOwner of the client is not sent.
+	noExpectedBucketOwner bool
+	// Set on the probes of isBucketOwnerMismatch, whose AccessDenied
+	// errors are not probed in turn.
+	bucketOwnerProbe bool
+	// Set for responses streamed to the caller, which are not bounded
+	// by the default operation timeout.
+	streamingResponse bool
+}
+
+// cancelOnCloseBody cancels the context of a request once its response
+// body is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
 }
 
 // dumpHTTP - dump HTTP request and response.
@@ -589,6 +895,12 @@ func (c *Client) do(req *http.Request) (resp *http.Response, err error) {
 		}
 	}()
 
+	if c.requestTrace != nil {
+		traceReq := req.Clone(req.Context())
+		traceReq.Body, traceReq.GetBody = http.NoBody, nil
+		c.requestTrace(traceReq)
+	}
+
 	resp, err = c.httpClient.Do(req)
 	if err != nil {
 		// Handle this specifically for now until future Golang versions fix this issue properly.
@@ -610,6 +922,19 @@ func (c *Client) do(req *http.Request) (resp *http.Response, err error) {
 		return nil, errInvalidArgument(msg)
 	}
 
+	if c.responseTrace != nil {
+		traceResp := *resp
+		traceResp.Header = resp.Header.Clone()
+		traceResp.Trailer = resp.Trailer.Clone()
+		traceResp.Body = http.NoBody
+		c.responseTrace(&traceResp)
+	}
+
+	if err = c.interceptResponse(resp); err != nil {
+		closeResponse(resp)
+		return nil, err
+	}
+
 	// If trace is enabled, dump http request and response,
 	// except when the traceErrorsOnly enabled and the response's status code is ok
 	if c.isTraceEnabled && (!c.traceErrorsOnly || resp.StatusCode != http.StatusOK) {
@@ -622,9 +947,28 @@ func (c *Client) do(req *http.Request) (resp *http.Response, err error) {
 	return resp, nil
 }
 
+// sumSHA256Hex returns the hex encoded sha256sum of body, the body
+// is seeked back to where it started.
+func (c *Client) sumSHA256Hex(body io.Reader, seeker io.Seeker) (string, error) {
+	offset, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", err
+	}
+	h := c.sha256Hasher()
+	defer h.Close()
+	if _, err = io.Copy(h, body); err != nil {
+		return "", err
+	}
+	if _, err = seeker.Seek(offset, io.SeekStart); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 // List of success status.
 var successStatus = map[int]struct{}{
 	http.StatusOK:             {},
+	http.StatusAccepted:       {},
 	http.StatusNoContent:      {},
 	http.StatusPartialContent: {},
 }
@@ -637,6 +981,24 @@ func (c *Client) executeMethod(ctx context.Context, method string, metadata requ
 		return nil, errors.New(c.endpointURL.String() + " is offline.")
 	}
 
+	// Reject tokens which would inject headers.
+	if strings.ContainsAny(metadata.userAgent, "\r\n") {
+		return nil, errInvalidArgument("User-Agent token cannot contain CR or LF characters.")
+	}
+
+	if _, ok := ctx.Deadline(); !ok && c.defaultOperationTimeout > 0 && !metadata.streamingResponse {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.defaultOperationTimeout)
+		defer func() {
+			// Keep the deadline until the response body is read.
+			if res == nil || res.Body == nil {
+				cancel()
+				return
+			}
+			res.Body = &cancelOnCloseBody{ReadCloser: res.Body, cancel: cancel}
+		}()
+	}
+
 	var retryable bool       // Indicates if request can be retried.
 	var bodySeeker io.Seeker // Extracted seeker from io.Reader.
 	reqRetry := c.maxRetries // Indicates how many times we can retry the request
@@ -662,7 +1024,19 @@ func (c *Client) executeMethod(ctx context.Context, method string, metadata requ
 		}
 	}
 
-	if metadata.addCrc != nil && metadata.contentLength > 0 {
+	if c.disableContentMD5 && !metadata.requireMD5 && metadata.contentMD5Base64 != "" {
+		if metadata.contentSHA256Hex == "" && retryable {
+			// Sign the payload instead of sending its md5sum.
+			sha256Hex, err := c.sumSHA256Hex(metadata.contentBody, bodySeeker)
+			if err != nil {
+				return nil, err
+			}
+			metadata.contentSHA256Hex = sha256Hex
+		}
+		metadata.contentMD5Base64 = ""
+	}
+
+	if metadata.addCrc != nil && metadata.contentLength != 0 {
 		if metadata.trailer == nil {
 			metadata.trailer = make(http.Header, 1)
 		}
@@ -674,6 +1048,14 @@ func (c *Client) executeMethod(ctx context.Context, method string, metadata requ
 		metadata.trailer.Set(metadata.addCrc.Key(), base64.StdEncoding.EncodeToString(crc.Sum(nil)))
 	}
 
+	// Set once the request was redirected to the bucket region.
+	var redirected bool
+
+	var op string // Operation name reported to the metrics collector.
+	if c.metricsCollector != nil {
+		op = metricsOperation(method, metadata)
+	}
+
 	for range c.newRetryTimer(ctx, reqRetry, DefaultRetryUnit, DefaultRetryCap, MaxJitter) {
 		// Retry executes the following function body if request has an
 		// error until maxRetries have been exhausted, retry attempts are
@@ -698,10 +1080,16 @@ func (c *Client) executeMethod(ctx context.Context, method string, metadata requ
 
 			return nil, err
 		}
+		if c.metricsCollector != nil && req.Body != nil && req.Body != http.NoBody {
+			req.Body = &metricsBody{ReadCloser: req.Body, op: op, collector: c.metricsCollector}
+		}
 
 		// Initiate the request.
+		start := time.Now()
 		res, err = c.do(req)
+		elapsed := time.Since(start)
 		if err != nil {
+			c.observeRequest(op, elapsed, 0, err)
 			if isRequestErrorRetryable(ctx, err) {
 				// Retry the request
 				continue
@@ -709,8 +1097,13 @@ func (c *Client) executeMethod(ctx context.Context, method string, metadata requ
 			return nil, err
 		}
 
+		if c.metricsCollector != nil {
+			res.Body = &metricsBody{ReadCloser: res.Body, op: op, collector: c.metricsCollector}
+		}
+
 		_, success := successStatus[res.StatusCode]
 		if success && !metadata.expect200OKWithError {
+			c.observeRequest(op, elapsed, res.StatusCode, nil)
 			// We do not expect 2xx to return an error return.
 			return res, nil
 		} // in all other situations we must first parse the body as ErrorResponse
@@ -721,6 +1114,7 @@ func (c *Client) executeMethod(ctx context.Context, method string, metadata requ
 		// By now, res.Body should be closed
 		closeResponse(res)
 		if err != nil {
+			c.observeRequest(op, elapsed, res.StatusCode, err)
 			return nil, err
 		}
 
@@ -734,14 +1128,32 @@ func (c *Client) executeMethod(ctx context.Context, method string, metadata requ
 		bodySeeker.Seek(0, 0) // Seek back to starting point.
 		res.Body = io.NopCloser(bodySeeker)
 
+		c.observeRequest(op, elapsed, res.StatusCode, apiErr)
 		if apiErr == nil {
 			return res, nil
 		}
 
 		// For errors verify if its retryable otherwise fail quickly.
 		errResponse := ToErrorResponse(apiErr)
+		if errResponse.Code == AccessDenied {
+			if owner := c.expectedBucketOwnerFor(metadata); owner != "" && !metadata.bucketOwnerProbe && c.isBucketOwnerMismatch(ctx, metadata, owner) {
+				errResponse.ExpectedBucketOwner = owner
+			}
+		}
 		err = errResponse
 
+		// The bucket lives in another region, retry once against the
+		// region of the bucket unless the caller asked for a region.
+		if !c.disableRegionRedirect && !redirected && metadata.bucketName != "" && metadata.bucketLocation == "" && errResponse.Region != "" {
+			switch errResponse.Code {
+			case PermanentRedirect, AuthorizationHeaderMalformed:
+				c.bucketLocCache.Set(metadata.bucketName, errResponse.Region)
+				metadata.bucketLocation = errResponse.Region
+				redirected = true
+				continue // Retry.
+			}
+		}
+
 		// Bucket region if set in error response and the error
 		// code dictates invalid region, we can retry the request
 		// with the new region.
@@ -762,8 +1174,9 @@ func (c *Client) executeMethod(ctx context.Context, method string, metadata requ
 				// Region is not empty figure out a way to
 				// handle this appropriately.
 				if metadata.bucketName != "" {
-					// Gather Cached location only if bucketName is present.
-					if location, cachedOk := c.bucketLocCache.Get(metadata.bucketName); cachedOk && location != errResponse.Region {
+					// Gather Cached location only if bucketName is present,
+					// requests signed for an explicit region are not retried.
+					if location, cachedOk := c.bucketLocCache.Get(metadata.bucketName); cachedOk && metadata.bucketLocation == "" && location != errResponse.Region {
 						c.bucketLocCache.Set(metadata.bucketName, errResponse.Region)
 						continue // Retry.
 					}
@@ -801,6 +1214,52 @@ func (c *Client) executeMethod(ctx context.Context, method string, metadata requ
 	return res, err
 }
 
+// observeRequest reports a request to the metrics collector, if any.
+func (c *Client) observeRequest(op string, dur time.Duration, statusCode int, err error) {
+	if c.metricsCollector != nil {
+		c.metricsCollector.ObserveRequest(op, dur, statusCode, err)
+	}
+}
+
+// expectedBucketOwnerFor returns the expected bucket owner sent
+// with the request, if any.
+func (c *Client) expectedBucketOwnerFor(metadata requestMetadata) string {
+	if owner := metadata.customHeader.Get(amzExpectedBucketOnwer); owner != "" {
+		return owner
+	}
+	if metadata.bucketName == "" || metadata.noExpectedBucketOwner {
+		return ""
+	}
+	return c.expectedBucketOwner
+}
+
+// isBucketOwnerMismatch tells whether the AccessDenied error of a
+// request asserting the bucket owner is due to the assertion. S3
+// reports mismatches as plain AccessDenied errors, the bucket is
+// probed with and without asserting its owner to tell them apart: the
+// first probe must be denied and the second one allowed.
+func (c *Client) isBucketOwnerMismatch(ctx context.Context, metadata requestMetadata, owner string) bool {
+	probe := func(header http.Header) int {
+		resp, _ := c.executeMethod(ctx, http.MethodHead, requestMetadata{
+			bucketName:            metadata.bucketName,
+			bucketLocation:        metadata.bucketLocation,
+			contentSHA256Hex:      emptySHA256Hex,
+			customHeader:          header,
+			noExpectedBucketOwner: true,
+			bucketOwnerProbe:      true,
+		})
+		defer closeResponse(resp)
+		if resp == nil {
+			return 0
+		}
+		return resp.StatusCode
+	}
+	if probe(http.Header{amzExpectedBucketOnwer: []string{owner}}) != http.StatusForbidden {
+		return false
+	}
+	return probe(nil) == http.StatusOK
+}
+
 // newRequest - instantiate a new HTTP request for a given method.
 func (c *Client) newRequest(ctx context.Context, method string, metadata requestMetadata) (req *http.Request, err error) {
 	// If no method is supplied default to 'POST'.
@@ -828,12 +1287,25 @@ func (c *Client) newRequest(ctx context.Context, method string, metadata request
 	isMakeBucket := (metadata.objectName == "" && method == http.MethodPut && len(metadata.queryValues) == 0)
 	isVirtualHost := c.isVirtualHostStyleRequest(*c.endpointURL, metadata.bucketName) && !isMakeBucket
 
+	// Transfer acceleration does not support creating or deleting
+	// buckets, such requests are sent to the regular endpoint.
+	isRemoveBucket := (metadata.objectName == "" && method == http.MethodDelete && len(metadata.queryValues) == 0)
+	accelerate := c.s3AccelerateEndpoint != "" && !isMakeBucket && !isRemoveBucket
+	if accelerate && metadata.bucketName != "" {
+		// Accelerated endpoints only support virtual host style.
+		isVirtualHost = true
+	}
+
 	// Construct a new target URL.
-	targetURL, err := c.makeTargetURL(metadata.bucketName, metadata.objectName, location,
-		isVirtualHost, metadata.queryValues)
+	targetURL, err := c.targetURL(metadata.bucketName, metadata.objectName, location,
+		isVirtualHost, accelerate, metadata.queryValues)
 	if err != nil {
 		return nil, err
 	}
+	if metadata.adminPath != "" {
+		targetURL.Path = minioAdminPrefix + metadata.adminPath
+		targetURL.RawPath = ""
+	}
 
 	if c.httpTrace != nil {
 		ctx = httptrace.WithClientTrace(ctx, c.httpTrace)
@@ -842,7 +1314,7 @@ func (c *Client) newRequest(ctx context.Context, method string, metadata request
 	// make sure to de-dup calls to credential services, this reduces
 	// the overall load to the endpoint generating credential service.
 	value, err, _ := c.credsGroup.Do(metadata.bucketName, func() (credentials.Value, error) {
-		if s3utils.IsS3ExpressBucket(metadata.bucketName) && s3utils.IsAmazonEndpoint(*c.endpointURL) {
+		if c.isS3ExpressRequest(metadata.bucketName) {
 			return c.CreateSession(ctx, metadata.bucketName, SessionReadWrite)
 		}
 		// Get credentials from the configured credentials provider.
@@ -865,9 +1337,13 @@ func (c *Client) newRequest(ctx context.Context, method string, metadata request
 		sessionToken    = value.SessionToken
 	)
 
-	if s3utils.IsS3ExpressBucket(metadata.bucketName) && sessionToken != "" {
+	// Requests authenticated with CreateSession credentials are signed
+	// for the s3express service.
+	s3Express := c.isS3ExpressRequest(metadata.bucketName)
+	if s3Express && sessionToken != "" {
 		req.Header.Set("x-amz-s3session-token", sessionToken)
 	}
+	s3Express = s3Express || s3utils.IsAmazonExpressRegionalEndpoint(*c.endpointURL)
 
 	// Custom signer set then override the behavior.
 	if c.overrideSignerType != credentials.SignatureDefault {
@@ -896,7 +1372,18 @@ func (c *Client) newRequest(ctx context.Context, method string, metadata request
 		if signerType.IsV2() {
 			// Presign URL with signature v2.
 			req = signer.PreSignV2(*req, accessKeyID, secretAccessKey, metadata.expires, isVirtualHost)
+			if metadata.presignHost != "" {
+				// Host is not part of signature v2, the endpoint
+				// host is only needed to compute the signature.
+				req.URL.Host = metadata.presignHost
+			}
 		} else if signerType.IsV4() {
+			if metadata.presignHost != "" {
+				// Signature v4 signs the host header, sign for the
+				// requested host, e.g. a CDN fronting the endpoint.
+				req.URL.Host = metadata.presignHost
+				req.Host = metadata.presignHost
+			}
 			// Presign URL with signature v4.
 			req = signer.PreSignV4(*req, accessKeyID, secretAccessKey, sessionToken, location, metadata.expires)
 		}
@@ -905,6 +1392,14 @@ func (c *Client) newRequest(ctx context.Context, method string, metadata request
 
 	// Set 'User-Agent' header for the request.
 	c.setUserAgent(req)
+	if metadata.userAgent != "" {
+		req.Header.Set("User-Agent", req.Header.Get("User-Agent")+" "+metadata.userAgent)
+	}
+
+	// Assert the bucket owner, custom headers below may override it.
+	if c.expectedBucketOwner != "" && metadata.bucketName != "" && !metadata.noExpectedBucketOwner {
+		req.Header.Set(amzExpectedBucketOnwer, c.expectedBucketOwner)
+	}
 
 	// Set all headers.
 	for k, v := range metadata.customHeader {
@@ -933,6 +1428,12 @@ func (c *Client) newRequest(ctx context.Context, method string, metadata request
 		req.Header.Set("Content-Md5", metadata.contentMD5Base64)
 	}
 
+	// Run the request interceptors before signing, such that the
+	// headers they set are signed.
+	if err = c.interceptRequest(req); err != nil {
+		return nil, err
+	}
+
 	// For anonymous requests just return.
 	if signerType.IsAnonymous() {
 		if len(metadata.trailer) > 0 {
@@ -954,7 +1455,7 @@ func (c *Client) newRequest(ctx context.Context, method string, metadata request
 		// Streaming signature is used by default for a PUT object request.
 		// Additionally, we also look if the initialized client is secure,
 		// if yes then we don't need to perform streaming signature.
-		if s3utils.IsAmazonExpressRegionalEndpoint(*c.endpointURL) {
+		if s3Express {
 			req = signer.StreamingSignV4Express(req, accessKeyID,
 				secretAccessKey, sessionToken, location, metadata.contentLength, time.Now().UTC(), c.sha256Hasher())
 		} else {
@@ -975,7 +1476,7 @@ func (c *Client) newRequest(ctx context.Context, method string, metadata request
 		}
 		req.Header.Set("X-Amz-Content-Sha256", shaHeader)
 
-		if s3utils.IsAmazonExpressRegionalEndpoint(*c.endpointURL) {
+		if s3Express {
 			req = signer.SignV4TrailerExpress(*req, accessKeyID, secretAccessKey, sessionToken, location, metadata.trailer)
 		} else {
 			// Add signature version '4' authorization header.
@@ -987,6 +1488,12 @@ func (c *Client) newRequest(ctx context.Context, method string, metadata request
 	return req, nil
 }
 
+// isS3ExpressRequest returns true if requests to the bucket are sent
+// to an S3 Express One Zone directory bucket.
+func (c *Client) isS3ExpressRequest(bucketName string) bool {
+	return s3utils.IsS3ExpressBucket(bucketName) && (c.s3Express || s3utils.IsAmazonEndpoint(*c.endpointURL))
+}
+
 // set User agent.
 func (c *Client) setUserAgent(req *http.Request) {
 	req.Header.Set("User-Agent", libraryUserAgent)
@@ -997,13 +1504,19 @@ func (c *Client) setUserAgent(req *http.Request) {
 
 // makeTargetURL make a new target url.
 func (c *Client) makeTargetURL(bucketName, objectName, bucketLocation string, isVirtualHostStyle bool, queryValues url.Values) (*url.URL, error) {
+	return c.targetURL(bucketName, objectName, bucketLocation, isVirtualHostStyle, c.s3AccelerateEndpoint != "", queryValues)
+}
+
+// targetURL make a new target url, accelerate selects the transfer
+// acceleration endpoint if one is configured.
+func (c *Client) targetURL(bucketName, objectName, bucketLocation string, isVirtualHostStyle, accelerate bool, queryValues url.Values) (*url.URL, error) {
 	host := c.endpointURL.Host
 	// For Amazon S3 endpoint, try to fetch location based endpoint.
 	if s3utils.IsAmazonEndpoint(*c.endpointURL) {
-		if c.s3AccelerateEndpoint != "" && bucketName != "" {
+		if accelerate && c.s3AccelerateEndpoint != "" && bucketName != "" {
 			// http://docs.aws.amazon.com/AmazonS3/latest/dev/transfer-acceleration.html
 			// Disable transfer acceleration for non-compliant bucket names.
-			if strings.Contains(bucketName, ".") {
+			if strings.Contains(bucketName, ".") || s3utils.CheckValidBucketNameStrict(bucketName) != nil {
 				return nil, errTransferAccelerationBucket(bucketName)
 			}
 			// If transfer acceleration is requested set new host.
@@ -1099,11 +1612,45 @@ func (c *Client) isVirtualHostStyleRequest(url url.URL, bucketName string) bool
 		return false
 	}
 
+	// Legacy bucket names may not be valid host names.
+	if c.laxBucketNameValidation && s3utils.CheckValidBucketName(bucketName) != nil {
+		return false
+	}
+
 	// default to virtual only for Amazon/Google storage. In all other cases use
 	// path style requests
 	return s3utils.IsVirtualHostSupported(url, bucketName)
 }
 
+// checkBucketName checks the bucket name is valid, only checking it is
+// made of letters, digits, '.', '-' and '_' and is not "." or ".." with
+// Options.LaxBucketNameValidation.
+func (c *Client) checkBucketName(bucketName string) error {
+	if !c.laxBucketNameValidation {
+		return s3utils.CheckValidBucketName(bucketName)
+	}
+	if strings.TrimSpace(bucketName) == "" {
+		return errors.New("Bucket name cannot be empty")
+	}
+	if strings.Contains(bucketName, "/") {
+		return errors.New("Bucket name cannot contain slashes")
+	}
+	// They would be resolved as path segments.
+	if bucketName == "." || bucketName == ".." {
+		return errors.New("Bucket name cannot be '.' or '..'")
+	}
+	// Legacy names only have the characters below, others such as
+	// '?', '#' or '%' would change the request URL.
+	for _, r := range bucketName {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+		default:
+			return fmt.Errorf("Bucket name contains invalid character %q", r)
+		}
+	}
+	return nil
+}
+
 // CredContext returns the context for fetching credentials
 func (c *Client) CredContext() *credentials.CredContext {
 	httpClient := c.httpClient
@@ -21,11 +21,31 @@
 package openstor
 
 import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/openstor/openstor-go/v7/pkg/credentials"
 	"github.com/openstor/openstor-go/v7/pkg/policy"
+	"github.com/openstor/openstor-go/v7/pkg/signer"
+	"github.com/openstor/openstor-go/v7/pkg/sse"
 )
 
 // Tests valid hosts for location.
@@ -88,6 +108,42 @@ func TestSignatureType(t *testing.T) {
 	}
 }
 
+func TestSignatureV2Requests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS accessKey:") {
+			t.Errorf("expected a signature V2 authorization, got %q", auth)
+		}
+		// The server recomputes the signature from the received request.
+		req := r.Clone(context.Background())
+		req.Header.Del("Authorization")
+		if expected := signer.SignV2(*req, "accessKey", "secretKey", false).Header.Get("Authorization"); auth != expected {
+			t.Errorf("expected %s, got %s", expected, auth)
+		}
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.Header().Set("ETag", `"etag"`)
+	}))
+	defer srv.Close()
+
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:  credentials.NewStaticV2("accessKey", "secretKey", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if _, err = clnt.StatObject(ctx, "bucket", "object", StatObjectOptions{VersionID: "v1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = clnt.PutObject(ctx, "bucket", "object", strings.NewReader("data"), 4, PutObjectOptions{
+		ContentType:  "text/plain",
+		UserMetadata: map[string]string{"owner": "alice"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // Tests bucket policy types.
 func TestBucketPolicyTypes(t *testing.T) {
 	want := map[string]bool{
@@ -171,6 +227,55 @@ func TestPartSize(t *testing.T) {
 	if lastPartSize != 67108864 {
 		t.Fatalf("Error: expecting part size of 67108864: got %v instead", lastPartSize)
 	}
+
+	// Large objects are uploaded in about 1000 parts.
+	totalPartsCount, partSize, lastPartSize, err = OptimalPartInfo(1<<40, 0)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if totalPartsCount != 993 || partSize != 1107296256 || lastPartSize != 1073741824 {
+		t.Fatalf("Error: expecting 993 parts of 1107296256, last of 1073741824: got %v parts of %v, last of %v instead", totalPartsCount, partSize, lastPartSize)
+	}
+	totalPartsCount, partSize, lastPartSize, err = OptimalPartInfo(maxMultipartPutObjectSize, 0)
+	if err != nil {
+		t.Fatal("Error:", err)
+	}
+	if totalPartsCount != 1024 || partSize != maxPartSize || lastPartSize != maxPartSize {
+		t.Fatalf("Error: expecting 1024 parts of %v: got %v parts of %v, last of %v instead", maxPartSize, totalPartsCount, partSize, lastPartSize)
+	}
+}
+
+// Tests the part size of uploads of unknown size.
+func TestUnknownSizePartSize(t *testing.T) {
+	testCases := []struct {
+		partNumber         int
+		configuredPartSize uint64
+		expected           int64
+	}{
+		{1, 0, minPartSize},
+		{1000, 0, minPartSize},
+		{1001, 0, 2 * minPartSize},
+		{2001, 0, 4 * minPartSize},
+		{9000, 0, 256 * minPartSize},
+		{9001, 0, maxPartSize},
+		{maxPartsCount, 0, maxPartSize},
+		{1, absMinPartSize, absMinPartSize},
+		{maxPartsCount, absMinPartSize, absMinPartSize},
+	}
+	for i, testCase := range testCases {
+		if partSize := unknownSizePartSize(testCase.partNumber, testCase.configuredPartSize); partSize != testCase.expected {
+			t.Fatalf("Test %d: expected part size %d, got %d", i+1, testCase.expected, partSize)
+		}
+	}
+
+	// maxPartsCount parts hold the largest object.
+	var total int64
+	for partNumber := 1; partNumber <= maxPartsCount; partNumber++ {
+		total += unknownSizePartSize(partNumber, 0)
+	}
+	if total < maxMultipartPutObjectSize {
+		t.Fatalf("expected %d parts to hold %d bytes, got %d", maxPartsCount, int64(maxMultipartPutObjectSize), total)
+	}
 }
 
 // TestMakeTargetURL - testing makeTargetURL()
@@ -231,3 +336,902 @@ func TestMakeTargetURL(t *testing.T) {
 		}
 	}
 }
+
+func TestTransferAccelerationRequests(t *testing.T) {
+	c, err := New("s3.amazonaws.com", &Options{
+		Creds:                  credentials.NewStaticV4("foo", "bar", ""),
+		Secure:                 true,
+		Region:                 "us-east-1",
+		S3TransferAcceleration: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		method       string
+		metadata     requestMetadata
+		expectedHost string
+		success      bool
+	}{
+		{http.MethodGet, requestMetadata{bucketName: "mybucket", objectName: "myobject"}, "mybucket.s3-accelerate.amazonaws.com", true},
+		{http.MethodGet, requestMetadata{bucketName: "mybucket", queryValues: url.Values{"list-type": {"2"}}}, "mybucket.s3-accelerate.amazonaws.com", true},
+		{http.MethodGet, requestMetadata{}, "s3.dualstack.us-east-1.amazonaws.com", true},
+		{http.MethodPut, requestMetadata{bucketName: "mybucket"}, "s3.dualstack.us-east-1.amazonaws.com", true},
+		{http.MethodDelete, requestMetadata{bucketName: "mybucket"}, "mybucket.s3.dualstack.us-east-1.amazonaws.com", true},
+		{http.MethodGet, requestMetadata{bucketName: "my.bucket", objectName: "myobject"}, "", false},
+	}
+
+	for i, testCase := range testCases {
+		testCase.metadata.contentSHA256Hex = emptySHA256Hex
+		req, err := c.newRequest(context.Background(), testCase.method, testCase.metadata)
+		if err != nil && testCase.success {
+			t.Fatalf("Test %d: expected success, got %v", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Fatalf("Test %d: expected failure, got success", i+1)
+		}
+		if err == nil && req.URL.Host != testCase.expectedHost {
+			t.Fatalf("Test %d: expected host %s, got %s", i+1, testCase.expectedHost, req.URL.Host)
+		}
+	}
+}
+
+func TestExpectedBucketOwner(t *testing.T) {
+	// denied denies all requests, as for a missing permission,
+	// objectDenied all of them but the HEAD of the bucket.
+	var denied, objectDenied bool
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		owner := r.Header.Get(amzExpectedBucketOnwer)
+		bucketProbe := r.Method == http.MethodHead && r.URL.Path == "/bucket/"
+		if owner == "" && bucketProbe {
+			// Probe of the bucket without asserting its owner.
+			owner = "111122223333"
+		}
+		switch {
+		case denied || (objectDenied && !bucketProbe) || owner == "444455556666":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`))
+		case owner == "111122223333":
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected %s: %q", amzExpectedBucketOnwer, r.Header.Get(amzExpectedBucketOnwer))
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:               credentials.NewStaticV4("accessKey", "secretKey", ""),
+		Region:              "us-east-1",
+		ExpectedBucketOwner: "111122223333",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = clnt.StatObject(context.Background(), "bucket", "object", StatObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := StatObjectOptions{}
+	opts.Set(amzExpectedBucketOnwer, "444455556666")
+	_, err = clnt.StatObject(context.Background(), "bucket", "object", opts)
+	if !IsBucketOwnerMismatch(err) {
+		t.Fatalf("expected bucket owner mismatch, got %v", err)
+	}
+	if owner := ToErrorResponse(err).ExpectedBucketOwner; owner != "444455556666" {
+		t.Fatalf("expected owner 444455556666, got %s", owner)
+	}
+
+	// Other permission failures are not reported as mismatches.
+	denied = true
+	_, err = clnt.StatObject(context.Background(), "bucket", "object", opts)
+	if IsBucketOwnerMismatch(err) || ToErrorResponse(err).Code != AccessDenied {
+		t.Fatalf("expected a plain AccessDenied error, got %v", err)
+	}
+
+	// Nor are objects denied in a bucket whose owner is asserted right,
+	// only the probe asserting the owner being sent.
+	denied, objectDenied = false, true
+	requests = 0
+	_, err = clnt.StatObject(context.Background(), "bucket", "object", StatObjectOptions{})
+	if IsBucketOwnerMismatch(err) || ToErrorResponse(err).Code != AccessDenied {
+		t.Fatalf("expected a plain AccessDenied error, got %v", err)
+	}
+	if requests != 2 {
+		t.Fatalf("expected the object request and one probe, got %d requests", requests)
+	}
+}
+
+func TestRequestPayer(t *testing.T) {
+	mpSrv := &multipartServer{
+		parts:    make(map[string]map[int][]byte),
+		partPuts: make(map[int]int),
+	}
+	// Parts are uploaded concurrently, count the requests by method.
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if payer := r.Header.Get(amzRequestPayer); payer != requestPayerRequester {
+			t.Errorf("%s %s: unexpected %s: %q", r.Method, r.URL, amzRequestPayer, payer)
+		}
+		mu.Lock()
+		requests[r.Method]++
+		mu.Unlock()
+		w.Header().Set(amzRequestCharged, requestPayerRequester)
+		query := r.URL.Query()
+		switch {
+		case query.Has("uploads") || query.Has("uploadId"):
+			mpSrv.ServeHTTP(w, r)
+		case r.Method == http.MethodPut:
+			w.Header().Set("ETag", `"etag"`)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case query.Get("list-type") == "2":
+			io.WriteString(w, `<ListBucketResult><Name>bucket</Name><IsTruncated>false</IsTruncated><Contents><Key>object</Key><Size>1</Size></Contents></ListBucketResult>`)
+		default:
+			w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+			w.Header().Set("ETag", `"etag"`)
+			w.Header().Set("Content-Length", "1")
+			io.WriteString(w, "a")
+		}
+	}))
+	ctx := context.Background()
+
+	info, err := clnt.StatObject(ctx, "bucket", "object", StatObjectOptions{RequestPayer: true})
+	if err != nil || !info.RequestCharged {
+		t.Fatalf("expected the stat to be charged, got %v", err)
+	}
+	obj, err := clnt.GetObject(ctx, "bucket", "object", GetObjectOptions{RequestPayer: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = io.ReadAll(obj); err != nil {
+		t.Fatal(err)
+	}
+	if info, err = obj.Stat(); err != nil || !info.RequestCharged {
+		t.Fatalf("expected the download to be charged, got %v", err)
+	}
+	obj.Close()
+
+	upload, err := clnt.PutObject(ctx, "bucket", "object", strings.NewReader("a"), 1, PutObjectOptions{RequestPayer: true})
+	if err != nil || !upload.RequestCharged {
+		t.Fatalf("expected the upload to be charged, got %v", err)
+	}
+	data := strings.Repeat("a", 2*absMinPartSize)
+	upload, err = clnt.PutObject(ctx, "bucket", "object", strings.NewReader(data), int64(len(data)), PutObjectOptions{
+		RequestPayer: true,
+		PartSize:     absMinPartSize,
+	})
+	if err != nil || !upload.RequestCharged || mpSrv.uploads != 1 {
+		t.Fatalf("expected the multipart upload to be charged, got %v", err)
+	}
+
+	// The failed multipart upload is aborted as requester.
+	mpSrv.failPart = 2
+	_, err = clnt.PutObject(ctx, "bucket", "object", strings.NewReader(data), int64(len(data)), PutObjectOptions{
+		RequestPayer: true,
+		PartSize:     absMinPartSize,
+		// Upload the parts in order, the part 2 failing last.
+		NumThreads: 1,
+	})
+	if err == nil || len(mpSrv.aborted) != 1 {
+		t.Fatalf("expected the failed multipart upload to be aborted, got %v", err)
+	}
+
+	if err = clnt.RemoveObject(ctx, "bucket", "object", RemoveObjectOptions{RequestPayer: true}); err != nil {
+		t.Fatal(err)
+	}
+	for obj := range clnt.ListObjects(ctx, "bucket", ListObjectsOptions{RequestPayer: true}) {
+		if obj.Err != nil {
+			t.Fatal(obj.Err)
+		}
+	}
+
+	expected := map[string]int{
+		http.MethodHead: 1,
+		// Download and listing.
+		http.MethodGet: 2,
+		// Upload, then two multipart uploads of 2 parts, the part 2
+		// of the second failing.
+		http.MethodPut: 5,
+		// Two initiated and one completed multipart uploads.
+		http.MethodPost: 3,
+		// Removal and abort.
+		http.MethodDelete: 2,
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Fatalf("unexpected requests %v, expected %v", requests, expected)
+	}
+}
+
+func TestDisableContentMD5(t *testing.T) {
+	var encryptionPuts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if _, ok := r.URL.Query()["encryption"]; ok && len(body) > 0 {
+			encryptionPuts++
+		}
+		_, isDelete := r.URL.Query()["delete"]
+		if hasMD5 := r.Header.Get("Content-Md5") != ""; hasMD5 != isDelete {
+			t.Errorf("%s %s: expected Content-Md5 to be sent: %t", r.Method, r.URL, isDelete)
+		}
+		if got := r.Header.Get("X-Amz-Content-Sha256"); got != sum256Hex(body) {
+			t.Errorf("%s %s: expected payload to be signed, got %s", r.Method, r.URL, got)
+		}
+		if isDelete {
+			w.Write([]byte(`<DeleteResult></DeleteResult>`))
+		}
+	}))
+	defer srv.Close()
+
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:             credentials.NewStaticV4("accessKey", "secretKey", ""),
+		Region:            "us-east-1",
+		DisableContentMD5: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = clnt.SetBucketEncryption(context.Background(), "bucket", sse.NewConfigurationSSES3()); err != nil {
+		t.Fatal(err)
+	}
+	if encryptionPuts != 1 {
+		t.Fatalf("expected the encryption configuration to be sent once, got %d", encryptionPuts)
+	}
+
+	objectsCh := make(chan ObjectInfo, 1)
+	objectsCh <- ObjectInfo{Key: "object"}
+	close(objectsCh)
+	for res := range clnt.RemoveObjects(context.Background(), "bucket", objectsCh, RemoveObjectsOptions{}) {
+		t.Fatalf("unexpected error %v", res.Err)
+	}
+}
+
+func TestLaxBucketNameValidation(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	for _, lax := range []bool{false, true} {
+		clnt, err := New(srv.Listener.Addr().String(), &Options{
+			Creds:                   credentials.NewStaticV4("accessKey", "secretKey", ""),
+			Region:                  "us-east-1",
+			LaxBucketNameValidation: lax,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths = nil
+		_, err = clnt.StatObject(ctx, "Legacy..Bucket", "object", StatObjectOptions{})
+		if lax && (err != nil || len(paths) != 1 || paths[0] != "/Legacy..Bucket/object") {
+			t.Fatalf("expected the legacy bucket to be accessed, got %v requesting %v", err, paths)
+		}
+		if !lax && (err == nil || len(paths) != 0) {
+			t.Fatalf("expected the legacy bucket name to be rejected, got %v requesting %v", err, paths)
+		}
+		// Names changing the request URL are rejected without a request.
+		paths = nil
+		for _, bucketName := range []string{"", " ", "bucket/prefix", ".", "..", "a?b", "a#b", "a%2Fb"} {
+			if _, err = clnt.StatObject(ctx, bucketName, "object", StatObjectOptions{}); err == nil {
+				t.Fatalf("expected bucket name %q to be rejected", bucketName)
+			}
+		}
+		if len(paths) != 0 {
+			t.Fatalf("expected no request, got %v", paths)
+		}
+		if err = clnt.MakeBucket(ctx, "Legacy..Bucket", MakeBucketOptions{}); err == nil {
+			t.Fatal("expected new bucket names to be checked strictly")
+		}
+	}
+
+	// Legacy bucket names are not used as host names.
+	clnt, err := New("s3.amazonaws.com", &Options{
+		Creds:                   credentials.NewStaticV4("accessKey", "secretKey", ""),
+		LaxBucketNameValidation: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !clnt.isVirtualHostStyleRequest(*clnt.endpointURL, "bucket") || clnt.isVirtualHostStyleRequest(*clnt.endpointURL, "Legacy..Bucket") {
+		t.Fatal("expected only the legacy bucket to be requested in path style")
+	}
+}
+
+func TestPerRequestRegion(t *testing.T) {
+	var regions []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("location") {
+			regions = append(regions, "location")
+			io.WriteString(w, `<LocationConstraint>us-west-1</LocationConstraint>`)
+			return
+		}
+		// Credential=accessKey/<date>/<region>/s3/aws4_request
+		_, credential, _ := strings.Cut(r.Header.Get("Authorization"), "Credential=")
+		scope := strings.Split(credential, "/")
+		if len(scope) > 2 {
+			regions = append(regions, scope[2])
+		}
+		io.Copy(io.Discard, r.Body)
+		switch r.Method {
+		case http.MethodGet:
+			io.WriteString(w, `<ListBucketResult><Name>bucket</Name><IsTruncated>false</IsTruncated></ListBucketResult>`)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodHead:
+			w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+			w.Header().Set("Content-Length", "0")
+		}
+	}))
+	defer srv.Close()
+
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds: credentials.NewStaticV4("accessKey", "secretKey", ""),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	const region = "eu-central-1"
+	if _, err = clnt.StatObject(ctx, "bucket", "object", StatObjectOptions{Region: region}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = clnt.PutObject(ctx, "bucket", "object", strings.NewReader("data"), 4, PutObjectOptions{Region: region}); err != nil {
+		t.Fatal(err)
+	}
+	if err = clnt.RemoveObject(ctx, "bucket", "object", RemoveObjectOptions{Region: region}); err != nil {
+		t.Fatal(err)
+	}
+	for obj := range clnt.ListObjects(ctx, "bucket", ListObjectsOptions{Region: region}) {
+		if obj.Err != nil {
+			t.Fatal(obj.Err)
+		}
+	}
+	if got := strings.Join(regions, ","); got != "eu-central-1,eu-central-1,eu-central-1,eu-central-1" {
+		t.Fatalf("expected every request to be signed for %s without a location lookup, got %s", region, got)
+	}
+
+	// Without an override the bucket location is looked up.
+	regions = nil
+	if _, err = clnt.StatObject(ctx, "bucket", "object", StatObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(regions, ","); got != "location,us-west-1" {
+		t.Fatalf("expected the bucket location to be used, got %s", got)
+	}
+}
+
+func TestRequestResponseTrace(t *testing.T) {
+	const content = "object content"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			t.Errorf("%s %s: expected the request to be signed", r.Method, r.URL)
+		}
+		if r.Method == http.MethodPut {
+			if body, _ := io.ReadAll(r.Body); !strings.Contains(string(body), content) {
+				t.Errorf("expected the request body to be sent, got %q", body)
+			}
+			w.Header().Set("ETag", `"etag"`)
+			return
+		}
+		w.Header().Set("Last-Modified", "Wed, 01 Jan 2025 00:00:00 GMT")
+		w.Header().Set("ETag", `"etag"`)
+		io.WriteString(w, content)
+	}))
+	defer srv.Close()
+
+	var requests, responses []string
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:  credentials.NewStaticV4("accessKey", "secretKey", ""),
+		Region: "us-east-1",
+		RequestTrace: func(req *http.Request) {
+			if req.Body != http.NoBody {
+				t.Error("expected the traced request to have no body")
+			}
+			// Redacting the copy must not affect the request.
+			req.Header.Set("Authorization", "REDACTED")
+			requests = append(requests, req.Method)
+		},
+		ResponseTrace: func(resp *http.Response) {
+			if resp.Body != http.NoBody {
+				t.Error("expected the traced response to have no body")
+			}
+			responses = append(responses, resp.Status)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if _, err = clnt.PutObject(ctx, "bucket", "object", strings.NewReader(content), int64(len(content)), PutObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	obj, err := clnt.GetObject(ctx, "bucket", "object", GetObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Close()
+	if data, err := io.ReadAll(obj); err != nil || string(data) != content {
+		t.Fatalf("unexpected object %q, %v", data, err)
+	}
+
+	if strings.Join(requests, ",") != "PUT,GET" || strings.Join(responses, ",") != "200 OK,200 OK" {
+		t.Fatalf("unexpected traces %v, %v", requests, responses)
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	var received int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+		if id := r.Header.Get("X-Request-Id"); id != "id-1-2" {
+			t.Errorf("expected X-Request-Id id-1-2, got %q", id)
+		}
+		if !strings.Contains(r.Header.Get("Authorization"), "x-request-id") {
+			t.Errorf("expected X-Request-Id to be signed, got %s", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("ETag", `"etag"`)
+	}))
+	defer srv.Close()
+
+	setRequestID := []func(*http.Request) error{
+		func(req *http.Request) error {
+			req.Header.Set("X-Request-Id", "id-1")
+			return nil
+		},
+		func(req *http.Request) error {
+			req.Header.Set("X-Request-Id", req.Header.Get("X-Request-Id")+"-2")
+			return nil
+		},
+	}
+	errIntercept := errors.New("intercepted")
+
+	testCases := []struct {
+		requestInterceptors  []func(*http.Request) error
+		responseInterceptors []func(*http.Response) error
+		err                  error
+		code                 string
+		received             int
+	}{
+		{setRequestID, []func(*http.Response) error{func(*http.Response) error { return nil }}, nil, "", 1},
+		// Interceptors must not break the signature.
+		{append(slices.Clone(setRequestID), func(req *http.Request) error {
+			req.Header.Set("Authorization", "AWS4-HMAC-SHA256 forged")
+			return nil
+		}), nil, nil, InvalidArgument, 0},
+		{append(slices.Clone(setRequestID), func(req *http.Request) error {
+			req.Header.Set("Content-Md5", "forged")
+			return nil
+		}), nil, nil, InvalidArgument, 0},
+		{append(slices.Clone(setRequestID), func(req *http.Request) error {
+			req.URL.Path += "-other"
+			return nil
+		}), nil, nil, InvalidArgument, 0},
+		// Errors fail the request without retries.
+		{append(slices.Clone(setRequestID), func(*http.Request) error { return errIntercept }), nil, errIntercept, "", 0},
+		{setRequestID, []func(*http.Response) error{func(resp *http.Response) error { return errIntercept }}, errIntercept, "", 1},
+	}
+
+	for i, testCase := range testCases {
+		var responses []int
+		responseInterceptors := append(testCase.responseInterceptors, func(resp *http.Response) error {
+			responses = append(responses, resp.StatusCode)
+			return nil
+		})
+		clnt, err := New(srv.Listener.Addr().String(), &Options{
+			Creds:                credentials.NewStaticV4("accessKey", "secretKey", ""),
+			Region:               "us-east-1",
+			RequestInterceptors:  testCase.requestInterceptors,
+			ResponseInterceptors: responseInterceptors,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		received = 0
+		_, err = clnt.PutObject(context.Background(), "bucket", "object", strings.NewReader("data"), 4, PutObjectOptions{SendContentMd5: true})
+		switch {
+		case testCase.err != nil:
+			if !errors.Is(err, testCase.err) {
+				t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.err, err)
+			}
+		case testCase.code != "":
+			if ToErrorResponse(err).Code != testCase.code {
+				t.Fatalf("Test %d: expected %s, got %v", i+1, testCase.code, err)
+			}
+		case err != nil:
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		default:
+			if len(responses) != 1 || responses[0] != http.StatusOK {
+				t.Fatalf("Test %d: expected the response to be intercepted, got %v", i+1, responses)
+			}
+		}
+		if received != testCase.received {
+			t.Fatalf("Test %d: expected %d requests to be received, got %d", i+1, testCase.received, received)
+		}
+	}
+}
+
+func TestDefaultOperationTimeout(t *testing.T) {
+	const delay = 200 * time.Millisecond
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && !r.URL.Query().Has("tagging") {
+			// Stream the object slower than the timeout.
+			w.Header().Set("Content-Length", "6")
+			w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+			io.WriteString(w, "abc")
+			w.(http.Flusher).Flush()
+			time.Sleep(delay)
+			io.WriteString(w, "def")
+			return
+		}
+		time.Sleep(delay)
+		io.WriteString(w, `<Tagging><TagSet></TagSet></Tagging>`)
+	}))
+	defer srv.Close()
+
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:                   credentials.NewStaticV4("accessKey", "secretKey", ""),
+		Region:                  "us-east-1",
+		MaxRetries:              1,
+		DefaultOperationTimeout: delay / 4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = clnt.GetObjectTagging(context.Background(), "bucket", "object", GetObjectTaggingOptions{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the request to time out, got %v", err)
+	}
+
+	// A deadline set by the caller takes precedence.
+	ctx, cancel := context.WithTimeout(context.Background(), 4*delay)
+	defer cancel()
+	if _, err = clnt.GetObjectTagging(ctx, "bucket", "object", GetObjectTaggingOptions{}); err != nil {
+		t.Fatalf("expected the caller deadline to be used, got %v", err)
+	}
+
+	// Object bodies are read beyond the timeout.
+	obj, err := clnt.GetObject(context.Background(), "bucket", "object", GetObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Close()
+	data, err := io.ReadAll(obj)
+	if err != nil || string(data) != "abcdef" {
+		t.Fatalf("expected the object to be read, got %q, %v", data, err)
+	}
+}
+
+func TestCustomDialer(t *testing.T) {
+	var hosts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hosts = append(hosts, r.Host)
+	}))
+	defer srv.Close()
+
+	var dialed []string
+	dialer := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = append(dialed, addr)
+		// Pin the endpoint to the test server.
+		return (&net.Dialer{}).DialContext(ctx, network, srv.Listener.Addr().String())
+	}
+
+	testCases := []struct {
+		transport http.RoundTripper
+		success   bool
+	}{
+		{nil, true},
+		{&http.Transport{DisableKeepAlives: true}, true},
+		{&InterceptRouteTripper{}, false},
+	}
+
+	for i, testCase := range testCases {
+		hosts, dialed = nil, nil
+		clnt, err := New("s3.example.invalid:9000", &Options{
+			Creds:        credentials.NewStaticV4("accessKey", "secretKey", ""),
+			Region:       "us-east-1",
+			Transport:    testCase.transport,
+			CustomDialer: dialer,
+		})
+		if !testCase.success {
+			if ToErrorResponse(err).Code != InvalidArgument {
+				t.Fatalf("Test %d: expected the transport to be rejected, got %v", i+1, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if _, err = clnt.BucketExists(context.Background(), "bucket"); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if len(dialed) != 1 || dialed[0] != "s3.example.invalid:9000" || len(hosts) != 1 || hosts[0] != "s3.example.invalid:9000" {
+			t.Fatalf("Test %d: unexpected connections to %v for hosts %v", i+1, dialed, hosts)
+		}
+	}
+	if tr := testCases[1].transport.(*http.Transport); tr.DialContext != nil {
+		t.Fatal("expected the custom transport not to be modified")
+	}
+}
+
+func TestCertificatePins(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	// Rejected handshakes are expected.
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	defer srv.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(srv.Certificate())
+	pin := sha256.Sum256(srv.Certificate().RawSubjectPublicKeyInfo)
+	otherPin := sha256.Sum256([]byte("other"))
+
+	testCases := []struct {
+		transport http.RoundTripper
+		rootCAs   *x509.CertPool
+		pins      [][]byte
+		success   bool
+	}{
+		{nil, nil, nil, false},
+		{nil, rootCAs, nil, true},
+		{nil, rootCAs, [][]byte{otherPin[:], pin[:]}, true},
+		{nil, rootCAs, [][]byte{otherPin[:]}, false},
+		{&http.Transport{DisableKeepAlives: true}, rootCAs, [][]byte{pin[:]}, true},
+	}
+
+	for i, testCase := range testCases {
+		clnt, err := New(srv.Listener.Addr().String(), &Options{
+			Creds:           credentials.NewStaticV4("accessKey", "secretKey", ""),
+			Region:          "us-east-1",
+			Secure:          true,
+			Transport:       testCase.transport,
+			RootCAs:         testCase.rootCAs,
+			CertificatePins: testCase.pins,
+			MaxRetries:      1,
+		})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		_, err = clnt.BucketExists(context.Background(), "bucket")
+		if err != nil && testCase.success {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Fatalf("Test %d: expected the connection to be rejected", i+1)
+		}
+	}
+	if tr := testCases[4].transport.(*http.Transport); tr.TLSClientConfig != nil && (tr.TLSClientConfig.RootCAs != nil || tr.TLSClientConfig.VerifyConnection != nil) {
+		t.Fatal("expected the custom transport not to be modified")
+	}
+
+	// Pins are only accepted as SHA256 sums over TLS.
+	for i, opts := range []*Options{
+		{CertificatePins: [][]byte{pin[:]}},
+		{CertificatePins: [][]byte{pin[:16]}, Secure: true},
+		{CertificatePins: [][]byte{pin[:]}, Secure: true, Transport: &InterceptRouteTripper{}},
+	} {
+		if _, err := New(srv.Listener.Addr().String(), opts); ToErrorResponse(err).Code != InvalidArgument {
+			t.Fatalf("Test %d: expected the options to be rejected, got %v", i+1, err)
+		}
+	}
+}
+
+func TestRequestUserAgent(t *testing.T) {
+	var userAgents []string
+	clnt, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+		switch {
+		case r.URL.Query().Has("list-type"):
+			io.WriteString(w, `<ListBucketResult><Name>bucket</Name><IsTruncated>false</IsTruncated></ListBucketResult>`)
+		case r.Method == http.MethodGet || r.Method == http.MethodHead:
+			w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+			w.Header().Set("Content-Length", "0")
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			io.Copy(io.Discard, r.Body)
+		}
+	}))
+	clnt.SetAppInfo("app", "1.0")
+	base := libraryUserAgent + " app/1.0"
+
+	ctx := context.Background()
+	const token = "tenant-a/2.1"
+	if _, err := clnt.PutObject(ctx, "bucket", "object", strings.NewReader("data"), 4, PutObjectOptions{UserAgent: token}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := clnt.StatObject(ctx, "bucket", "object", StatObjectOptions{UserAgent: token}); err != nil {
+		t.Fatal(err)
+	}
+	obj, err := clnt.GetObject(ctx, "bucket", "object", GetObjectOptions{UserAgent: token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.ReadAll(obj)
+	obj.Close()
+	for obj := range clnt.ListObjects(ctx, "bucket", ListObjectsOptions{UserAgent: token}) {
+		if obj.Err != nil {
+			t.Fatal(obj.Err)
+		}
+	}
+	if err = clnt.RemoveObject(ctx, "bucket", "object", RemoveObjectOptions{UserAgent: token}); err != nil {
+		t.Fatal(err)
+	}
+	if len(userAgents) < 5 {
+		t.Fatalf("expected at least 5 requests, got %d", len(userAgents))
+	}
+	for i, userAgent := range userAgents {
+		if userAgent != base+" "+token {
+			t.Fatalf("request %d: unexpected User-Agent %q", i+1, userAgent)
+		}
+	}
+
+	// The token does not leak into other requests.
+	userAgents = nil
+	if _, err = clnt.StatObject(ctx, "bucket", "object", StatObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(userAgents) != 1 || userAgents[0] != base {
+		t.Fatalf("unexpected User-Agent %v", userAgents)
+	}
+
+	// Tokens injecting headers are rejected before sending any request.
+	userAgents = nil
+	for _, token := range []string{"app\r\nX-Injected: 1", "app\n"} {
+		_, err = clnt.StatObject(ctx, "bucket", "object", StatObjectOptions{UserAgent: token})
+		if ToErrorResponse(err).Code != InvalidArgument {
+			t.Fatalf("expected token %q to be rejected, got %v", token, err)
+		}
+	}
+	if len(userAgents) != 0 {
+		t.Fatalf("unexpected requests %v", userAgents)
+	}
+}
+
+func TestRegionRedirect(t *testing.T) {
+	var regions []string
+	bucketRegion := "eu-central-1"
+	flipRegion := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("location") {
+			regions = append(regions, "location")
+			io.WriteString(w, `<LocationConstraint>us-west-1</LocationConstraint>`)
+			return
+		}
+		// Credential=accessKey/<date>/<region>/s3/aws4_request
+		_, credential, _ := strings.Cut(r.Header.Get("Authorization"), "Credential=")
+		scope := strings.Split(credential, "/")
+		if len(scope) > 2 {
+			regions = append(regions, scope[2])
+		}
+		if flipRegion {
+			if scope[2] == "us-east-1" {
+				bucketRegion = "eu-central-1"
+			} else {
+				bucketRegion = "us-east-1"
+			}
+		}
+		if len(scope) <= 2 || scope[2] != bucketRegion {
+			// HEAD responses carry the region in the headers only.
+			w.Header().Set("x-amz-bucket-region", bucketRegion)
+			w.WriteHeader(http.StatusMovedPermanently)
+			return
+		}
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Length", "0")
+	}))
+	defer srv.Close()
+
+	newClient := func(disableRedirect bool) *Client {
+		clnt, err := New(srv.Listener.Addr().String(), &Options{
+			Creds:                 credentials.NewStaticV4("accessKey", "secretKey", ""),
+			DisableRegionRedirect: disableRedirect,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return clnt
+	}
+
+	ctx := context.Background()
+	clnt := newClient(false)
+	if _, err := clnt.StatObject(ctx, "bucket", "object", StatObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(regions, ","); got != "location,us-west-1,eu-central-1" {
+		t.Fatalf("expected the request to be redirected to the bucket region, got %s", got)
+	}
+
+	// The bucket region is cached for the following requests.
+	regions = nil
+	if _, err := clnt.StatObject(ctx, "bucket", "object", StatObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(regions, ","); got != "eu-central-1" {
+		t.Fatalf("expected the cached bucket region to be used, got %s", got)
+	}
+
+	// A request is redirected at most once, even when the server
+	// keeps redirecting it.
+	regions = nil
+	flipRegion = true
+	_, err := clnt.StatObject(ctx, "bucket", "object", StatObjectOptions{})
+	if ToErrorResponse(err).Code != PermanentRedirect {
+		t.Fatalf("expected PermanentRedirect error, got %v", err)
+	}
+	if got := strings.Join(regions, ","); got != "eu-central-1,us-east-1" {
+		t.Fatalf("expected a single redirect, got %s", got)
+	}
+	flipRegion = false
+
+	// Redirects can be disabled.
+	regions = nil
+	_, err = newClient(true).StatObject(ctx, "bucket", "object", StatObjectOptions{})
+	if ToErrorResponse(err).Code != PermanentRedirect {
+		t.Fatalf("expected PermanentRedirect error, got %v", err)
+	}
+	if got := strings.Join(regions, ","); got != "location,us-west-1" {
+		t.Fatalf("expected the request not to be redirected, got %s", got)
+	}
+}
+
+func TestComputeMultipartETag(t *testing.T) {
+	if etag := ComputeMultipartETag(nil); etag != "" {
+		t.Fatalf("expected no ETag without parts, got %s", etag)
+	}
+
+	dir := t.TempDir()
+	testCases := []struct {
+		data     string
+		partSize int64
+		expected string
+	}{
+		{strings.Repeat("a", 12), 5, "2615cdbe922634659654581b1895a338-3"},
+		{strings.Repeat("a", 12), 12, ""},
+		{strings.Repeat("a", 10), 5, ""},
+		{"", 5, "59adb24ef3cdbe0297f05b395827453f-1"},
+	}
+	for i, testCase := range testCases {
+		filePath := filepath.Join(dir, fmt.Sprintf("file-%d", i))
+		if err := os.WriteFile(filePath, []byte(testCase.data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		// Compute the expected ETag from the part MD5 sums.
+		expected := testCase.expected
+		if expected == "" {
+			var partMD5s [][]byte
+			for data := testCase.data; data != ""; {
+				n := min(int64(len(data)), testCase.partSize)
+				sum := md5.Sum([]byte(data[:n]))
+				partMD5s = append(partMD5s, sum[:])
+				data = data[n:]
+			}
+			expected = ComputeMultipartETag(partMD5s)
+		}
+
+		etag, err := ComputeMultipartETagFromFile(filePath, testCase.partSize)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if etag != expected {
+			t.Fatalf("Test %d: expected ETag %s, got %s", i+1, expected, etag)
+		}
+	}
+
+	if _, err := ComputeMultipartETagFromFile(filepath.Join(dir, "file-1"), 0); ToErrorResponse(err).Code != InvalidArgument {
+		t.Fatalf("expected a zero part size to be rejected, got %v", err)
+	}
+}
@@ -28,14 +28,13 @@ import (
 	"path"
 
 	"github.com/openstor/openstor-go/v7/pkg/credentials"
-	"github.com/openstor/openstor-go/v7/pkg/s3utils"
 	"github.com/openstor/openstor-go/v7/pkg/signer"
 )
 
 // GetBucketLocation - get location for the bucket name from location cache, if not
 // fetch freshly by making a new request.
 func (c *Client) GetBucketLocation(ctx context.Context, bucketName string) (string, error) {
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return "", err
 	}
 	return c.getBucketLocation(ctx, bucketName)
@@ -44,7 +43,7 @@ func (c *Client) GetBucketLocation(ctx context.Context, bucketName string) (stri
 // getBucketLocation - Get location for the bucketName from location map cache, if not
 // fetch freshly by making a new request.
 func (c *Client) getBucketLocation(ctx context.Context, bucketName string) (string, error) {
-	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+	if err := c.checkBucketName(bucketName); err != nil {
 		return "", err
 	}
 
@@ -57,6 +56,32 @@ func (c *Client) getBucketLocation(ctx context.Context, bucketName string) (stri
 		return location, nil
 	}
 
+	location, err := c.fetchBucketLocation(ctx, bucketName)
+	if err != nil {
+		return "", err
+	}
+	c.bucketLocCache.Set(bucketName, location)
+	return location, nil
+}
+
+// GetBucketLocationNoCache - get location for the bucket name freshly from
+// the server, regardless of the configured region. The location cache is
+// neither read nor updated.
+func (c *Client) GetBucketLocationNoCache(ctx context.Context, bucketName string) (string, error) {
+	if err := c.checkBucketName(bucketName); err != nil {
+		return "", err
+	}
+	return c.fetchBucketLocation(ctx, bucketName)
+}
+
+// PurgeBucketLocationCache - removes all the bucket locations from the
+// location cache, to have them fetched freshly on their next use.
+func (c *Client) PurgeBucketLocationCache() {
+	c.bucketLocCache.Clear()
+}
+
+// fetchBucketLocation - requests the location of the bucket from the server.
+func (c *Client) fetchBucketLocation(ctx context.Context, bucketName string) (string, error) {
 	// Initialize a new request.
 	req, err := c.getBucketLocationRequest(ctx, bucketName)
 	if err != nil {
@@ -69,12 +94,7 @@ func (c *Client) getBucketLocation(ctx context.Context, bucketName string) (stri
 	if err != nil {
 		return "", err
 	}
-	location, err := processBucketLocationResponse(resp, bucketName)
-	if err != nil {
-		return "", err
-	}
-	c.bucketLocCache.Set(bucketName, location)
-	return location, nil
+	return processBucketLocationResponse(resp, bucketName)
 }
 
 // processes the getBucketLocation http response from the server.
@@ -172,6 +192,11 @@ func (c *Client) getBucketLocationRequest(ctx context.Context, bucketName string
 	// Set UserAgent for the request.
 	c.setUserAgent(req)
 
+	// Run the request interceptors before signing.
+	if err = c.interceptRequest(req); err != nil {
+		return nil, err
+	}
+
 	// Get credentials from the configured credentials provider.
 	value, err := c.credsProvider.GetWithContext(c.CredContext())
 	if err != nil {
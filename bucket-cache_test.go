@@ -26,6 +26,7 @@ import (
 	"encoding/xml"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"path"
 	"reflect"
@@ -54,6 +55,12 @@ func TestBucketLocationCacheOps(t *testing.T) {
 	if ok {
 		t.Errorf("Bucket location cache not deleted as expected")
 	}
+	testBucketLocationCache.Set(expectedBucketName, expectedLocation)
+	testBucketLocationCache.Clear()
+	_, ok = testBucketLocationCache.Get(expectedBucketName)
+	if ok {
+		t.Errorf("Bucket location cache not cleared as expected")
+	}
 }
 
 // Tests validate http request generation for 'getBucketLocation'.
@@ -350,3 +357,57 @@ func TestProcessBucketLocationResponse(t *testing.T) {
 		}
 	}
 }
+
+// Tests the bucket location lookups bypassing and purging the cache.
+func TestGetBucketLocationNoCache(t *testing.T) {
+	location := "us-west-1"
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !r.URL.Query().Has("location") {
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		requests++
+		io.WriteString(w, `<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">`+location+`</LocationConstraint>`)
+	}))
+	defer srv.Close()
+
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds: credentials.NewStaticV4("accessKey", "secretKey", ""),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if got, err := clnt.GetBucketLocation(ctx, "bucket"); err != nil || got != "us-west-1" {
+		t.Fatalf("unexpected location %q, %v", got, err)
+	}
+
+	// The bucket moved, the cache holds the stale location.
+	location = "eu-central-1"
+	if got, err := clnt.GetBucketLocation(ctx, "bucket"); err != nil || got != "us-west-1" {
+		t.Fatalf("expected the cached location, got %q, %v", got, err)
+	}
+	if got, err := clnt.GetBucketLocationNoCache(ctx, "bucket"); err != nil || got != "eu-central-1" {
+		t.Fatalf("expected the fresh location, got %q, %v", got, err)
+	}
+	if got, ok := clnt.bucketLocCache.Get("bucket"); !ok || got != "us-west-1" {
+		t.Fatalf("expected the cache not to be updated, got %q", got)
+	}
+
+	clnt.PurgeBucketLocationCache()
+	if _, ok := clnt.bucketLocCache.Get("bucket"); ok {
+		t.Fatal("expected the cache to be purged")
+	}
+	if got, err := clnt.GetBucketLocation(ctx, "bucket"); err != nil || got != "eu-central-1" {
+		t.Fatalf("expected the fresh location, got %q, %v", got, err)
+	}
+	if requests != 3 {
+		t.Fatalf("expected 3 location requests, got %d", requests)
+	}
+
+	if _, err = clnt.GetBucketLocationNoCache(ctx, ""); err == nil {
+		t.Fatalf("expected the bucket name to be rejected, got %v", err)
+	}
+}
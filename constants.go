@@ -33,6 +33,14 @@ const minPartSize = 1024 * 1024 * 16
 // maxPartsCount - maximum number of parts for a single multipart session.
 const maxPartsCount = 10000
 
+// targetPartsCount - number of parts a multipart upload of known size
+// aims for, once its parts are larger than minPartSize.
+const targetPartsCount = 1000
+
+// partSizeGrowthInterval - number of parts after which the part size of
+// an upload of unknown size doubles.
+const partSizeGrowthInterval = 1000
+
 // maxPartSize - maximum part size 5GiB for a single multipart upload
 // operation.
 const maxPartSize = 1024 * 1024 * 1024 * 5
@@ -56,6 +64,9 @@ const unsignedPayloadTrailer = "STREAMING-UNSIGNED-PAYLOAD-TRAILER"
 // Total number of parallel workers used for multipart operation.
 const totalWorkers = 4
 
+// minioAdminPrefix is the path prefix of the MinIO admin API.
+const minioAdminPrefix = "/minio/admin/v3"
+
 // Signature related constants.
 const (
 	signV4Algorithm   = "AWS4-HMAC-SHA256"
@@ -99,6 +110,12 @@ const (
 	amzRestore           = "X-Amz-Restore"
 	amzReplicationStatus = "X-Amz-Replication-Status"
 	amzDeleteMarker      = "X-Amz-Delete-Marker"
+	amzPartsCount        = "X-Amz-Mp-Parts-Count"
+
+	// Requester Pays headers
+	amzRequestPayer       = "X-Amz-Request-Payer"
+	amzRequestCharged     = "X-Amz-Request-Charged"
+	requestPayerRequester = "requester"
 
 	// Object legal hold header
 	amzLegalHoldHeader = "X-Amz-Object-Lock-Legal-Hold"
@@ -108,6 +125,9 @@ const (
 	amzLockRetainUntil  = "X-Amz-Object-Lock-Retain-Until-Date"
 	amzBypassGovernance = "X-Amz-Bypass-Governance-Retention"
 
+	// Append object header
+	amzWriteOffsetBytes = "X-Amz-Write-Offset-Bytes"
+
 	// Replication status
 	amzBucketReplicationStatus = "X-Amz-Replication-Status"
 	// Minio specific Replication/lifecycle transition extension
@@ -49,13 +49,13 @@ func NewCore(endpoint string, opts *Options) (*Core, error) {
 // ListObjects - List all the objects at a prefix, optionally with marker and delimiter
 // you can further filter the results.
 func (c Core) ListObjects(bucket, prefix, marker, delimiter string, maxKeys int) (result ListBucketResult, err error) {
-	return c.listObjectsQuery(context.Background(), bucket, prefix, marker, delimiter, maxKeys, nil)
+	return c.listObjectsQuery(context.Background(), bucket, prefix, marker, delimiter, maxKeys, nil, "", "")
 }
 
 // ListObjectsV2 - Lists all the objects at a prefix, similar to ListObjects() but uses
 // continuationToken instead of marker to support iteration over the results.
 func (c Core) ListObjectsV2(bucketName, objectPrefix, startAfter, continuationToken, delimiter string, maxkeys int) (ListBucketV2Result, error) {
-	return c.listObjectsV2Query(context.Background(), bucketName, objectPrefix, continuationToken, true, false, delimiter, startAfter, maxkeys, nil)
+	return c.listObjectsV2Query(context.Background(), bucketName, objectPrefix, continuationToken, true, false, delimiter, startAfter, maxkeys, nil, "", "")
 }
 
 // CopyObject - copies an object from source object to destination object on server side.
@@ -133,7 +133,7 @@ func (c Core) CompleteMultipartUpload(ctx context.Context, bucket, object, uploa
 
 // AbortMultipartUpload - Abort an incomplete upload.
 func (c Core) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string) error {
-	return c.abortMultipartUpload(ctx, bucket, object, uploadID)
+	return c.abortMultipartUpload(ctx, bucket, object, uploadID, PutObjectOptions{})
 }
 
 // GetBucketPolicy - fetches bucket access policy for a given bucket.
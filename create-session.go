@@ -91,14 +91,14 @@ func (c *Client) CreateSession(ctx context.Context, bucketName string, sessionMo
 		return credentials.Value{}, err
 	}
 
-	defer c.bucketSessionCache.Set(bucketName, cred)
-
-	return credentials.Value{
+	cred = credentials.Value{
 		AccessKeyID:     credSession.Credentials.AccessKey,
 		SecretAccessKey: credSession.Credentials.SecretKey,
 		SessionToken:    credSession.Credentials.SessionToken,
 		Expiration:      credSession.Credentials.Expiration,
-	}, nil
+	}
+	c.bucketSessionCache.Set(bucketName, cred)
+	return cred, nil
 }
 
 // createSessionRequest - Wrapper creates a new CreateSession request.
@@ -110,8 +110,12 @@ func (c *Client) createSessionRequest(ctx context.Context, bucketName string, se
 	// Set get bucket location always as path style.
 	targetURL := *c.endpointURL
 
-	// Fetch new host based on the bucket location.
-	host := getS3ExpressEndpoint(c.region, s3utils.IsS3ExpressBucket(bucketName))
+	// Fetch new host based on the bucket location, other endpoints
+	// serve the session themselves.
+	host := targetURL.Host
+	if s3utils.IsAmazonEndpoint(targetURL) {
+		host = getS3ExpressEndpoint(c.region, s3utils.IsS3ExpressBucket(bucketName))
+	}
 
 	// as it works in makeTargetURL method from api.go file
 	if h, p, err := net.SplitHostPort(host); err == nil {
@@ -144,6 +148,11 @@ func (c *Client) createSessionRequest(ctx context.Context, bucketName string, se
 	// Set UserAgent for the request.
 	c.setUserAgent(req)
 
+	// Run the request interceptors before signing.
+	if err = c.interceptRequest(req); err != nil {
+		return nil, err
+	}
+
 	// Get credentials from the configured credentials provider.
 	value, err := c.credsProvider.GetWithContext(c.CredContext())
 	if err != nil {
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openstor/openstor-go/v7/pkg/credentials"
+)
+
+func TestS3ExpressSigning(t *testing.T) {
+	const bucket = "data--use1-az4--x-s3"
+	expiration := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	testCases := []struct {
+		bucket    string
+		s3Express bool
+		service   string
+		sessions  int
+	}{
+		{bucket, true, "s3express", 1},
+		{bucket, false, "s3", 0},
+		{"bucket", true, "s3", 0},
+	}
+
+	for i, testCase := range testCases {
+		var sessions int
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth := r.Header.Get("Authorization")
+			if r.URL.Query().Has("session") {
+				sessions++
+				if !strings.Contains(auth, "Credential=accessKey/") || !strings.Contains(auth, "/s3express/aws4_request") {
+					t.Errorf("Test %d: unexpected CreateSession authorization %s", i+1, auth)
+				}
+				if mode := r.Header.Get("x-amz-create-session-mode"); mode != string(SessionReadWrite) {
+					t.Errorf("Test %d: unexpected session mode %s", i+1, mode)
+				}
+				fmt.Fprintf(w, `<CreateSessionResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Credentials>`+
+					`<AccessKeyId>sessionKey</AccessKeyId><SecretAccessKey>sessionSecret</SecretAccessKey>`+
+					`<SessionToken>sessionToken</SessionToken><Expiration>%s</Expiration></Credentials></CreateSessionResult>`, expiration)
+				return
+			}
+
+			accessKey := "accessKey"
+			if testCase.sessions > 0 {
+				accessKey = "sessionKey"
+				if token := r.Header.Get("x-amz-s3session-token"); token != "sessionToken" {
+					t.Errorf("Test %d: expected the session token to be sent, got %q", i+1, token)
+				}
+				if r.Header.Get("X-Amz-Security-Token") != "" {
+					t.Errorf("Test %d: unexpected security token", i+1)
+				}
+			} else if r.Header.Get("x-amz-s3session-token") != "" {
+				t.Errorf("Test %d: unexpected session token", i+1)
+			}
+			if !strings.Contains(auth, "Credential="+accessKey+"/") || !strings.Contains(auth, "/"+testCase.service+"/aws4_request") {
+				t.Errorf("Test %d: expected signing with %s for %s, got %s", i+1, accessKey, testCase.service, auth)
+			}
+			if r.Method == http.MethodGet {
+				w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+				io.WriteString(w, "data")
+				return
+			}
+			io.Copy(io.Discard, r.Body)
+			w.Header().Set("ETag", `"etag"`)
+		}))
+
+		clnt, err := New(srv.Listener.Addr().String(), &Options{
+			Creds:     credentials.NewStaticV4("accessKey", "secretKey", ""),
+			Region:    "us-east-1",
+			S3Express: testCase.s3Express,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx := context.Background()
+		if _, err = clnt.PutObject(ctx, testCase.bucket, "object", bytes.NewReader([]byte("data")), 4, PutObjectOptions{}); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		obj, err := clnt.GetObject(ctx, testCase.bucket, "object", GetObjectOptions{})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if data, err := io.ReadAll(obj); err != nil || string(data) != "data" {
+			t.Fatalf("Test %d: unexpected object %q, %v", i+1, data, err)
+		}
+		obj.Close()
+		srv.Close()
+
+		// The session credentials are cached.
+		if sessions != testCase.sessions {
+			t.Fatalf("Test %d: expected %d sessions, got %d", i+1, testCase.sessions, sessions)
+		}
+	}
+}
@@ -8827,6 +8827,99 @@ func testCopyObjectV2() {
 	logSuccess(testName, function, args, startTime)
 }
 
+// Tests that CopyObject replaces metadata and tags independently.
+func testCopyObjectDirectives() {
+	// initialize logging params
+	startTime := time.Now()
+	testName := getFuncName()
+	function := "CopyObject(destination, source)"
+	args := map[string]interface{}{}
+
+	c, err := NewClient(ClientConfig{})
+	if err != nil {
+		logError(testName, function, args, startTime, "", "MinIO client object creation failed", err)
+		return
+	}
+
+	// Generate a new random bucket name.
+	bucketName := randString(60, rand.NewSource(time.Now().UnixNano()), "minio-go-test-")
+	args["bucketName"] = bucketName
+
+	err = c.MakeBucket(context.Background(), bucketName, minio.MakeBucketOptions{Region: "us-east-1"})
+	if err != nil {
+		logError(testName, function, args, startTime, "", "MakeBucket failed", err)
+		return
+	}
+	defer cleanupBucket(bucketName, c)
+
+	objectName := randString(60, rand.NewSource(time.Now().UnixNano()), "")
+	args["objectName"] = objectName
+	_, err = c.PutObject(context.Background(), bucketName, objectName, bytes.NewReader([]byte("directives")), 10, minio.PutObjectOptions{
+		UserMetadata: map[string]string{"Origin": "source"},
+		UserTags:     map[string]string{"origin": "source"},
+	})
+	if err != nil {
+		logError(testName, function, args, startTime, "", "PutObject failed", err)
+		return
+	}
+
+	for i, testCase := range []struct {
+		replaceMetadata bool
+		replaceTags     bool
+	}{
+		{false, false},
+		{true, false},
+		{false, true},
+		{true, true},
+	} {
+		dst := minio.CopyDestOptions{
+			Bucket:          bucketName,
+			Object:          objectName + "-copy-" + strconv.Itoa(i),
+			UserMetadata:    map[string]string{"Origin": "copy"},
+			UserTags:        map[string]string{"origin": "copy"},
+			ReplaceMetadata: testCase.replaceMetadata,
+			ReplaceTags:     testCase.replaceTags,
+		}
+		args["destination"] = dst
+
+		_, err = c.CopyObject(context.Background(), dst, minio.CopySrcOptions{Bucket: bucketName, Object: objectName})
+		if err != nil {
+			logError(testName, function, args, startTime, "", "CopyObject failed", err)
+			return
+		}
+
+		expectedMeta, expectedTag := "source", "source"
+		if testCase.replaceMetadata {
+			expectedMeta = "copy"
+		}
+		if testCase.replaceTags {
+			expectedTag = "copy"
+		}
+
+		objInfo, err := c.StatObject(context.Background(), dst.Bucket, dst.Object, minio.StatObjectOptions{})
+		if err != nil {
+			logError(testName, function, args, startTime, "", "StatObject failed", err)
+			return
+		}
+		if got := objInfo.UserMetadata["Origin"]; got != expectedMeta {
+			logError(testName, function, args, startTime, "", "Unexpected metadata, expected "+expectedMeta+" got "+got, nil)
+			return
+		}
+
+		tags, err := c.GetObjectTagging(context.Background(), dst.Bucket, dst.Object, minio.GetObjectTaggingOptions{})
+		if err != nil {
+			logError(testName, function, args, startTime, "", "GetObjectTagging failed", err)
+			return
+		}
+		if got := tags.ToMap()["origin"]; got != expectedTag {
+			logError(testName, function, args, startTime, "", "Unexpected tag, expected "+expectedTag+" got "+got, nil)
+			return
+		}
+	}
+
+	logSuccess(testName, function, args, startTime)
+}
+
 // Tests copy object with various checksum scenarios, tries to not repeat CopyObjectV2 test and
 // instead just focus on Checksum.
 func testCopyObjectWithChecksums() {
@@ -14678,6 +14771,7 @@ func main() {
 		testGetObjectReadAtFunctionalV2()
 		testGetObjectRanges()
 		testCopyObjectV2()
+		testCopyObjectDirectives()
 		testFunctionalV2()
 		testComposeObjectErrorCasesV2()
 		testCompose10KSourcesV2()
@@ -23,10 +23,14 @@ package openstor
 import (
 	"context"
 	"math/rand"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
+	"sync/atomic"
 	"testing"
 	"time"
+	"weak"
 )
 
 func TestHealthCheck(t *testing.T) {
@@ -47,6 +51,9 @@ func TestHealthCheck(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	if _, err = clnt.HealthCheck(1 * time.Second); err == nil {
+		t.Fatal("Expected a second health check to fail")
+	}
 
 	probeBucketName := randString(60, rand.NewSource(time.Now().UnixNano()), "probe-health-")
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
@@ -69,4 +76,122 @@ func TestHealthCheck(t *testing.T) {
 	if !clnt.IsOnline() {
 		t.Fatal("Expected online but found offline")
 	}
+
+	// A health check can be started again once the previous one is stopped.
+	if _, err = clnt.HealthCheck(1 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	hcancel()
+	if atomic.LoadInt32(&clnt.healthStatus) == unknown {
+		t.Fatal("Expected the stale cancel not to stop the new health check")
+	}
+	clnt.Close()
+	if atomic.LoadInt32(&clnt.healthStatus) != unknown {
+		t.Fatal("Expected health check to be stopped")
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = clnt.CheckHealth(context.Background()); err != nil {
+		t.Fatalf("Expected endpoint to be healthy, got %v", err)
+	}
+
+	srv.Close()
+	if err = clnt.CheckHealth(context.Background()); err == nil {
+		t.Fatal("Expected an error for an unreachable endpoint")
+	}
+}
+
+func TestHealthCheckInterval(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	if _, err := New(srv.Listener.Addr().String(), &Options{
+		Region:              "us-east-1",
+		HealthCheckInterval: 100 * time.Millisecond,
+	}); err == nil {
+		t.Fatal("Expected an interval below one second to fail")
+	}
+
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Region:              "us-east-1",
+		HealthCheckInterval: time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !clnt.IsOnline() {
+		t.Fatal("Expected online but found offline")
+	}
+	if atomic.LoadInt32(&clnt.healthStatus) == unknown {
+		t.Fatal("Expected health check to be running")
+	}
+
+	// The health check must not keep the client alive.
+	wc := weak.Make(clnt)
+	clnt = nil
+	for i := 0; i < 10 && wc.Value() != nil; i++ {
+		runtime.GC()
+	}
+	if wc.Value() != nil {
+		t.Fatal("Expected client to be garbage collected")
+	}
+}
+
+func TestClientClose(t *testing.T) {
+	closed := make(chan struct{}, 1)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			select {
+			case closed <- struct{}{}:
+			default:
+			}
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Region:              "us-east-1",
+		HealthCheckInterval: time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = clnt.BucketExists(context.Background(), "bucket"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = clnt.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected idle connection to be closed")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&clnt.healthStatus) != unknown {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected health check to be stopped")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
 }
@@ -23,6 +23,7 @@ package openstor
 import (
 	"fmt"
 	"io"
+	"sync"
 )
 
 // hookReader hooks additional reader in the source stream. It is
@@ -94,3 +95,57 @@ func newHook(source, hook io.Reader) io.Reader {
 		hook:   hook,
 	}
 }
+
+// progressFuncHook is a hook reader that reports the bytes read
+// through it to a progress callback, optionally forwarding them to
+// another hook. Calls are serialized, such that the callback is never
+// invoked concurrently even when parts are uploaded in parallel.
+type progressFuncHook struct {
+	mutex       sync.Mutex
+	next        io.Reader
+	fn          func(bytesTransferred, totalBytes int64)
+	total       int64
+	transferred int64
+}
+
+// Read implements io.Reader, it reports len(b) bytes as transferred.
+func (p *progressFuncHook) Read(b []byte) (n int, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.next != nil {
+		if _, err = p.next.Read(b); err != nil && err != io.EOF {
+			return 0, err
+		}
+	}
+	p.add(int64(len(b)), p.total)
+	return len(b), nil
+}
+
+// report adds n bytes to the transferred total out of total bytes.
+func (p *progressFuncHook) report(n, total int64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.add(n, total)
+}
+
+func (p *progressFuncHook) add(n, total int64) {
+	if n <= 0 {
+		return
+	}
+	p.transferred += n
+	if total >= 0 && p.transferred > total {
+		// Retried parts are read twice, never report more than the total.
+		p.transferred = total
+	}
+	p.fn(p.transferred, total)
+}
+
+// newProgressFuncHook returns a hook reader reporting to fn, next if
+// non-nil is notified the same way as a regular progress hook.
+func newProgressFuncHook(next io.Reader, fn func(bytesTransferred, totalBytes int64), total int64) *progressFuncHook {
+	return &progressFuncHook{
+		next:  next,
+		fn:    fn,
+		total: total,
+	}
+}
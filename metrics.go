@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MetricsCollector receives the metrics of the requests sent by a
+// client, see Options.MetricsCollector. Operations are named after the
+// S3 API, e.g. "PutObject", "UploadPart" or "GetBucketTagging". The
+// methods are called concurrently and should return quickly.
+type MetricsCollector interface {
+	// ObserveRequest is called once per request sent, retries
+	// included, with the time until its response headers were
+	// received, the response status code, zero when no response was
+	// received, and the request error.
+	ObserveRequest(op string, dur time.Duration, statusCode int, err error)
+
+	// ObserveBytes is called as the request body is sent and the
+	// response body is read, with the number of bytes transferred.
+	ObserveBytes(op string, n int64)
+}
+
+// metricsSubresources are the query parameters selecting the
+// subresource of a bucket or object request.
+var metricsSubresources = []string{
+	"acl",
+	"attributes",
+	"cors",
+	"encryption",
+	"legal-hold",
+	"lifecycle",
+	"logging",
+	"minio-inventory",
+	"notification",
+	"object-lock",
+	"ownershipControls",
+	"policy",
+	"policyStatus",
+	"publicAccessBlock",
+	"qos",
+	"qos-metrics",
+	"replication",
+	"replication-check",
+	"replication-metrics",
+	"replication-reset",
+	"replication-reset-cancel",
+	"replication-reset-status",
+	"retention",
+	"tagging",
+	"versioning",
+}
+
+// metricsOperation returns the name of the operation of a request.
+func metricsOperation(method string, metadata requestMetadata) string {
+	query := metadata.queryValues
+	var subresource string
+	for _, name := range metricsSubresources {
+		if query.Has(name) {
+			// e.g. legal-hold becomes LegalHold.
+			for _, word := range strings.Split(name, "-") {
+				subresource += strings.ToUpper(word[:1]) + word[1:]
+			}
+			break
+		}
+	}
+	verb := map[string]string{
+		http.MethodGet:    "Get",
+		http.MethodPut:    "Put",
+		http.MethodDelete: "Delete",
+	}[method]
+
+	switch {
+	case method == http.MethodGet && query.Has("ping"):
+		return "ListenBucketNotification"
+	case metadata.bucketName == "":
+		if method == http.MethodGet {
+			return "ListBuckets"
+		}
+	case metadata.objectName != "":
+		switch {
+		case query.Has("uploadId"):
+			switch method {
+			case http.MethodGet:
+				return "ListParts"
+			case http.MethodPut:
+				if metadata.customHeader.Get("x-amz-copy-source") != "" {
+					return "UploadPartCopy"
+				}
+				return "UploadPart"
+			case http.MethodPost:
+				return "CompleteMultipartUpload"
+			case http.MethodDelete:
+				return "AbortMultipartUpload"
+			}
+		case method == http.MethodPost && query.Has("uploads"):
+			return "CreateMultipartUpload"
+		case method == http.MethodPost && query.Has("select"):
+			return "SelectObjectContent"
+		case method == http.MethodPost && query.Has("restore"):
+			return "RestoreObject"
+		case subresource != "" && verb != "":
+			return verb + "Object" + subresource
+		case method == http.MethodHead:
+			return "HeadObject"
+		case method == http.MethodPut && metadata.customHeader.Get("x-amz-copy-source") != "":
+			return "CopyObject"
+		case verb != "":
+			return verb + "Object"
+		}
+	default:
+		switch {
+		case method == http.MethodPost && query.Has("delete"):
+			return "DeleteObjects"
+		case subresource != "" && verb != "":
+			return verb + "Bucket" + subresource
+		case method == http.MethodHead:
+			return "HeadBucket"
+		case method == http.MethodGet:
+			switch {
+			case query.Has("location"):
+				return "GetBucketLocation"
+			case query.Has("session"):
+				return "CreateSession"
+			case query.Has("uploads"):
+				return "ListMultipartUploads"
+			case query.Has("versions"):
+				return "ListObjectVersions"
+			case query.Get("list-type") == "2":
+				return "ListObjectsV2"
+			}
+			return "ListObjects"
+		case method == http.MethodPut:
+			return "CreateBucket"
+		case method == http.MethodDelete:
+			return "DeleteBucket"
+		}
+	}
+	return method
+}
+
+// metricsBody reports the bytes read from a request or response body
+// to the metrics collector.
+type metricsBody struct {
+	io.ReadCloser
+	op        string
+	collector MetricsCollector
+}
+
+func (b *metricsBody) Read(p []byte) (n int, err error) {
+	n, err = b.ReadCloser.Read(p)
+	if n > 0 {
+		b.collector.ObserveBytes(b.op, int64(n))
+	}
+	return n, err
+}
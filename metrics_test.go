@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openstor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openstor/openstor-go/v7/pkg/credentials"
+)
+
+func TestMetricsOperation(t *testing.T) {
+	copyHeader := http.Header{"X-Amz-Copy-Source": []string{"/src/object"}}
+	testCases := []struct {
+		method   string
+		metadata requestMetadata
+		op       string
+	}{
+		{http.MethodGet, requestMetadata{}, "ListBuckets"},
+		{http.MethodPut, requestMetadata{bucketName: "bucket"}, "CreateBucket"},
+		{http.MethodHead, requestMetadata{bucketName: "bucket"}, "HeadBucket"},
+		{http.MethodGet, requestMetadata{bucketName: "bucket", queryValues: url.Values{"list-type": {"2"}, "prefix": {""}}}, "ListObjectsV2"},
+		{http.MethodGet, requestMetadata{bucketName: "bucket", queryValues: url.Values{"location": {""}}}, "GetBucketLocation"},
+		{http.MethodPut, requestMetadata{bucketName: "bucket", queryValues: url.Values{"tagging": {""}}}, "PutBucketTagging"},
+		{http.MethodGet, requestMetadata{bucketName: "bucket", queryValues: url.Values{"replication-metrics": {""}}}, "GetBucketReplicationMetrics"},
+		{http.MethodPost, requestMetadata{bucketName: "bucket", queryValues: url.Values{"delete": {""}}}, "DeleteObjects"},
+		{http.MethodGet, requestMetadata{bucketName: "bucket", queryValues: url.Values{"ping": {"10"}}}, "ListenBucketNotification"},
+		{http.MethodGet, requestMetadata{bucketName: "bucket", objectName: "object"}, "GetObject"},
+		{http.MethodHead, requestMetadata{bucketName: "bucket", objectName: "object"}, "HeadObject"},
+		{http.MethodPut, requestMetadata{bucketName: "bucket", objectName: "object"}, "PutObject"},
+		{http.MethodPut, requestMetadata{bucketName: "bucket", objectName: "object", customHeader: copyHeader}, "CopyObject"},
+		{http.MethodDelete, requestMetadata{bucketName: "bucket", objectName: "object", queryValues: url.Values{"legal-hold": {""}}}, "DeleteObjectLegalHold"},
+		{http.MethodPost, requestMetadata{bucketName: "bucket", objectName: "object", queryValues: url.Values{"uploads": {""}}}, "CreateMultipartUpload"},
+		{http.MethodPut, requestMetadata{bucketName: "bucket", objectName: "object", queryValues: url.Values{"uploadId": {"id"}, "partNumber": {"1"}}}, "UploadPart"},
+		{http.MethodPut, requestMetadata{bucketName: "bucket", objectName: "object", queryValues: url.Values{"uploadId": {"id"}, "partNumber": {"1"}}, customHeader: copyHeader}, "UploadPartCopy"},
+		{http.MethodPost, requestMetadata{bucketName: "bucket", objectName: "object", queryValues: url.Values{"uploadId": {"id"}}}, "CompleteMultipartUpload"},
+		{http.MethodDelete, requestMetadata{bucketName: "bucket", objectName: "object", queryValues: url.Values{"uploadId": {"id"}}}, "AbortMultipartUpload"},
+		{http.MethodPost, requestMetadata{bucketName: "bucket", objectName: "object", queryValues: url.Values{"select": {""}, "select-type": {"2"}}}, "SelectObjectContent"},
+	}
+	for i, testCase := range testCases {
+		if op := metricsOperation(testCase.method, testCase.metadata); op != testCase.op {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.op, op)
+		}
+	}
+}
+
+type requestObservation struct {
+	op         string
+	statusCode int
+	err        error
+}
+
+type testMetricsCollector struct {
+	mu       sync.Mutex
+	requests []requestObservation
+	bytes    map[string]int64
+}
+
+func (m *testMetricsCollector) ObserveRequest(op string, dur time.Duration, statusCode int, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, requestObservation{op, statusCode, err})
+}
+
+func (m *testMetricsCollector) ObserveBytes(op string, n int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bytes[op] += n
+}
+
+func TestMetricsCollector(t *testing.T) {
+	var tagRequests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		switch {
+		case r.URL.Query().Has("tagging"):
+			// Fail the first request to be retried.
+			if tagRequests++; tagRequests == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				io.WriteString(w, `<Error><Code>SlowDown</Code><Message>Please reduce your request rate.</Message></Error>`)
+				return
+			}
+			io.WriteString(w, `<Tagging><TagSet><Tag><Key>k</Key><Value>v</Value></Tag></TagSet></Tagging>`)
+		case r.Method == http.MethodPut:
+			io.Copy(io.Discard, r.Body)
+			w.Header().Set("ETag", `"etag"`)
+		case r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodGet:
+			io.WriteString(w, "hello world")
+		}
+	}))
+	defer srv.Close()
+
+	collector := &testMetricsCollector{bytes: make(map[string]int64)}
+	// Use anonymous credentials to send the raw data.
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:            credentials.NewStaticV4("", "", ""),
+		Region:           "us-east-1",
+		MetricsCollector: collector,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if _, err = clnt.PutObject(ctx, "bucket", "object", strings.NewReader("hello"), 5, PutObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	obj, err := clnt.GetObject(ctx, "bucket", "object", GetObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = io.ReadAll(obj); err != nil {
+		t.Fatal(err)
+	}
+	obj.Close()
+	if _, err = clnt.StatObject(ctx, "bucket", "missing", StatObjectOptions{}); err == nil {
+		t.Fatal("expected the missing object not to be found")
+	}
+	if _, err = clnt.GetBucketTagging(ctx, "bucket"); err != nil {
+		t.Fatal(err)
+	}
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	expected := []requestObservation{
+		{"PutObject", http.StatusOK, nil},
+		{"GetObject", http.StatusOK, nil},
+		{"HeadObject", http.StatusNotFound, nil},
+		{"GetBucketTagging", http.StatusServiceUnavailable, nil},
+		{"GetBucketTagging", http.StatusOK, nil},
+	}
+	if len(collector.requests) != len(expected) {
+		t.Fatalf("expected %d requests, got %+v", len(expected), collector.requests)
+	}
+	for i, req := range collector.requests {
+		if req.op != expected[i].op || req.statusCode != expected[i].statusCode {
+			t.Fatalf("Test %d: expected %s with status %d, got %s with status %d", i+1, expected[i].op, expected[i].statusCode, req.op, req.statusCode)
+		}
+		if failed := req.statusCode != http.StatusOK; failed != (req.err != nil) {
+			t.Fatalf("Test %d: unexpected error %v", i+1, req.err)
+		}
+	}
+	if collector.bytes["PutObject"] != 5 || collector.bytes["GetObject"] != int64(len("hello world")) {
+		t.Fatalf("unexpected bytes transferred %v", collector.bytes)
+	}
+}
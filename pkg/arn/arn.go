@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package arn parses and validates Amazon Resource Names as referenced
+// by bucket replication and notification configurations, see
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference-arns.html
+package arn
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ARN - an Amazon Resource Name of the form
+// arn:<partition>:<service>:<region>:<accountID>:<resource>
+type ARN struct {
+	Partition string
+	Service   string
+	Region    string
+	AccountID string
+	Resource  string
+}
+
+// String returns the string format of the ARN.
+func (a ARN) String() string {
+	return "arn:" + a.Partition + ":" + a.Service + ":" + a.Region + ":" + a.AccountID + ":" + a.Resource
+}
+
+// Error is returned for a malformed ARN, Field names the malformed
+// element, one of "prefix", "format", "partition", "service", "region",
+// "accountID" or "resource".
+type Error struct {
+	ARN    string
+	Field  string
+	Reason string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid ARN %q: %s %s", e.ARN, e.Field, e.Reason)
+}
+
+// Parse parses and validates the string representation of an ARN. The
+// resource may itself contain colons, e.g. "function:name:version".
+// Returns an *Error naming the malformed element otherwise.
+func Parse(s string) (ARN, error) {
+	parts := strings.SplitN(s, ":", 6)
+	if parts[0] != "arn" {
+		return ARN{}, &Error{ARN: s, Field: "prefix", Reason: "must be 'arn'"}
+	}
+	if len(parts) != 6 {
+		return ARN{}, &Error{ARN: s, Field: "format", Reason: "must be 'arn:<partition>:<service>:<region>:<accountID>:<resource>'"}
+	}
+	a := ARN{
+		Partition: parts[1],
+		Service:   parts[2],
+		Region:    parts[3],
+		AccountID: parts[4],
+		Resource:  parts[5],
+	}
+	switch {
+	case a.Partition == "":
+		return ARN{}, &Error{ARN: s, Field: "partition", Reason: "cannot be empty"}
+	case !isName(a.Partition):
+		return ARN{}, &Error{ARN: s, Field: "partition", Reason: "must only contain lowercase letters, digits and hyphens"}
+	case a.Service == "":
+		return ARN{}, &Error{ARN: s, Field: "service", Reason: "cannot be empty"}
+	case !isName(a.Service):
+		return ARN{}, &Error{ARN: s, Field: "service", Reason: "must only contain lowercase letters, digits and hyphens"}
+	case !isName(a.Region):
+		return ARN{}, &Error{ARN: s, Field: "region", Reason: "must only contain lowercase letters, digits and hyphens"}
+	case !isAccountID(a.AccountID):
+		return ARN{}, &Error{ARN: s, Field: "accountID", Reason: "must only contain letters, digits, hyphens, underscores and dots"}
+	case a.Resource == "":
+		return ARN{}, &Error{ARN: s, Field: "resource", Reason: "cannot be empty"}
+	case strings.IndexFunc(a.Resource, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0:
+		return ARN{}, &Error{ARN: s, Field: "resource", Reason: "cannot contain white space or control characters"}
+	}
+	return a, nil
+}
+
+// isName tells whether s only contains lowercase letters, digits and
+// hyphens, as partitions, services and regions do.
+func isName(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// isAccountID tells whether s is a valid account ID. Besides the AWS
+// 12 digit account IDs, MinIO uses target IDs and UUIDs.
+func isAccountID(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' && r != '_' && r != '.' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package arn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		arn      string
+		expected ARN
+		field    string
+	}{
+		{"arn:aws:s3:::dest", ARN{Partition: "aws", Service: "s3", Resource: "dest"}, ""},
+		{"arn:aws:sns:us-east-1:804064459714:UploadPhoto", ARN{"aws", "sns", "us-east-1", "804064459714", "UploadPhoto"}, ""},
+		{"arn:minio:sqs::_:webhook", ARN{"minio", "sqs", "", "_", "webhook"}, ""},
+		{"arn:minio:replication::dadddae7-f1d7-440f-b5d6-651aa9a8c8a7:dest", ARN{"minio", "replication", "", "dadddae7-f1d7-440f-b5d6-651aa9a8c8a7", "dest"}, ""},
+		{"arn:aws:lambda:us-west-2:123456789012:function:resize:1", ARN{"aws", "lambda", "us-west-2", "123456789012", "function:resize:1"}, ""},
+		{"", ARN{}, "prefix"},
+		{"dest", ARN{}, "prefix"},
+		{"arm:aws:s3:::dest", ARN{}, "prefix"},
+		{"arn:aws:s3::dest", ARN{}, "format"},
+		{"arn::s3:::dest", ARN{}, "partition"},
+		{"arn:AWS:s3:::dest", ARN{}, "partition"},
+		{"arn:aws::::dest", ARN{}, "service"},
+		{"arn:aws:s 3:::dest", ARN{}, "service"},
+		{"arn:aws:sns:us_east_1:1:topic", ARN{}, "region"},
+		{"arn:aws:sns:us-east-1:1/2:topic", ARN{}, "accountID"},
+		{"arn:aws:s3:::", ARN{}, "resource"},
+		{"arn:aws:s3:::my bucket", ARN{}, "resource"},
+	}
+	for i, testCase := range testCases {
+		got, err := Parse(testCase.arn)
+		if testCase.field == "" {
+			if err != nil {
+				t.Fatalf("Test %d: unexpected error %v", i+1, err)
+			}
+			if got != testCase.expected {
+				t.Fatalf("Test %d: expected %+v, got %+v", i+1, testCase.expected, got)
+			}
+			if got.String() != testCase.arn {
+				t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.arn, got)
+			}
+			continue
+		}
+		var arnErr *Error
+		if !errors.As(err, &arnErr) {
+			t.Fatalf("Test %d: expected an *Error, got %v", i+1, err)
+		}
+		if arnErr.Field != testCase.field {
+			t.Fatalf("Test %d: expected malformed %s, got %v", i+1, testCase.field, err)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// RefreshFunc fetches a new credentials value along with the time
+// it expires at, a zero expiration means it never expires. The HTTP
+// client and endpoint of the retrieval are available through
+// CredContextFromContext(ctx).
+type RefreshFunc func(ctx context.Context) (Value, time.Time, error)
+
+type credContextKey struct{}
+
+// CredContextFromContext returns the CredContext a RefreshFunc is
+// called with, nil if ctx does not carry any.
+func CredContextFromContext(ctx context.Context) *CredContext {
+	cc, _ := ctx.Value(credContextKey{}).(*CredContext)
+	return cc
+}
+
+// A Refreshing retrieves credentials through a user supplied function
+// and proactively refreshes them before they expire, such that no
+// request is signed with a token about to expire.
+type Refreshing struct {
+	Expiry
+
+	fetch RefreshFunc
+	skew  time.Duration
+
+	// Set when the last value has no expiration.
+	noExpiry bool
+}
+
+// NewRefreshingProvider returns a pointer to a new Credentials object
+// wrapping fetch. Credentials are refreshed skew before they expire,
+// if skew is zero or negative they are refreshed after 80% of their
+// lifetime instead.
+func NewRefreshingProvider(fetch RefreshFunc, skew time.Duration) *Credentials {
+	return New(&Refreshing{
+		fetch: fetch,
+		skew:  skew,
+	})
+}
+
+// Retrieve fetches new credentials.
+//
+// Deprecated: Retrieve() exists for historical compatibility and should not
+// be used. To get new credentials use the RetrieveWithCredContext function
+// to ensure the proper context (i.e. HTTP client) will be used.
+func (r *Refreshing) Retrieve() (Value, error) {
+	return r.RetrieveWithCredContext(nil)
+}
+
+// RetrieveWithCredContext fetches new credentials and records when
+// they need to be refreshed.
+func (r *Refreshing) RetrieveWithCredContext(cc *CredContext) (Value, error) {
+	if r.fetch == nil {
+		return Value{}, errors.New("credentials refresh function is not set")
+	}
+	if cc == nil {
+		cc = defaultCredContext
+	}
+
+	v, expiration, err := r.fetch(context.WithValue(context.Background(), credContextKey{}, cc))
+	if err != nil {
+		return Value{}, err
+	}
+
+	r.noExpiry = expiration.IsZero()
+	if !r.noExpiry {
+		window := r.skew
+		if window <= 0 {
+			// Use the default window of 80% of the lifetime.
+			window = -1
+		}
+		r.SetExpiration(expiration, window)
+	}
+	v.Expiration = expiration
+	return v, nil
+}
+
+// IsExpired returns if the credentials are about to expire.
+func (r *Refreshing) IsExpired() bool {
+	if r.noExpiry {
+		return false
+	}
+	return r.Expiry.IsExpired()
+}
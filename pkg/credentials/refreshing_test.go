@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRefreshingProvider(t *testing.T) {
+	now := time.Now()
+	fetches := 0
+	creds := NewRefreshingProvider(func(_ context.Context) (Value, time.Time, error) {
+		fetches++
+		return Value{AccessKeyID: "access", SecretAccessKey: "secret", SessionToken: "token"}, now.Add(time.Hour), nil
+	}, 5*time.Minute)
+
+	provider := creds.provider.(*Refreshing)
+	provider.CurrentTime = func() time.Time { return now }
+
+	v, err := creds.GetWithContext(defaultCredContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.SessionToken != "token" || !v.Expiration.Equal(now.Add(time.Hour)) {
+		t.Fatalf("unexpected credentials %+v", v)
+	}
+
+	testCases := []struct {
+		elapsed time.Duration
+		fetches int
+	}{
+		{time.Minute, 1},
+		{54 * time.Minute, 1},
+		{56 * time.Minute, 2},
+	}
+	for i, testCase := range testCases {
+		provider.CurrentTime = func() time.Time { return now.Add(testCase.elapsed) }
+		if _, err = creds.GetWithContext(defaultCredContext); err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if fetches != testCase.fetches {
+			t.Fatalf("Test %d: expected %d fetches, got %d", i+1, testCase.fetches, fetches)
+		}
+	}
+}
+
+func TestRefreshingProviderDefaultWindow(t *testing.T) {
+	now := time.Now()
+	creds := NewRefreshingProvider(func(_ context.Context) (Value, time.Time, error) {
+		return Value{AccessKeyID: "access", SecretAccessKey: "secret"}, now.Add(100 * time.Minute), nil
+	}, 0)
+	provider := creds.provider.(*Refreshing)
+	provider.CurrentTime = func() time.Time { return now }
+	if _, err := creds.GetWithContext(defaultCredContext); err != nil {
+		t.Fatal(err)
+	}
+
+	provider.CurrentTime = func() time.Time { return now.Add(79 * time.Minute) }
+	if creds.IsExpired() {
+		t.Fatal("expected credentials to be valid before 80% of their lifetime")
+	}
+	provider.CurrentTime = func() time.Time { return now.Add(81 * time.Minute) }
+	if !creds.IsExpired() {
+		t.Fatal("expected credentials to be refreshed after 80% of their lifetime")
+	}
+}
+
+func TestRefreshingProviderError(t *testing.T) {
+	creds := NewRefreshingProvider(func(_ context.Context) (Value, time.Time, error) {
+		return Value{}, time.Time{}, errors.New("sts unavailable")
+	}, time.Minute)
+	if _, err := creds.GetWithContext(defaultCredContext); err == nil {
+		t.Fatal("expected fetch error to be returned")
+	}
+}
+
+func TestRefreshingProviderCredContext(t *testing.T) {
+	var got []*CredContext
+	creds := NewRefreshingProvider(func(ctx context.Context) (Value, time.Time, error) {
+		got = append(got, CredContextFromContext(ctx))
+		return Value{AccessKeyID: "access", SecretAccessKey: "secret"}, time.Time{}, nil
+	}, time.Minute)
+
+	cc := &CredContext{Client: &http.Client{}, Endpoint: "https://sts.example.com"}
+	if _, err := creds.GetWithContext(cc); err != nil {
+		t.Fatal(err)
+	}
+	provider := creds.provider.(*Refreshing)
+	if _, err := provider.Retrieve(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != cc || got[1] != defaultCredContext {
+		t.Fatalf("unexpected cred contexts %v", got)
+	}
+	if CredContextFromContext(context.Background()) != nil {
+		t.Fatal("expected no cred context")
+	}
+}
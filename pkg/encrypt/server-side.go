@@ -122,6 +122,34 @@ func NewSSEC(key []byte) (ServerSide, error) {
 	return sse, nil
 }
 
+// NewSSEWithFallback returns a server-side-encryption applying primary,
+// with fallback to be used instead when the server rejects primary,
+// for example SSE-KMS when no KMS is configured. The fallback is not
+// applied automatically, callers retry the request with Fallback(sse)
+// when the request fails because of primary.
+//
+// If primary is nil NewSSEWithFallback returns fallback.
+func NewSSEWithFallback(primary, fallback ServerSide) ServerSide {
+	if primary == nil {
+		return fallback
+	}
+	return withFallback{ServerSide: primary, fallback: fallback}
+}
+
+// Fallback returns the fallback server-side-encryption of sse, created
+// with NewSSEWithFallback, or nil if sse has no fallback.
+func Fallback(sse ServerSide) ServerSide {
+	if sse, ok := sse.(withFallback); ok {
+		return sse.fallback
+	}
+	return nil
+}
+
+type withFallback struct {
+	ServerSide
+	fallback ServerSide
+}
+
 // SSE transforms a SSE-C copy encryption into a SSE-C encryption.
 // It is the inverse of SSECopy(...).
 //
@@ -33,6 +33,11 @@ func (r *Cache[K, V]) Delete(key K) {
 	r.m.Delete(key)
 }
 
+// Clear - Removes all the keys.
+func (r *Cache[K, V]) Clear() {
+	r.m.Clear()
+}
+
 // Get - Returns a value of a given key if it exists.
 func (r *Cache[K, V]) Get(key K) (value V, ok bool) {
 	return r.load(key)
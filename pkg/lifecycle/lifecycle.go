@@ -25,10 +25,16 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"errors"
+	"fmt"
 	"time"
 )
 
-var errMissingStorageClass = errors.New("storage-class cannot be empty")
+var (
+	errMissingStorageClass = errors.New("storage-class cannot be empty")
+	errMissingRuleID       = errors.New("rule ID cannot be empty")
+	errRuleIDTooLong       = errors.New("rule ID cannot be longer than 255 characters")
+	errMissingAction       = errors.New("rule must expire, transition or abort incomplete multipart uploads")
+)
 
 // AbortIncompleteMultipartUpload structure, not supported yet on MinIO
 type AbortIncompleteMultipartUpload struct {
@@ -83,10 +89,12 @@ func (n NoncurrentVersionExpiration) isNull() bool {
 // transition noncurrent object versions to different set storage classes
 // at a specific period in the object's lifetime.
 type NoncurrentVersionTransition struct {
-	XMLName                 xml.Name       `xml:"NoncurrentVersionTransition,omitempty"  json:"-"`
-	StorageClass            string         `xml:"StorageClass,omitempty" json:"StorageClass,omitempty"`
-	NoncurrentDays          ExpirationDays `xml:"NoncurrentDays" json:"NoncurrentDays"`
-	NewerNoncurrentVersions int            `xml:"NewerNoncurrentVersions,omitempty" json:"NewerNoncurrentVersions,omitempty"`
+	XMLName        xml.Name       `xml:"NoncurrentVersionTransition,omitempty"  json:"-"`
+	StorageClass   string         `xml:"StorageClass,omitempty" json:"StorageClass,omitempty"`
+	NoncurrentDays ExpirationDays `xml:"NoncurrentDays" json:"NoncurrentDays"`
+	// NewerNoncurrentVersions is the number of newest noncurrent
+	// versions which are not transitioned.
+	NewerNoncurrentVersions int `xml:"NewerNoncurrentVersions,omitempty" json:"NewerNoncurrentVersions,omitempty"`
 }
 
 // IsDaysNull returns true if days field is null
@@ -267,8 +275,28 @@ func (f Filter) MarshalJSON() ([]byte, error) {
 	return json.Marshal(newf)
 }
 
+// predicates returns the number of conditions set outside of And.
+func (f Filter) predicates() (n int) {
+	if f.Prefix != "" {
+		n++
+	}
+	if !f.Tag.IsEmpty() {
+		n++
+	}
+	if f.ObjectSizeLessThan > 0 {
+		n++
+	}
+	if f.ObjectSizeGreaterThan > 0 {
+		n++
+	}
+	return n
+}
+
 // MarshalXML - produces the xml representation of the Filter struct
 // only one of Prefix, And and Tag should be present in the output.
+// Multiple conditions set outside of And, for example a prefix along
+// with an object size bound, are combined in an And element as S3
+// requires.
 func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if err := e.EncodeToken(start); err != nil {
 		return err
@@ -279,6 +307,18 @@ func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		if err := e.EncodeElement(f.And, xml.StartElement{Name: xml.Name{Local: "And"}}); err != nil {
 			return err
 		}
+	case f.predicates() > 1:
+		and := And{
+			Prefix:                f.Prefix,
+			ObjectSizeLessThan:    f.ObjectSizeLessThan,
+			ObjectSizeGreaterThan: f.ObjectSizeGreaterThan,
+		}
+		if !f.Tag.IsEmpty() {
+			and.Tags = []Tag{f.Tag}
+		}
+		if err := e.EncodeElement(and, xml.StartElement{Name: xml.Name{Local: "And"}}); err != nil {
+			return err
+		}
 	case !f.Tag.IsEmpty():
 		if err := e.EncodeElement(f.Tag, xml.StartElement{Name: xml.Name{Local: "Tag"}}); err != nil {
 			return err
@@ -543,3 +583,91 @@ func (c *Configuration) Empty() bool {
 func NewConfiguration() *Configuration {
 	return &Configuration{}
 }
+
+// RuleBuilder builds a lifecycle rule, see NewRule.
+type RuleBuilder struct {
+	rule Rule
+	err  error
+}
+
+// NewRule starts building an enabled lifecycle rule with the given ID,
+// e.g.
+//
+//	rule, err := lifecycle.NewRule("archive-logs").
+//		WithPrefix("logs/").
+//		TransitionAfterDays(30, "GLACIER").
+//		ExpireAfterDays(365).
+//		Build()
+func NewRule(id string) *RuleBuilder {
+	return &RuleBuilder{rule: Rule{ID: id, Status: "Enabled"}}
+}
+
+// setErr records the first invalid argument, reported by Build.
+func (b *RuleBuilder) setErr(err error) *RuleBuilder {
+	if b.err == nil {
+		b.err = err
+	}
+	return b
+}
+
+// WithPrefix applies the rule to the objects whose name starts with prefix.
+func (b *RuleBuilder) WithPrefix(prefix string) *RuleBuilder {
+	b.rule.RuleFilter.Prefix = prefix
+	return b
+}
+
+// ExpireAfterDays expires the objects the given number of days after
+// their creation.
+func (b *RuleBuilder) ExpireAfterDays(days int) *RuleBuilder {
+	if days <= 0 {
+		return b.setErr(fmt.Errorf("expiration days must be positive, got %d", days))
+	}
+	b.rule.Expiration.Days = ExpirationDays(days)
+	return b
+}
+
+// TransitionAfterDays transitions the objects to storageClass the given
+// number of days after their creation.
+func (b *RuleBuilder) TransitionAfterDays(days int, storageClass string) *RuleBuilder {
+	if days <= 0 {
+		return b.setErr(fmt.Errorf("transition days must be positive, got %d", days))
+	}
+	if storageClass == "" {
+		return b.setErr(errMissingStorageClass)
+	}
+	b.rule.Transition = Transition{Days: ExpirationDays(days), StorageClass: storageClass}
+	return b
+}
+
+// AbortIncompleteMultipartAfterDays aborts the multipart uploads left
+// incomplete the given number of days after their initiation.
+func (b *RuleBuilder) AbortIncompleteMultipartAfterDays(days int) *RuleBuilder {
+	if days <= 0 {
+		return b.setErr(fmt.Errorf("days after initiation must be positive, got %d", days))
+	}
+	b.rule.AbortIncompleteMultipartUpload.DaysAfterInitiation = ExpirationDays(days)
+	return b
+}
+
+// Build validates and returns the rule. It fails on an invalid argument
+// given to the builder, a rule without action, or objects expiring
+// before or on the day they transition.
+func (b *RuleBuilder) Build() (Rule, error) {
+	if b.err != nil {
+		return Rule{}, b.err
+	}
+	r := b.rule
+	switch {
+	case r.ID == "":
+		return Rule{}, errMissingRuleID
+	case len(r.ID) > 255:
+		return Rule{}, errRuleIDTooLong
+	case r.Expiration.IsNull() && r.Transition.IsNull() && r.AbortIncompleteMultipartUpload.IsDaysNull():
+		return Rule{}, errMissingAction
+	}
+	if !r.Expiration.IsDaysNull() && !r.Transition.IsNull() && r.Expiration.Days <= r.Transition.Days {
+		return Rule{}, fmt.Errorf("expiration after %d days must be later than the transition after %d days",
+			r.Expiration.Days, r.Transition.Days)
+	}
+	return r, nil
+}
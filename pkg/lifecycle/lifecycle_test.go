@@ -24,6 +24,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"encoding/xml"
+	"strings"
 	"testing"
 	"time"
 )
@@ -377,6 +378,15 @@ func TestLifecycleXMLRoundtrip(t *testing.T) {
 					NewerNoncurrentVersions: 5,
 				},
 			},
+			{
+				ID:     "newer-noncurrent-transition",
+				Status: "Enabled",
+				NoncurrentVersionTransition: NoncurrentVersionTransition{
+					NoncurrentDays:          ExpirationDays(30),
+					NewerNoncurrentVersions: 3,
+					StorageClass:            "S3TIER-2",
+				},
+			},
 			{
 				ID:     "delmarker-expiration",
 				Status: "Enabled",
@@ -443,7 +453,7 @@ func TestLifecycleXMLRoundtrip(t *testing.T) {
 }
 
 func (n NoncurrentVersionTransition) equals(m NoncurrentVersionTransition) bool {
-	return n.NoncurrentDays == m.NoncurrentDays && n.StorageClass == m.StorageClass
+	return n.NoncurrentDays == m.NoncurrentDays && n.StorageClass == m.StorageClass && n.NewerNoncurrentVersions == m.NewerNoncurrentVersions
 }
 
 func (n NoncurrentVersionExpiration) equals(m NoncurrentVersionExpiration) bool {
@@ -508,3 +518,157 @@ func TestAllVersionsExpiration(t *testing.T) {
 		t.Fatalf("Expected %s but got %s", expected, got)
 	}
 }
+
+func TestObjectSizeFilters(t *testing.T) {
+	testCases := []struct {
+		filter   Filter
+		expected string
+		and      And
+	}{
+		{
+			filter:   Filter{ObjectSizeGreaterThan: 1024},
+			expected: `<Filter><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan></Filter>`,
+		},
+		{
+			filter:   Filter{ObjectSizeGreaterThan: 1024, ObjectSizeLessThan: 1048576},
+			expected: `<Filter><And><Prefix></Prefix><ObjectSizeLessThan>1048576</ObjectSizeLessThan><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan></And></Filter>`,
+			and:      And{ObjectSizeLessThan: 1048576, ObjectSizeGreaterThan: 1024},
+		},
+		{
+			filter:   Filter{Prefix: "logs/", Tag: Tag{Key: "key-1", Value: "value-1"}, ObjectSizeLessThan: 1048576},
+			expected: `<Filter><And><Prefix>logs/</Prefix><Tag><Key>key-1</Key><Value>value-1</Value></Tag><ObjectSizeLessThan>1048576</ObjectSizeLessThan></And></Filter>`,
+			and:      And{Prefix: "logs/", Tags: []Tag{{Key: "key-1", Value: "value-1"}}, ObjectSizeLessThan: 1048576},
+		},
+		{
+			filter: Filter{And: And{
+				Prefix:                "logs/",
+				Tags:                  []Tag{{Key: "key-1", Value: "value-1"}},
+				ObjectSizeLessThan:    1048576,
+				ObjectSizeGreaterThan: 1024,
+			}},
+			expected: `<Filter><And><Prefix>logs/</Prefix><Tag><Key>key-1</Key><Value>value-1</Value></Tag><ObjectSizeLessThan>1048576</ObjectSizeLessThan><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan></And></Filter>`,
+			and: And{
+				Prefix:                "logs/",
+				Tags:                  []Tag{{Key: "key-1", Value: "value-1"}},
+				ObjectSizeLessThan:    1048576,
+				ObjectSizeGreaterThan: 1024,
+			},
+		},
+	}
+
+	for i, testCase := range testCases {
+		buf, err := xml.Marshal(testCase.filter)
+		if err != nil {
+			t.Fatalf("Test %d: failed to marshal filter %v", i+1, err)
+		}
+		if string(buf) != testCase.expected {
+			t.Fatalf("Test %d: expected %s got %s", i+1, testCase.expected, buf)
+		}
+
+		var got Filter
+		if err = xml.Unmarshal(buf, &got); err != nil {
+			t.Fatalf("Test %d: failed to unmarshal filter %v", i+1, err)
+		}
+		if testCase.and.IsEmpty() {
+			if got.ObjectSizeLessThan != testCase.filter.ObjectSizeLessThan || got.ObjectSizeGreaterThan != testCase.filter.ObjectSizeGreaterThan {
+				t.Fatalf("Test %d: expected %#v got %#v", i+1, testCase.filter, got)
+			}
+			continue
+		}
+		if got.And.Prefix != testCase.and.Prefix || got.And.ObjectSizeLessThan != testCase.and.ObjectSizeLessThan ||
+			got.And.ObjectSizeGreaterThan != testCase.and.ObjectSizeGreaterThan || len(got.And.Tags) != len(testCase.and.Tags) {
+			t.Fatalf("Test %d: expected %#v got %#v", i+1, testCase.and, got.And)
+		}
+	}
+}
+
+func TestObjectSizeExpirationRule(t *testing.T) {
+	// Expire objects between 1KiB and 1MiB after 30 days.
+	lc := Configuration{
+		Rules: []Rule{
+			{
+				ID:     "expire-small-objects",
+				Status: "Enabled",
+				RuleFilter: Filter{
+					ObjectSizeGreaterThan: 1 << 10,
+					ObjectSizeLessThan:    1 << 20,
+				},
+				Expiration: Expiration{
+					Days: ExpirationDays(30),
+				},
+			},
+		},
+	}
+
+	buf, err := xml.Marshal(lc)
+	if err != nil {
+		t.Fatalf("failed to marshal lifecycle configuration %v", err)
+	}
+
+	var got Configuration
+	if err = xml.Unmarshal(buf, &got); err != nil {
+		t.Fatalf("failed to unmarshal lifecycle %v", err)
+	}
+	rule := got.Rules[0]
+	if rule.RuleFilter.And.ObjectSizeGreaterThan != 1<<10 || rule.RuleFilter.And.ObjectSizeLessThan != 1<<20 {
+		t.Fatalf("expected object size bounds to round-trip, got %#v", rule.RuleFilter)
+	}
+	if rule.Expiration.Days != 30 {
+		t.Fatalf("expected expiration after 30 days, got %d", rule.Expiration.Days)
+	}
+
+	// Re-encoding the decoded configuration must produce the same document.
+	again, err := xml.Marshal(got)
+	if err != nil {
+		t.Fatalf("failed to marshal lifecycle configuration %v", err)
+	}
+	if !bytes.Equal(buf, again) {
+		t.Fatalf("expected %s got %s", buf, again)
+	}
+}
+
+func TestRuleBuilder(t *testing.T) {
+	rule, err := NewRule("archive-logs").
+		WithPrefix("logs/").
+		TransitionAfterDays(30, "GLACIER").
+		ExpireAfterDays(365).
+		AbortIncompleteMultipartAfterDays(7).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf, err := xml.Marshal(rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<Rule><AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload><Expiration><Days>365</Days></Expiration><ID>archive-logs</ID><Filter><Prefix>logs/</Prefix></Filter><Status>Enabled</Status><Transition><StorageClass>GLACIER</StorageClass><Days>30</Days></Transition></Rule>`
+	if string(buf) != expected {
+		t.Fatalf("expected %s got %s", expected, buf)
+	}
+
+	testCases := []struct {
+		builder *RuleBuilder
+		valid   bool
+	}{
+		{NewRule("expire").ExpireAfterDays(1), true},
+		{NewRule("transition").TransitionAfterDays(0, "GLACIER").ExpireAfterDays(1), false},
+		{NewRule("abort").AbortIncompleteMultipartAfterDays(1), true},
+		{NewRule("no-action").WithPrefix("logs/"), false},
+		{NewRule("").ExpireAfterDays(1), false},
+		{NewRule(strings.Repeat("a", 256)).ExpireAfterDays(1), false},
+		{NewRule("negative").ExpireAfterDays(-1), false},
+		{NewRule("no-storage-class").TransitionAfterDays(1, ""), false},
+		{NewRule("same-day").TransitionAfterDays(30, "GLACIER").ExpireAfterDays(30), false},
+		{NewRule("expire-first").TransitionAfterDays(30, "GLACIER").ExpireAfterDays(10), false},
+		{NewRule("transition-first").TransitionAfterDays(30, "GLACIER").ExpireAfterDays(31), true},
+	}
+	for i, testCase := range testCases {
+		_, err := testCase.builder.Build()
+		if testCase.valid && err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if !testCase.valid && err == nil {
+			t.Fatalf("Test %d: expected the rule to be rejected", i+1)
+		}
+	}
+}
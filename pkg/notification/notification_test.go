@@ -1436,3 +1436,34 @@ func TestNewArnFromString(t *testing.T) {
 		}
 	})
 }
+
+func TestConfiguration_AddDuplicate(t *testing.T) {
+	arn := NewArn("aws", "sqs", "us-east-1", "1", "webhook")
+	newConfig := func(prefix string) Config {
+		config := NewConfig(arn)
+		config.AddEvents(ObjectCreatedAll)
+		config.AddFilterPrefix(prefix)
+		return config
+	}
+
+	var config Configuration
+	if !config.AddQueue(newConfig("images/")) {
+		t.Fatal("expected the queue config to be added")
+	}
+	// An identical config with a distinct filter pointer is a duplicate.
+	if config.AddQueue(newConfig("images/")) {
+		t.Fatal("expected the duplicate queue config to be rejected")
+	}
+	if !config.AddQueue(newConfig("videos/")) {
+		t.Fatal("expected the queue config with another prefix to be added")
+	}
+	if !config.AddTopic(newConfig("images/")) || config.AddTopic(newConfig("images/")) {
+		t.Fatal("unexpected topic config duplicate detection")
+	}
+	if !config.AddLambda(newConfig("images/")) || config.AddLambda(newConfig("images/")) {
+		t.Fatal("unexpected lambda config duplicate detection")
+	}
+	if len(config.QueueConfigs) != 2 || len(config.TopicConfigs) != 1 || len(config.LambdaConfigs) != 1 {
+		t.Fatalf("unexpected configuration %+v", config)
+	}
+}
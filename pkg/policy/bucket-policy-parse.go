@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/openstor/openstor-go/v7/pkg/arn"
+)
+
+// Policy language versions accepted by S3.
+var validVersions = map[string]bool{
+	"2012-10-17": true,
+	"2008-10-17": true,
+}
+
+// Condition operators accepted by S3, without the IfExists suffix and
+// the ForAllValues/ForAnyValue set qualifiers.
+var validConditionOperators = map[string]bool{
+	"StringEquals":              true,
+	"StringNotEquals":           true,
+	"StringEqualsIgnoreCase":    true,
+	"StringNotEqualsIgnoreCase": true,
+	"StringLike":                true,
+	"StringNotLike":             true,
+	"NumericEquals":             true,
+	"NumericNotEquals":          true,
+	"NumericLessThan":           true,
+	"NumericLessThanEquals":     true,
+	"NumericGreaterThan":        true,
+	"NumericGreaterThanEquals":  true,
+	"DateEquals":                true,
+	"DateNotEquals":             true,
+	"DateLessThan":              true,
+	"DateLessThanEquals":        true,
+	"DateGreaterThan":           true,
+	"DateGreaterThanEquals":     true,
+	"Bool":                      true,
+	"BinaryEquals":              true,
+	"IpAddress":                 true,
+	"NotIpAddress":              true,
+	"ArnEquals":                 true,
+	"ArnLike":                   true,
+	"ArnNotEquals":              true,
+	"ArnNotLike":                true,
+	"Null":                      true,
+}
+
+// Parse - parses a JSON bucket policy as accepted by SetBucketPolicy,
+// returning a descriptive error if it does not conform to the policy
+// schema, so that it can be validated before it is sent to the server.
+func Parse(policy string) (*BucketAccessPolicy, error) {
+	dec := json.NewDecoder(strings.NewReader(policy))
+	dec.DisallowUnknownFields()
+	var p BucketAccessPolicy
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("invalid bucket policy: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("invalid bucket policy: unexpected data after the policy")
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// validate - returns an error describing the first violation of the
+// policy schema found.
+func (p BucketAccessPolicy) validate() error {
+	if p.Version != "" && !validVersions[p.Version] {
+		return fmt.Errorf("invalid bucket policy: unsupported Version %q, expected 2012-10-17", p.Version)
+	}
+	if len(p.Statements) == 0 {
+		return errors.New("invalid bucket policy: no Statement found")
+	}
+	for i, statement := range p.Statements {
+		if err := statement.validate(); err != nil {
+			return fmt.Errorf("invalid bucket policy: Statement %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (statement Statement) validate() error {
+	if statement.Effect != "Allow" && statement.Effect != "Deny" {
+		return fmt.Errorf("invalid Effect %q, expected Allow or Deny", statement.Effect)
+	}
+	if err := validateElement("Principal", !statement.Principal.isEmpty(), !statement.NotPrincipal.isEmpty()); err != nil {
+		return err
+	}
+	if err := validateElement("Action", !statement.Actions.IsEmpty(), !statement.NotActions.IsEmpty()); err != nil {
+		return err
+	}
+	// Action names are case-insensitive.
+	for _, action := range statement.Actions.Union(statement.NotActions).ToSlice() {
+		if action != "*" && (!strings.HasPrefix(strings.ToLower(action), "s3:") || len(action) == len("s3:")) {
+			return fmt.Errorf("invalid Action %q, expected an s3: action", action)
+		}
+	}
+	if err := validateElement("Resource", !statement.Resources.IsEmpty(), !statement.NotResources.IsEmpty()); err != nil {
+		return err
+	}
+	// S3 resources are arn:<partition>:s3:::<bucket>[/<key>] in every
+	// partition, e.g. aws, aws-cn or aws-us-gov.
+	for _, resource := range statement.Resources.Union(statement.NotResources).ToSlice() {
+		if resource == "*" {
+			continue
+		}
+		if a, err := arn.Parse(resource); err != nil || a.Service != "s3" || a.Region != "" || a.AccountID != "" {
+			return fmt.Errorf("invalid Resource %q, expected an arn:<partition>:s3::: ARN", resource)
+		}
+	}
+	for operator, condKeyMap := range statement.Conditions {
+		name := strings.TrimPrefix(strings.TrimPrefix(operator, "ForAllValues:"), "ForAnyValue:")
+		if !validConditionOperators[strings.TrimSuffix(name, "IfExists")] {
+			return fmt.Errorf("unsupported Condition operator %q", operator)
+		}
+		if len(condKeyMap) == 0 {
+			return fmt.Errorf("no keys found for Condition operator %q", operator)
+		}
+		for key, values := range condKeyMap {
+			if !strings.Contains(key, ":") {
+				return fmt.Errorf("invalid Condition key %q for %q, expected a prefixed key such as aws:SourceIp", key, operator)
+			}
+			if values.IsEmpty() {
+				return fmt.Errorf("no values found for Condition key %q", key)
+			}
+		}
+	}
+	return nil
+}
+
+// validateElement - returns an error unless exactly one of the element
+// and its Not* counterpart is set.
+func validateElement(name string, has, hasNot bool) error {
+	switch {
+	case has && hasNot:
+		return fmt.Errorf("both %s and Not%s found", name, name)
+	case !has && !hasNot:
+		return fmt.Errorf("no %s or Not%s found", name, name)
+	}
+	return nil
+}
+
+// String - returns the policy serialized as JSON, with statement fields
+// and set values in a fixed order, such that equivalent policies are
+// serialized identically.
+func (p BucketAccessPolicy) String() string {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2025 openstor contributors
+// SPDX-FileCopyrightText: 2015-2025 MinIO, Inc.
+// SPDX-License-Identifier: Apache-2.0
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2025 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		policy   string
+		expected string
+		err      string
+	}{
+		// Valid policies are serialized canonically.
+		{
+			policy:   `{"Version":"2012-10-17","Statement":[{"Sid":"PublicRead","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::mybucket/*"}]}`,
+			expected: `{"Version":"2012-10-17","Statement":[{"Action":["s3:GetObject"],"Effect":"Allow","Principal":{"AWS":["*"]},"Resource":["arn:aws:s3:::mybucket/*"],"Sid":"PublicRead"}]}`,
+		},
+		{
+			policy: `{"Version":"2012-10-17","Id":"Policy1","Statement":[{"Effect":"Deny","Principal":{"AWS":["arn:aws:iam::111122223333:root"]},"Action":["s3:PutObject","s3:DeleteObject"],"Resource":["arn:aws:s3:::mybucket/*","arn:aws:s3:::mybucket"],` +
+				`"Condition":{"Bool":{"aws:SecureTransport":false},"ForAnyValue:StringLikeIfExists":{"s3:prefix":["home/*"]}}}]}`,
+			expected: `{"Version":"2012-10-17","Id":"Policy1","Statement":[{"Action":["s3:DeleteObject","s3:PutObject"],"Condition":{"Bool":{"aws:SecureTransport":["false"]},"ForAnyValue:StringLikeIfExists":{"s3:prefix":["home/*"]}},` +
+				`"Effect":"Deny","Principal":{"AWS":["arn:aws:iam::111122223333:root"]},"Resource":["arn:aws:s3:::mybucket","arn:aws:s3:::mybucket/*"],"Sid":""}]}`,
+		},
+		// Policies taken from AWS, with other principal kinds, Not*
+		// elements or actions in another case.
+		{
+			policy: `{"Version":"2012-10-17","Statement":[{"Sid":"S3ServerAccessLogsPolicy","Effect":"Allow","Principal":{"Service":"logging.s3.amazonaws.com"},"Action":["s3:PutObject"],"Resource":"arn:aws:s3:::logs/*",` +
+				`"Condition":{"StringEquals":{"aws:SourceAccount":"111122223333"}}},{"Effect":"Allow","Principal":{"Federated":"cognito-identity.amazonaws.com"},"Action":"S3:GetObject","Resource":"arn:aws:s3:::logs/*"}]}`,
+			expected: `{"Version":"2012-10-17","Statement":[{"Action":["s3:PutObject"],"Condition":{"StringEquals":{"aws:SourceAccount":["111122223333"]}},"Effect":"Allow","Principal":{"Service":["logging.s3.amazonaws.com"]},"Resource":["arn:aws:s3:::logs/*"],"Sid":"S3ServerAccessLogsPolicy"},` +
+				`{"Action":["S3:GetObject"],"Effect":"Allow","Principal":{"Federated":["cognito-identity.amazonaws.com"]},"Resource":["arn:aws:s3:::logs/*"],"Sid":""}]}`,
+		},
+		{
+			policy:   `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","NotPrincipal":{"AWS":["arn:aws:iam::111122223333:root"]},"NotAction":"s3:GetObject","NotResource":"arn:aws:s3:::mybucket/public/*"}]}`,
+			expected: `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Sid":"","NotAction":["s3:GetObject"],"NotPrincipal":{"AWS":["arn:aws:iam::111122223333:root"]},"NotResource":["arn:aws:s3:::mybucket/public/*"]}]}`,
+		},
+		{
+			policy:   `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::mybucket/*"}]}`,
+			expected: `{"Version":"2012-10-17","Statement":[{"Action":["s3:GetObject"],"Effect":"Allow","Principal":{"AWS":["*"]},"Resource":["arn:aws:s3:::mybucket/*"],"Sid":""}]}`,
+		},
+		{
+			policy:   `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws-cn:s3:::mybucket/*"}]}`,
+			expected: `{"Version":"2012-10-17","Statement":[{"Action":["s3:GetObject"],"Effect":"Allow","Principal":{"AWS":["*"]},"Resource":["arn:aws-cn:s3:::mybucket/*"],"Sid":""}]}`,
+		},
+		{
+			policy:   `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws-us-gov:s3:::mybucket/*"}]}`,
+			expected: `{"Version":"2012-10-17","Statement":[{"Action":["s3:GetObject"],"Effect":"Allow","Principal":{"AWS":["*"]},"Resource":["arn:aws-us-gov:s3:::mybucket/*"],"Sid":""}]}`,
+		},
+		// Invalid policies.
+		{policy: `{"Version":"2012-10-17","Statement":[`, err: "invalid bucket policy: unexpected EOF"},
+		{policy: `{"Version":"2012-10-17","Statements":[]}`, err: `json: unknown field "Statements"`},
+		{policy: `{"Version":"2012-10-17","Statement":[]} {}`, err: "unexpected data after the policy"},
+		{policy: `{"Version":"2020-01-01","Statement":[]}`, err: `unsupported Version "2020-01-01"`},
+		{policy: `{"Version":"2012-10-17","Statement":[]}`, err: "no Statement found"},
+		{
+			policy: `{"Statement":[{"Effect":"allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::mybucket/*"}]}`,
+			err:    `Statement 1: invalid Effect "allow", expected Allow or Deny`,
+		},
+		{
+			policy: `{"Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"arn:aws:s3:::mybucket/*"}]}`,
+			err:    "Statement 1: no Principal or NotPrincipal found",
+		},
+		{
+			policy: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"GetObject","Resource":"arn:aws:s3:::mybucket/*"}]}`,
+			err:    `Statement 1: invalid Action "GetObject"`,
+		},
+		{
+			policy: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject"}]}`,
+			err:    "Statement 1: no Resource or NotResource found",
+		},
+		{
+			policy: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"mybucket/*"}]}`,
+			err:    `Statement 1: invalid Resource "mybucket/*"`,
+		},
+		{
+			policy: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::"}]}`,
+			err:    `Statement 1: invalid Resource "arn:aws:s3:::"`,
+		},
+		{
+			policy: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws-cn:sqs:cn-north-1:111122223333:queue"}]}`,
+			err:    `Statement 1: invalid Resource "arn:aws-cn:sqs:cn-north-1:111122223333:queue"`,
+		},
+		{
+			policy: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"},{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Condition":{"StringMatches":{"s3:prefix":"home/"}}}]}`,
+			err:    `Statement 2: unsupported Condition operator "StringMatches"`,
+		},
+		{
+			policy: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Condition":{"IpAddress":{"SourceIp":"10.0.0.0/8"}}}]}`,
+			err:    `Statement 1: invalid Condition key "SourceIp"`,
+		},
+		{
+			policy: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","NotResource":"arn:aws:s3:::mybucket"}]}`,
+			err:    "Statement 1: both Resource and NotResource found",
+		},
+		{
+			policy: `{"Statement":[{"Effect":"Allow","Principal":"*","NotPrincipal":{"AWS":"arn:aws:iam::111122223333:root"},"Action":"s3:GetObject","Resource":"*"}]}`,
+			err:    "Statement 1: both Principal and NotPrincipal found",
+		},
+		{
+			policy: `{"Statement":[{"Effect":"Allow","Principal":{"Service":"logging.s3.amazonaws.com"},"NotAction":"GetObject","Resource":"*"}]}`,
+			err:    `Statement 1: invalid Action "GetObject"`,
+		},
+	}
+
+	for i, testCase := range testCases {
+		p, err := Parse(testCase.policy)
+		if testCase.err != "" {
+			if err == nil || !strings.Contains(err.Error(), testCase.err) {
+				t.Fatalf("Test %d: expected error containing %q, got %v", i+1, testCase.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if p.String() != testCase.expected {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.expected, p.String())
+		}
+		// The canonical form parses back to itself.
+		if p, err = Parse(p.String()); err != nil || p.String() != testCase.expected {
+			t.Fatalf("Test %d: expected the canonical form to round-trip, got %v, %v", i+1, p, err)
+		}
+	}
+}
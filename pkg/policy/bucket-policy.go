@@ -85,6 +85,13 @@ var startsWithFunc = func(resource, resourcePrefix string) bool {
 type User struct {
 	AWS           set.StringSet `json:"AWS,omitempty"`
 	CanonicalUser set.StringSet `json:"CanonicalUser,omitempty"`
+	Federated     set.StringSet `json:"Federated,omitempty"`
+	Service       set.StringSet `json:"Service,omitempty"`
+}
+
+// isEmpty - returns whether the principal names nobody.
+func (u User) isEmpty() bool {
+	return u.AWS.IsEmpty() && u.CanonicalUser.IsEmpty() && u.Federated.IsEmpty() && u.Service.IsEmpty()
 }
 
 // UnmarshalJSON is a custom json unmarshaler for Principal field,
@@ -116,17 +123,24 @@ func (u *User) UnmarshalJSON(data []byte) error {
 
 // Statement - minio policy statement
 type Statement struct {
-	Actions    set.StringSet `json:"Action"`
+	Actions    set.StringSet `json:"Action,omitempty"`
 	Conditions ConditionMap  `json:"Condition,omitempty"`
 	Effect     string
-	Principal  User          `json:"Principal"`
-	Resources  set.StringSet `json:"Resource"`
+	Principal  User          `json:"Principal,omitzero"`
+	Resources  set.StringSet `json:"Resource,omitempty"`
 	Sid        string
+
+	// Elements matching everything but their values, in place of
+	// Action, Principal and Resource respectively.
+	NotActions   set.StringSet `json:"NotAction,omitempty"`
+	NotPrincipal User          `json:"NotPrincipal,omitzero"`
+	NotResources set.StringSet `json:"NotResource,omitempty"`
 }
 
 // BucketAccessPolicy - minio policy collection
 type BucketAccessPolicy struct {
 	Version    string      // date in YYYY-MM-DD format
+	ID         string      `json:"Id,omitempty"`
 	Statements []Statement `json:"Statement"`
 }
 
@@ -584,6 +584,15 @@ func (tag Tag) IsEmpty() bool {
 	return tag.Key == ""
 }
 
+// MarshalXML leaves out empty <Tag></Tag> elements.
+func (tag Tag) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if tag.IsEmpty() {
+		return nil
+	}
+	type tagWrapper Tag
+	return e.EncodeElement(tagWrapper(tag), start)
+}
+
 // Validate checks this tag.
 func (tag Tag) Validate() error {
 	if len(tag.Key) == 0 || utf8.RuneCountInString(tag.Key) > 128 {
@@ -615,6 +624,15 @@ func (a And) isEmpty() bool {
 	return len(a.Tags) == 0 && a.Prefix == ""
 }
 
+// MarshalXML leaves out empty <And></And> elements.
+func (a And) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if a.isEmpty() {
+		return nil
+	}
+	type andWrapper And
+	return e.EncodeElement(andWrapper(a), start)
+}
+
 // Status represents Enabled/Disabled status
 type Status string
 
@@ -634,6 +652,16 @@ func (d DeleteMarkerReplication) IsEmpty() bool {
 	return len(d.Status) == 0
 }
 
+// MarshalXML leaves out <DeleteMarkerReplication></DeleteMarkerReplication>
+// when its status is not set.
+func (d DeleteMarkerReplication) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if d.IsEmpty() {
+		return nil
+	}
+	type deleteMarkerReplicationWrapper DeleteMarkerReplication
+	return e.EncodeElement(deleteMarkerReplicationWrapper(d), start)
+}
+
 // DeleteReplication - whether versioned deletes are replicated - this
 // is a MinIO specific extension
 type DeleteReplication struct {
@@ -645,6 +673,16 @@ func (d DeleteReplication) IsEmpty() bool {
 	return len(d.Status) == 0
 }
 
+// MarshalXML leaves out <DeleteReplication></DeleteReplication> when its
+// status is not set.
+func (d DeleteReplication) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if d.IsEmpty() {
+		return nil
+	}
+	type deleteReplicationWrapper DeleteReplication
+	return e.EncodeElement(deleteReplicationWrapper(d), start)
+}
+
 // ReplicaModifications specifies if replica modification sync is enabled
 type ReplicaModifications struct {
 	Status Status `xml:"Status" json:"Status"` // should be set to "Enabled" by default
@@ -655,6 +693,16 @@ type SourceSelectionCriteria struct {
 	ReplicaModifications ReplicaModifications `xml:"ReplicaModifications" json:"ReplicaModifications"`
 }
 
+// MarshalXML leaves out <SourceSelectionCriteria></SourceSelectionCriteria>
+// when replica modification sync is not set.
+func (s SourceSelectionCriteria) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if s.ReplicaModifications.Status == "" {
+		return nil
+	}
+	type sourceSelectionCriteriaWrapper SourceSelectionCriteria
+	return e.EncodeElement(sourceSelectionCriteriaWrapper(s), start)
+}
+
 // IsValid - checks whether SourceSelectionCriteria is valid or not.
 func (s SourceSelectionCriteria) IsValid() bool {
 	return s.ReplicaModifications.Status == Enabled || s.ReplicaModifications.Status == Disabled
@@ -673,14 +721,24 @@ func (s SourceSelectionCriteria) Validate() error {
 
 // ExistingObjectReplication - whether existing object replication is enabled
 type ExistingObjectReplication struct {
-	Status Status `xml:"Status"` // should be set to "Disabled" by default
+	Status Status `xml:"Status" json:"Status"` // should be set to "Disabled" by default
 }
 
-// IsEmpty returns true if DeleteMarkerReplication is not set
+// IsEmpty returns true if ExistingObjectReplication is not set
 func (e ExistingObjectReplication) IsEmpty() bool {
 	return len(e.Status) == 0
 }
 
+// MarshalXML leaves out <ExistingObjectReplication></ExistingObjectReplication>
+// when its status is not set.
+func (e ExistingObjectReplication) MarshalXML(en *xml.Encoder, start xml.StartElement) error {
+	if e.IsEmpty() {
+		return nil
+	}
+	type existingObjectReplicationWrapper ExistingObjectReplication
+	return en.EncodeElement(existingObjectReplicationWrapper(e), start)
+}
+
 // Validate validates whether the status is disabled.
 func (e ExistingObjectReplication) Validate() error {
 	if e.IsEmpty() {
@@ -21,6 +21,8 @@
 package replication
 
 import (
+	"encoding/xml"
+	"strings"
 	"testing"
 )
 
@@ -351,3 +353,42 @@ func TestEditReplicationRule(t *testing.T) {
 		}
 	}
 }
+
+// Tests that unset optional rule elements are left out when marshaling.
+func TestRuleMarshalXML(t *testing.T) {
+	rule := Rule{
+		ID:          "rule",
+		Status:      Enabled,
+		Priority:    1,
+		Destination: Destination{Bucket: "arn:aws:s3:::dest"},
+	}
+	b, err := xml.Marshal(rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, elem := range []string{"<DeleteMarkerReplication>", "<DeleteReplication>", "<SourceSelectionCriteria>", "<ExistingObjectReplication>", "<And>", "<Tag>"} {
+		if strings.Contains(string(b), elem) {
+			t.Fatalf("expected %s to be left out, got %s", elem, b)
+		}
+	}
+
+	rule.DeleteMarkerReplication.Status = Enabled
+	rule.DeleteReplication.Status = Disabled
+	rule.SourceSelectionCriteria.ReplicaModifications.Status = Enabled
+	rule.ExistingObjectReplication.Status = Enabled
+	rule.Filter.And = And{Prefix: "logs/", Tags: []Tag{{Key: "k", Value: "v"}}}
+	if b, err = xml.Marshal(rule); err != nil {
+		t.Fatal(err)
+	}
+	for _, elem := range []string{
+		"<DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication>",
+		"<DeleteReplication><Status>Disabled</Status></DeleteReplication>",
+		"<SourceSelectionCriteria><ReplicaModifications><Status>Enabled</Status></ReplicaModifications></SourceSelectionCriteria>",
+		"<ExistingObjectReplication><Status>Enabled</Status></ExistingObjectReplication>",
+		"<And><Prefix>logs/</Prefix><Tag><Key>k</Key><Value>v</Value></Tag></And>",
+	} {
+		if !strings.Contains(string(b), elem) {
+			t.Fatalf("expected %s, got %s", elem, b)
+		}
+	}
+}
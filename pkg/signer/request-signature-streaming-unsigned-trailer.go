@@ -67,12 +67,19 @@ func getUSStreamLength(dataLen, chunkSize int64, trailers http.Header) int64 {
 // prepareStreamingRequest - prepares a request with appropriate
 // headers before computing the seed signature.
 func prepareUSStreamingRequest(req *http.Request, sessionToken string, dataLen int64, timestamp time.Time) {
-	req.TransferEncoding = []string{"aws-chunked"}
+	setAWSChunkedEncoding(req)
 	if sessionToken != "" {
 		req.Header.Set("X-Amz-Security-Token", sessionToken)
 	}
 
 	req.Header.Set("X-Amz-Date", timestamp.Format(iso8601DateFormat))
+	if dataLen < 0 {
+		// Unknown length, the chunks are sent until the end
+		// of the body with transfer-encoding: chunked.
+		req.ContentLength = -1
+		req.TransferEncoding = []string{"chunked"}
+		return
+	}
 	// Set content length with streaming signature for each chunk included.
 	req.ContentLength = getUSStreamLength(dataLen, int64(payloadChunkSize), req.Trailer)
 }
@@ -201,10 +208,16 @@ func (s *StreamingUSReader) Read(buf []byte) (int, error) {
 
 					// bytes read from baseReader different than
 					// content length provided.
-					if s.bytesRead != s.contentLen {
+					if s.contentLen >= 0 && s.bytesRead != s.contentLen {
 						return 0, fmt.Errorf("http: ContentLength=%d with Body length %d", s.contentLen, s.bytesRead)
 					}
 
+					// With an unknown length the last chunk is
+					// only known to be the last one at EOF.
+					if s.chunkBufLen > 0 {
+						s.writeChunk(s.chunkBufLen, true)
+					}
+
 					// Sign the chunk and write it to s.buf.
 					s.writeChunk(0, len(s.trailer) == 0)
 					if len(s.trailer) > 0 {
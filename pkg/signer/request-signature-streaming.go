@@ -133,14 +133,22 @@ func prepareStreamingRequest(req *http.Request, sessionToken string, dataLen int
 		for k := range req.Trailer {
 			req.Header.Add("X-Amz-Trailer", strings.ToLower(k))
 		}
-		req.TransferEncoding = []string{"aws-chunked"}
+		setAWSChunkedEncoding(req)
 	}
 
-	if sessionToken != "" {
+	// S3 Express sends its session token in x-amz-s3session-token.
+	if sessionToken != "" && req.Header.Get("x-amz-s3session-token") == "" {
 		req.Header.Set("X-Amz-Security-Token", sessionToken)
 	}
 
 	req.Header.Set("X-Amz-Date", timestamp.Format(iso8601DateFormat))
+	if dataLen < 0 {
+		// Unknown length, the signed chunks are sent until the
+		// end of the body with transfer-encoding: chunked.
+		req.ContentLength = -1
+		req.TransferEncoding = []string{"chunked"}
+		return
+	}
 	// Set content length with streaming signature for each chunk included.
 	req.ContentLength = getStreamLength(dataLen, int64(payloadChunkSize), req.Trailer)
 	req.Header.Set("x-amz-decoded-content-length", strconv.FormatInt(dataLen, 10))
@@ -424,10 +432,16 @@ func (s *StreamingReader) Read(buf []byte) (int, error) {
 
 					// bytes read from baseReader different than
 					// content length provided.
-					if s.bytesRead != s.contentLen {
+					if s.contentLen >= 0 && s.bytesRead != s.contentLen {
 						return 0, fmt.Errorf("http: ContentLength=%d with Body length %d", s.contentLen, s.bytesRead)
 					}
 
+					// With an unknown length the last chunk is
+					// only known to be the last one at EOF.
+					if s.chunkBufLen > 0 {
+						s.signChunk(s.chunkBufLen, true)
+					}
+
 					// Sign the chunk and write it to s.buf.
 					s.signChunk(0, len(s.trailer) == 0)
 					if len(s.trailer) > 0 {
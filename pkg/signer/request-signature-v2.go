@@ -210,15 +210,21 @@ func stringToSignV2(req http.Request, virtualHost bool) string {
 	return buf.String()
 }
 
-// writeSignV2Headers - write signV2 required headers.
+// writeSignV2Headers - write signV2 required headers. The Date is
+// left empty when X-Amz-Date is set, which is then signed with the
+// canonicalized headers instead.
 func writeSignV2Headers(buf *bytes.Buffer, req http.Request) {
 	buf.WriteString(req.Method + "\n")
 	buf.WriteString(req.Header.Get("Content-Md5") + "\n")
 	buf.WriteString(req.Header.Get("Content-Type") + "\n")
-	buf.WriteString(req.Header.Get("Date") + "\n")
+	if req.Header.Get("X-Amz-Date") == "" {
+		buf.WriteString(req.Header.Get("Date"))
+	}
+	buf.WriteString("\n")
 }
 
-// writeCanonicalizedHeaders - write canonicalized headers.
+// writeCanonicalizedHeaders - write canonicalized headers, the values
+// of the X-Amz-* headers trimmed and joined with commas.
 func writeCanonicalizedHeaders(buf *bytes.Buffer, req http.Request) {
 	var protoHeaders []string
 	vals := make(map[string][]string)
@@ -238,7 +244,7 @@ func writeCanonicalizedHeaders(buf *bytes.Buffer, req http.Request) {
 			if idx > 0 {
 				buf.WriteByte(',')
 			}
-			buf.WriteString(v)
+			buf.WriteString(strings.TrimSpace(v))
 		}
 		buf.WriteByte('\n')
 	}
@@ -301,8 +301,10 @@ func signV4(req http.Request, accessKeyID, secretAccessKey, sessionToken, locati
 			req.Header.Add("X-Amz-Trailer", strings.ToLower(k))
 		}
 
-		req.Header.Set("Content-Encoding", "aws-chunked")
-		req.Header.Set("x-amz-decoded-content-length", strconv.FormatInt(req.ContentLength, 10))
+		setAWSChunkedEncoding(&req)
+		if req.ContentLength >= 0 {
+			req.Header.Set("x-amz-decoded-content-length", strconv.FormatInt(req.ContentLength, 10))
+		}
 	}
 
 	hashedPayload := getHashedPayload(req)
@@ -365,8 +367,10 @@ func UnsignedTrailer(req http.Request, trailer http.Header) *http.Request {
 		req.Header.Add("X-Amz-Trailer", strings.ToLower(k))
 	}
 
-	req.Header.Set("Content-Encoding", "aws-chunked")
-	req.Header.Set("x-amz-decoded-content-length", strconv.FormatInt(req.ContentLength, 10))
+	setAWSChunkedEncoding(&req)
+	if req.ContentLength >= 0 {
+		req.Header.Set("x-amz-decoded-content-length", strconv.FormatInt(req.ContentLength, 10))
+	}
 
 	// Use custom chunked encoding.
 	req.Trailer = trailer
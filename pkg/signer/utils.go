@@ -63,3 +63,17 @@ func signV4TrimAll(input string) string {
 	// unicode.IsSpace() internally here) to one space and return
 	return strings.Join(strings.Fields(input), " ")
 }
+
+// setAWSChunkedEncoding sets Content-Encoding to aws-chunked for a body
+// sent as signed or unsigned chunks followed by trailers, keeping the
+// content encoding of the object, e.g. "aws-chunked,gzip". S3 removes
+// aws-chunked before storing the object.
+func setAWSChunkedEncoding(req *http.Request) {
+	contentEncoding := req.Header.Get("Content-Encoding")
+	switch {
+	case contentEncoding == "":
+		req.Header.Set("Content-Encoding", "aws-chunked")
+	case !strings.HasPrefix(contentEncoding, "aws-chunked"):
+		req.Header.Set("Content-Encoding", "aws-chunked,"+contentEncoding)
+	}
+}
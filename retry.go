@@ -143,6 +143,15 @@ func isRequestErrorRetryable(ctx context.Context, err error) bool {
 		// Retry if internal timeout in the HTTP call.
 		return ctx.Err() == nil
 	}
+	if errors.As(err, &interceptorError{}) {
+		// Failed by a response interceptor.
+		return false
+	}
+	if errors.As(err, &ErrorResponse{}) {
+		// Failed reading a request body streamed from another
+		// request, e.g. by CopyObjectCrossClient, retried already.
+		return false
+	}
 	if ue, ok := err.(*url.Error); ok {
 		e := ue.Unwrap()
 		switch e.(type) {
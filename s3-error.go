@@ -54,6 +54,7 @@ const (
 	InvalidPartOrder                  = "InvalidPartOrder"
 	InvalidObjectState                = "InvalidObjectState"
 	AuthorizationHeaderMalformed      = "AuthorizationHeaderMalformed"
+	PermanentRedirect                 = "PermanentRedirect"
 	MalformedPOSTRequest              = "MalformedPOSTRequest"
 	BucketNotEmpty                    = "BucketNotEmpty"
 	AllAccessDisabled                 = "AllAccessDisabled"
@@ -69,8 +70,12 @@ const (
 	BucketAlreadyExists               = "BucketAlreadyExists"
 	NoSuchVersion                     = "NoSuchVersion"
 	NoSuchTagSet                      = "NoSuchTagSet"
+	OwnershipControlsNotFoundError    = "OwnershipControlsNotFoundError"
 	Testing                           = "Testing"
 	Success                           = "Success"
+
+	NoSuchPublicAccessBlockConfiguration = "NoSuchPublicAccessBlockConfiguration"
+	InvalidWriteOffset                   = "InvalidWriteOffset"
 )
 
 // Non exhaustive list of AWS S3 standard error responses -
@@ -103,6 +108,7 @@ var s3ErrorResponseMap = map[string]string{
 	InvalidPartOrder:                  "The list of parts was not in ascending order. The parts list must be specified in order by part number.",
 	InvalidObjectState:                "The operation is not valid for the current state of the object.",
 	AuthorizationHeaderMalformed:      "The authorization header is malformed; the region is wrong.",
+	PermanentRedirect:                 "The bucket you are attempting to access must be addressed using the specified endpoint.",
 	MalformedPOSTRequest:              "The body of your POST request is not well-formed multipart/form-data.",
 	BucketNotEmpty:                    "The bucket you tried to delete is not empty.",
 	AllAccessDisabled:                 "All access to this bucket has been disabled.",
@@ -114,6 +120,10 @@ var s3ErrorResponseMap = map[string]string{
 	InvalidDuration:                   "Duration provided in the request is invalid.",
 	XAmzContentSHA256Mismatch:         "The provided 'x-amz-content-sha256' header does not match what was computed.",
 	NoSuchCORSConfiguration:           "The specified bucket does not have a CORS configuration.",
+	OwnershipControlsNotFoundError:    "The bucket ownership controls were not found.",
 	Conflict:                          "Bucket not empty.",
+
+	NoSuchPublicAccessBlockConfiguration: "The public access block configuration was not found.",
+	InvalidWriteOffset:                   "The write offset value that you specified does not match the current object size.",
 	// Add new API errors here.
 }
@@ -25,7 +25,11 @@ import (
 	"encoding/xml"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strconv"
+	"testing"
+
+	"github.com/openstor/openstor-go/v7/pkg/credentials"
 )
 
 // Contains common used utilities for tests.
@@ -76,3 +80,20 @@ func mustParseBool(str string) bool {
 	}
 	return b
 }
+
+// newTestClient starts a test server serving handler, closed once the
+// test completes, and returns a client signing its requests to it.
+func newTestClient(t *testing.T, handler http.Handler) (*Client, *httptest.Server) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	clnt, err := New(srv.Listener.Addr().String(), &Options{
+		Creds:  credentials.NewStaticV4("accessKey", "secretKey", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return clnt, srv
+}
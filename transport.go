@@ -24,8 +24,11 @@
 package openstor
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"net"
 	"net/http"
 	"os"
@@ -84,3 +87,26 @@ var DefaultTransport = func(secure bool) (*http.Transport, error) {
 	}
 	return tr, nil
 }
+
+// verifyCertificatePins returns a tls.Config VerifyConnection function
+// rejecting connections whose leaf certificate public key does not
+// match one of the SHA256 pins, after calling next if it is not nil.
+func verifyCertificatePins(pins [][]byte, next func(tls.ConnectionState) error) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if next != nil {
+			if err := next(cs); err != nil {
+				return err
+			}
+		}
+		if len(cs.PeerCertificates) == 0 {
+			return errors.New("no certificate presented by " + cs.ServerName)
+		}
+		sum := sha256.Sum256(cs.PeerCertificates[0].RawSubjectPublicKeyInfo)
+		for _, pin := range pins {
+			if bytes.Equal(pin, sum[:]) {
+				return nil
+			}
+		}
+		return errors.New("certificate of " + cs.ServerName + " does not match the certificate pins")
+	}
+}
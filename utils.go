@@ -38,6 +38,7 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -116,6 +117,21 @@ func getEndpointURL(endpoint string, secure bool) (*url.URL, error) {
 		scheme = "http"
 	}
 
+	// Endpoint may optionally carry a scheme, strip it after
+	// making sure it does not conflict with the secure flag.
+	if inScheme, rest, ok := strings.Cut(endpoint, "://"); ok {
+		switch strings.ToLower(inScheme) {
+		case "http", "https":
+		default:
+			return nil, errInvalidArgument(fmt.Sprintf("Endpoint scheme ‘%s’ is not supported, use ‘http’ or ‘https’.", inScheme))
+		}
+		if !strings.EqualFold(inScheme, scheme) {
+			return nil, errInvalidArgument(fmt.Sprintf("Endpoint scheme ‘%s’ conflicts with Secure: %t, either use ‘%s://’ or set Secure to %t.",
+				inScheme, secure, scheme, !secure))
+		}
+		endpoint = rest
+	}
+
 	// Construct a secured endpoint URL.
 	endpointURLStr := scheme + "://" + endpoint
 	endpointURL, err := url.Parse(endpointURLStr)
@@ -185,14 +201,15 @@ func isValidEndpointURL(endpointURL url.URL) error {
 	return nil
 }
 
-// Verify if input expires value is valid.
+// Verify if input expires value is valid, the returned
+// errors match ErrInvalidExpiry.
 func isValidExpiry(expires time.Duration) error {
 	expireSeconds := int64(expires / time.Second)
 	if expireSeconds < 1 {
-		return errInvalidArgument("Expires cannot be lesser than 1 second.")
+		return errInvalidArgumentMatching(ErrInvalidExpiry, expiryTooShortMessage)
 	}
 	if expireSeconds > 604800 {
-		return errInvalidArgument("Expires cannot be greater than 7 days.")
+		return errInvalidArgumentMatching(ErrInvalidExpiry, expiryTooLongMessage)
 	}
 	return nil
 }
@@ -294,6 +311,16 @@ func ToObjectInfo(bucketName, objectName string, h http.Header) (ObjectInfo, err
 		}
 	}
 
+	// Parse the size of the whole object for range requests.
+	fullSize := size
+	if contentRange := h.Get("Content-Range"); contentRange != "" {
+		// The ranged read itself is valid, report an unparsable
+		// length as unknown rather than failing it.
+		if fullSize, err = parseContentRangeSize(contentRange); err != nil {
+			fullSize = -1
+		}
+	}
+
 	// Parse Last-Modified has http time format.
 	mtime, err := parseRFC7231Time(h.Get("Last-Modified"))
 	if err != nil {
@@ -331,6 +358,17 @@ func ToObjectInfo(bucketName, objectName string, h http.Header) (ObjectInfo, err
 		}
 	}
 
+	// Copied before extractObjMetadata decodes the values in place.
+	var userMetadataRaw http.Header
+	for k, v := range h {
+		if len(k) > len("X-Amz-Meta-") && strings.EqualFold(k[:len("X-Amz-Meta-")], "X-Amz-Meta-") {
+			if userMetadataRaw == nil {
+				userMetadataRaw = make(http.Header)
+			}
+			userMetadataRaw[k] = slices.Clone(v)
+		}
+	}
+
 	metadata := extractObjMetadata(h)
 	userMetadata := make(map[string]string)
 	for k, v := range metadata {
@@ -362,6 +400,22 @@ func ToObjectInfo(bucketName, objectName string, h http.Header) (ObjectInfo, err
 		}
 	}
 
+	var partsCount int
+	if count := h.Get(amzPartsCount); count != "" {
+		partsCount, err = strconv.Atoi(count)
+		if err != nil {
+			return ObjectInfo{}, ErrorResponse{
+				Code:       InternalError,
+				Message:    fmt.Sprintf("x-amz-mp-parts-count is not an integer, failed with %v", err),
+				BucketName: bucketName,
+				Key:        objectName,
+				RequestID:  h.Get("x-amz-request-id"),
+				HostID:     h.Get("x-amz-id-2"),
+				Region:     h.Get("x-amz-bucket-region"),
+			}
+		}
+	}
+
 	// Nil if not found
 	var restore *RestoreInfo
 	if restoreHdr := h.Get(amzRestore); restoreHdr != "" {
@@ -382,8 +436,10 @@ func ToObjectInfo(bucketName, objectName string, h http.Header) (ObjectInfo, err
 		ETag:              etag,
 		Key:               objectName,
 		Size:              size,
+		FullSize:          fullSize,
 		LastModified:      mtime,
 		ContentType:       contentType,
+		ContentEncoding:   h.Get("Content-Encoding"),
 		Expires:           expiry,
 		VersionID:         h.Get(amzVersionID),
 		IsDeleteMarker:    deleteMarker,
@@ -393,11 +449,14 @@ func ToObjectInfo(bucketName, objectName string, h http.Header) (ObjectInfo, err
 		// Extract only the relevant header keys describing the object.
 		// following function filters out a list of standard set of keys
 		// which are not part of object metadata.
-		Metadata:     metadata,
-		UserMetadata: userMetadata,
-		UserTags:     userTags.ToMap(),
-		UserTagCount: tagCount,
-		Restore:      restore,
+		Metadata:        metadata,
+		UserMetadata:    userMetadata,
+		UserMetadataRaw: userMetadataRaw,
+		UserTags:        userTags.ToMap(),
+		UserTagCount:    tagCount,
+		Restore:         restore,
+		PartsCount:      partsCount,
+		RequestCharged:  h.Get(amzRequestCharged) == requestPayerRequester,
 
 		// Checksum values
 		ChecksumCRC32:     h.Get(ChecksumCRC32.Key()),
@@ -409,6 +468,20 @@ func ToObjectInfo(bucketName, objectName string, h http.Header) (ObjectInfo, err
 	}, nil
 }
 
+// parseContentRangeSize returns the complete length of a
+// `bytes <first>-<last>/<length>` Content-Range, -1 if it is `*`.
+func parseContentRangeSize(contentRange string) (int64, error) {
+	rangeSpec, ok := strings.CutPrefix(contentRange, "bytes ")
+	_, length, found := strings.Cut(rangeSpec, "/")
+	if !ok || !found {
+		return 0, fmt.Errorf("unexpected Content-Range %q", contentRange)
+	}
+	if length == "*" {
+		return -1, nil
+	}
+	return strconv.ParseInt(length, 10, 64)
+}
+
 var readFull = func(r io.Reader, buf []byte) (n int, err error) {
 	// ReadFull reads exactly len(buf) bytes from r into buf.
 	// It returns the number of bytes copied and an error if
@@ -133,6 +133,12 @@ func TestGetEndpointURL(t *testing.T) {
 		{"13333.123123.-", true, "", errInvalidArgument(fmt.Sprintf("Endpoint: %s does not follow ip address or domain name standards.", "13333.123123.-")), false},
 		{"s3.aamzza.-", true, "", errInvalidArgument(fmt.Sprintf("Endpoint: %s does not follow ip address or domain name standards.", "s3.aamzza.-")), false},
 		{"", true, "", errInvalidArgument("Endpoint:  does not follow ip address or domain name standards."), false},
+		{"https://s3.amazonaws.com", true, "https://s3.amazonaws.com", nil, true},
+		{"HTTP://192.168.1.1:9000", false, "http://192.168.1.1:9000", nil, true},
+		{"http://192.168.1.1:9000/", false, "http://192.168.1.1:9000/", nil, true},
+		{"http://192.168.1.1:9000", true, "", errInvalidArgument("Endpoint scheme ‘http’ conflicts with Secure: true, either use ‘https://’ or set Secure to false."), false},
+		{"https://192.168.1.1:9000", false, "", errInvalidArgument("Endpoint scheme ‘https’ conflicts with Secure: false, either use ‘http://’ or set Secure to true."), false},
+		{"ftp://192.168.1.1:9000", false, "", errInvalidArgument("Endpoint scheme ‘ftp’ is not supported, use ‘http’ or ‘https’."), false},
 	}
 
 	for i, testCase := range testCases {
@@ -276,10 +282,12 @@ func TestIsValidExpiry(t *testing.T) {
 		// Flag to indicate whether the test should pass.
 		shouldPass bool
 	}{
-		{100 * time.Millisecond, errInvalidArgument("Expires cannot be lesser than 1 second."), false},
-		{604801 * time.Second, errInvalidArgument("Expires cannot be greater than 7 days."), false},
-		{0 * time.Second, errInvalidArgument("Expires cannot be lesser than 1 second."), false},
+		{100 * time.Millisecond, errInvalidArgumentMatching(ErrInvalidExpiry, expiryTooShortMessage), false},
+		{604801 * time.Second, errInvalidArgumentMatching(ErrInvalidExpiry, expiryTooLongMessage), false},
+		{0 * time.Second, errInvalidArgumentMatching(ErrInvalidExpiry, expiryTooShortMessage), false},
+		{-1 * time.Hour, errInvalidArgumentMatching(ErrInvalidExpiry, expiryTooShortMessage), false},
 		{1 * time.Second, nil, true},
+		{604800 * time.Second, nil, true},
 		{10000 * time.Second, nil, true},
 		{999 * time.Second, nil, true},
 	}
@@ -297,8 +305,16 @@ func TestIsValidExpiry(t *testing.T) {
 			if err.Error() != testCase.err.Error() {
 				t.Errorf("Test %d: Expected to fail with error \"%s\", but instead failed with error \"%s\" instead", i+1, testCase.err.Error(), err.Error())
 			}
+			if !errors.Is(err, ErrInvalidExpiry) {
+				t.Errorf("Test %d: Expected error \"%s\" to match ErrInvalidExpiry", i+1, err.Error())
+			}
 		}
 	}
+
+	// Server errors with the same message are not client validation errors.
+	if errors.Is(errInvalidArgument(expiryTooShortMessage), ErrInvalidExpiry) {
+		t.Error("Expected a server error not to match ErrInvalidExpiry")
+	}
 }
 
 // Tests validate the bucket name validator.
@@ -545,3 +561,91 @@ func TestExtractObjMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestToObjectInfoUserMetadataRaw(t *testing.T) {
+	encoded := mime.QEncoding.Encode("UTF-8", "öha")
+	h := http.Header{
+		"Last-Modified":    []string{time.Now().UTC().Format(http.TimeFormat)},
+		"Content-Length":   []string{"0"},
+		"X-Amz-Meta-Owner": []string{"alice", "bob"},
+		"X-Amz-Meta-Note":  []string{encoded},
+		// Set by a transport preserving the casing of the server.
+		"x-amz-meta-AppKey": []string{"value"},
+		"X-Amz-Meta-":       []string{"empty"},
+		"Content-Type":      []string{"text/plain"},
+	}
+	info, err := ToObjectInfo("bucket", "object", h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := http.Header{
+		"X-Amz-Meta-Owner":  []string{"alice", "bob"},
+		"X-Amz-Meta-Note":   []string{encoded},
+		"x-amz-meta-AppKey": []string{"value"},
+	}
+	if !reflect.DeepEqual(info.UserMetadataRaw, want) {
+		t.Fatalf("unexpected raw user metadata %v, want %v", info.UserMetadataRaw, want)
+	}
+	if info.UserMetadata["Owner"] != "alice" || info.UserMetadata["Note"] != "öha" {
+		t.Fatalf("unexpected user metadata %v", info.UserMetadata)
+	}
+}
+
+func TestToObjectInfoUserTagCount(t *testing.T) {
+	testCases := []struct {
+		count    string
+		expected int
+		success  bool
+	}{
+		{"", 0, true},
+		{"0", 0, true},
+		{"3", 3, true},
+		{"many", 0, false},
+	}
+	for i, testCase := range testCases {
+		h := http.Header{
+			"Last-Modified":  []string{time.Now().UTC().Format(http.TimeFormat)},
+			"Content-Length": []string{"0"},
+		}
+		if testCase.count != "" {
+			h.Set(amzTaggingCount, testCase.count)
+		}
+		info, err := ToObjectInfo("bucket", "object", h)
+		if !testCase.success {
+			if ToErrorResponse(err).Code != InternalError {
+				t.Fatalf("Test %d: expected the tag count to be rejected, got %v", i+1, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if info.UserTagCount != testCase.expected {
+			t.Fatalf("Test %d: expected %d tags, got %d", i+1, testCase.expected, info.UserTagCount)
+		}
+	}
+}
+
+func TestParseContentRangeSize(t *testing.T) {
+	testCases := []struct {
+		contentRange string
+		size         int64
+		success      bool
+	}{
+		{"bytes 0-99/1234", 1234, true},
+		{"bytes 0-99/*", -1, true},
+		{"bytes */1234", 1234, true},
+		{"bytes 0-99", 0, false},
+		{"items 0-99/1234", 0, false},
+		{"bytes 0-99/big", 0, false},
+	}
+	for i, testCase := range testCases {
+		size, err := parseContentRangeSize(testCase.contentRange)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if testCase.success && size != testCase.size {
+			t.Fatalf("Test %d: expected %d, got %d", i+1, testCase.size, size)
+		}
+	}
+}